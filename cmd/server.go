@@ -11,6 +11,7 @@ import (
 	"os/signal"
 	"time"
 
+	"todoapp/internal/database"
 	"todoapp/internal/migrations"
 	"todoapp/internal/models"
 	"todoapp/internal/server"
@@ -31,18 +32,21 @@ func Run(c context.Context, _ io.Writer, _ []string) error {
 
 	server.SetupRoutes(ctx, app)
 
-	if err = migrations.RunMigrations(ctx, app, app.MigrationMethod); err != nil {
+	if err = runMigrations(ctx, app); err != nil {
 		slog.LogAttrs(c, slog.LevelError, "error while running migrations",
 			slog.String("error", err.Error()))
 
 		return err
 	}
 
+	go app.RunTrashPurge(ctx)
+	go app.RunReminders(ctx)
+
 	srvErr := make(chan error, 1)
 
 	httpServer := &http.Server{
 		Addr:         net.JoinHostPort(app.Host, app.Port),
-		Handler:      app.GlobalRateLimiter(app.Mux),
+		Handler:      app.GlobalRateLimiter(app.ValidateOpenAPI(app.Mux)),
 		ReadTimeout:  time.Duration(app.ReadTimeout * int(time.Second)),
 		WriteTimeout: time.Duration(app.WriteTimeout * int(time.Second)),
 		IdleTimeout:  time.Duration(app.IdleTimeout * int(time.Second)),
@@ -67,6 +71,17 @@ func Run(c context.Context, _ io.Writer, _ []string) error {
 	return nil
 }
 
+// runMigrations skips the migrations in memory mode as there is no database to migrate
+func runMigrations(ctx context.Context, app *server.Server) error {
+	if app.DBDriver == database.DriverMemory {
+		app.Logger.LogAttrs(ctx, slog.LevelInfo, "in-memory store selected, skipping migrations")
+
+		return nil
+	}
+
+	return migrations.RunMigrations(ctx, app, app.MigrationMethod)
+}
+
 func checkForTrigger(ctx context.Context, app *server.Server, srvErr chan error) error {
 	var err error
 
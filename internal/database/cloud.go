@@ -0,0 +1,145 @@
+package database
+
+import (
+	"sync"
+
+	"todoapp/internal/models"
+
+	"github.com/sqlitecloud/sqlitecloud-go"
+)
+
+// cloudDB talks to SQLite Cloud over a single connection. A transaction holds the
+// connection lock until it ends, so no other query can slip into it.
+type cloudDB struct {
+	*sqlitecloud.SQCloud
+	mu sync.Mutex
+}
+
+type cloudTx struct {
+	db   *cloudDB
+	once sync.Once
+}
+
+func openCloud(cfg *Config) (*cloudDB, error) {
+	sqcl := sqlitecloud.New(sqlitecloud.SQCloudConfig{
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Database: cfg.Database,
+		ApiKey:   cfg.APIKey,
+		MaxRows:  cfg.MaxRows,
+		Secure:   cfg.Secure,
+	})
+
+	if err := sqcl.Connect(); err != nil {
+		return nil, err
+	}
+
+	if !sqcl.IsConnected() {
+		return nil, models.NewConstError("database is not connected after conn success")
+	}
+
+	return &cloudDB{SQCloud: sqcl}, nil
+}
+
+func (*cloudDB) Driver() string {
+	return DriverSQLiteCloud
+}
+
+func (c *cloudDB) Execute(query string, args ...any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.execute(query, args)
+}
+
+func (c *cloudDB) Select(query string, args ...any) (Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.selectRows(query, args)
+}
+
+func (c *cloudDB) Ping() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.SQCloud.Ping()
+}
+
+func (c *cloudDB) Begin() (Tx, error) {
+	c.mu.Lock()
+
+	if err := c.BeginTransaction(); err != nil {
+		c.mu.Unlock()
+
+		return nil, err
+	}
+
+	return &cloudTx{db: c}, nil
+}
+
+func (c *cloudDB) execute(query string, args []any) error {
+	values, err := bindArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return c.SQCloud.Execute(query)
+	}
+
+	return c.ExecuteArray(query, values)
+}
+
+func (c *cloudDB) selectRows(query string, args []any) (Result, error) {
+	values, err := bindArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *sqlitecloud.Result
+
+	if len(values) == 0 {
+		res, err = c.SQCloud.Select(query)
+	} else {
+		res, err = c.SelectArray(query, values)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (*cloudTx) Driver() string {
+	return DriverSQLiteCloud
+}
+
+func (t *cloudTx) Execute(query string, args ...any) error {
+	return t.db.execute(query, args)
+}
+
+func (t *cloudTx) Select(query string, args ...any) (Result, error) {
+	return t.db.selectRows(query, args)
+}
+
+func (t *cloudTx) Commit() error {
+	return t.end(t.db.EndTransaction)
+}
+
+func (t *cloudTx) Rollback() error {
+	return t.end(t.db.RollBackTransaction)
+}
+
+// end runs fn and releases the connection, only the first Commit or Rollback has any effect
+func (t *cloudTx) end(fn func() error) error {
+	var err error = errTxDone
+
+	t.once.Do(func() {
+		err = fn()
+		t.db.mu.Unlock()
+	})
+
+	return err
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"todoapp/internal/models"
+)
+
+const (
+	DriverSQLiteCloud = "sqlitecloud"
+	DriverSQLite      = "sqlite"
+	DriverPostgres    = "postgres"
+	// DriverMemory opens no database at all, the stores keep everything in process memory
+	DriverMemory = "memory"
+)
+
+// Querier runs the statements, it is implemented by both DB and Tx.
+// Values are never formatted into the query text, they are passed as args and bound to '?' placeholders.
+type Querier interface {
+	Driver() string
+	Execute(query string, args ...any) error
+	Select(query string, args ...any) (Result, error)
+}
+
+// DB is the storage driver used by the stores and the migrations, every backend has to implement it
+type DB interface {
+	Querier
+	Begin() (Tx, error)
+	Ping() error
+	IsConnected() bool
+	Close() error
+}
+
+// Tx is a database transaction, it has to be ended with either Commit or Rollback
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// Result is a fully read row set returned by DB.Select
+type Result interface {
+	GetNumberOfRows() uint64
+	GetStringValue(row, col uint64) (string, error)
+	GetInt64Value(row, col uint64) (int64, error)
+	// nolint:revive // keeping the same name as sqlitecloud.Result
+	GetInt64Value_(row, col uint64) int64
+}
+
+type Config struct {
+	Driver   string
+	Host     string
+	Port     int
+	Database string
+	APIKey   string
+	MaxRows  int
+	Secure   bool
+	Path     string
+	URL      string
+	MaxConns int
+}
+
+// Open connects to the storage backend selected by cfg.Driver
+func Open(cfg *Config) (DB, error) {
+	switch cfg.Driver {
+	case DriverSQLiteCloud, "":
+		return openCloud(cfg)
+	case DriverSQLite:
+		return openLocal(cfg)
+	case DriverPostgres:
+		return openPostgres(cfg)
+	default:
+		return nil, models.ErrInvalid("database driver")
+	}
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+
+	_ "modernc.org/sqlite" // registers the pure go "sqlite" driver
+)
+
+const (
+	defaultLocalPath = "todo.db"
+	localDriverName  = "sqlite"
+	localPragmas     = "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+)
+
+// openLocal opens a file backed SQLite database. SQLite allows a single writer, so the pool is
+// limited to one connection and concurrent queries wait for it instead of failing as busy.
+func openLocal(cfg *Config) (*sqlDB, error) {
+	path := strings.TrimSpace(cfg.Path)
+	if path == "" {
+		path = defaultLocalPath
+	}
+
+	db, err := sql.Open(localDriverName, "file:"+path+localPragmas)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+
+	return newSQLDB(db, DriverSQLite, func(query string) string { return query })
+}
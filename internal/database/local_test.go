@@ -0,0 +1,81 @@
+package database
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"todoapp/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalDB(t *testing.T) DB {
+	t.Helper()
+
+	db, err := Open(&Config{Driver: DriverSQLite, Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func TestOpen(t *testing.T) {
+	_, err := Open(&Config{Driver: "mysql"})
+	if !errors.Is(err, models.ErrInvalid("database driver")) {
+		t.Errorf("Open() error = %v, wantErr %v", err, models.ErrInvalid("database driver"))
+	}
+
+	db := newTestLocalDB(t)
+
+	assert.True(t, db.IsConnected())
+	assert.NoError(t, db.Ping())
+}
+
+func TestLocalSelect(t *testing.T) {
+	db := newTestLocalDB(t)
+
+	require.NoError(t, db.Execute("CREATE TABLE t(id TEXT, n INTEGER, d DATETIME);"))
+	require.NoError(t, db.Execute("INSERT INTO t VALUES ('a', 1, 1718000000000), ('b', 2, NULL);"))
+
+	res, err := db.Select("SELECT id, n, d FROM t ORDER BY id;")
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(2), res.GetNumberOfRows())
+
+	id, err := res.GetStringValue(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "a", id)
+
+	n, err := res.GetInt64Value(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	assert.Equal(t, int64(1718000000000), res.GetInt64Value_(0, 2))
+	assert.Equal(t, int64(0), res.GetInt64Value_(1, 2))
+
+	_, err = res.GetStringValue(2, 0)
+	assert.Error(t, err)
+}
+
+func TestLocalTransaction(t *testing.T) {
+	db := newTestLocalDB(t)
+
+	require.NoError(t, db.Execute("CREATE TABLE t(id TEXT);"))
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, tx.Execute("INSERT INTO t VALUES ('a');"))
+	require.NoError(t, tx.Rollback())
+
+	tx, err = db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, tx.Execute("INSERT INTO t VALUES ('b');"))
+	require.NoError(t, tx.Commit())
+
+	res, err := db.Select("SELECT id FROM t;")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), res.GetNumberOfRows())
+}
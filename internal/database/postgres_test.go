@@ -0,0 +1,77 @@
+package database
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPostgresEnv points the postgres tests to a running server, e.g. a local container:
+// docker run --rm -p 5432:5432 -e POSTGRES_PASSWORD=postgres postgres:16
+const testPostgresEnv = "TEST_POSTGRES_URL"
+
+func TestRebindDollar(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "no placeholder", query: "SELECT 1;", want: "SELECT 1;"},
+		{name: "placeholders", query: "UPDATE t SET a=?, b=? WHERE id=?;", want: "UPDATE t SET a=$1, b=$2 WHERE id=$3;"},
+		{name: "quoted question mark", query: "SELECT '?', \"a?\" FROM t WHERE id=?;",
+			want: "SELECT '?', \"a?\" FROM t WHERE id=$1;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rebindDollar(tt.query))
+		})
+	}
+}
+
+func TestPostgres(t *testing.T) {
+	url := os.Getenv(testPostgresEnv)
+	if url == "" {
+		t.Skipf("%s not set, skipping postgres tests", testPostgresEnv)
+	}
+
+	_, err := Open(&Config{Driver: DriverPostgres})
+	assert.Error(t, err)
+
+	db, err := Open(&Config{Driver: DriverPostgres, URL: url})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Execute("DROP TABLE IF EXISTS db_test;")
+		_ = db.Close()
+	})
+
+	require.NoError(t, db.Execute("CREATE TABLE IF NOT EXISTS db_test(id TEXT, n BIGINT, done SMALLINT);"))
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, tx.Execute("INSERT INTO db_test VALUES (?, ?, ?);", "a", 1, true))
+	require.NoError(t, tx.Rollback())
+
+	tx, err = db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, tx.Execute("INSERT INTO db_test VALUES (?, ?, ?);", "Bob's", 2, false))
+	require.NoError(t, tx.Commit())
+
+	res, err := db.Select("SELECT id, n, done FROM db_test WHERE id=?;", "Bob's")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.GetNumberOfRows())
+
+	var (
+		id   string
+		n    int64
+		done bool
+	)
+
+	require.NoError(t, ScanRow(res, 0, &id, &n, &done))
+	assert.Equal(t, "Bob's", id)
+	assert.Equal(t, int64(2), n)
+	assert.False(t, done)
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"encoding"
+	"fmt"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// bindArgs converts the query arguments into the plain types every driver can bind to a '?'
+// placeholder. Times are stored as unix milliseconds, bools as 0/1 and uuids as text.
+// nolint:gocyclo // one case per supported argument type
+func bindArgs(args []any) ([]any, error) {
+	res := make([]any, len(args))
+
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case nil, string, []byte, int64, float64:
+			res[i] = v
+		case int:
+			res[i] = int64(v)
+		case int32:
+			res[i] = int64(v)
+		case bool:
+			res[i] = boolToInt(v)
+		case uuid.UUID:
+			res[i] = v.String()
+		case *uuid.UUID:
+			if v != nil {
+				res[i] = v.String()
+			}
+		case time.Time:
+			res[i] = v.UnixMilli()
+		case *time.Time:
+			if v != nil {
+				res[i] = v.UnixMilli()
+			}
+		case fmt.Stringer:
+			res[i] = v.String()
+		default:
+			return nil, models.ErrInvalid(fmt.Sprintf("query argument type %T", arg))
+		}
+	}
+
+	return res, nil
+}
+
+// ScanRow reads the columns of the given row into dest, in select order.
+// Supported destinations: *string, *int64, *int, *bool, *uuid.UUID, *time.Time, *(*time.Time) and
+// encoding.TextUnmarshaler, *(*time.Time) is set to nil when the stored value is NULL or 0.
+func ScanRow(res Result, row uint64, dest ...any) error {
+	for i, d := range dest {
+		col := uint64(i)
+
+		if err := scanColumn(res, row, col, d); err != nil {
+			return fmt.Errorf("column %d: %w", col, err)
+		}
+	}
+
+	return nil
+}
+
+// nolint:gocyclo // one case per supported destination type
+func scanColumn(res Result, row, col uint64, dest any) error {
+	switch d := dest.(type) {
+	case *string:
+		v, err := res.GetStringValue(row, col)
+		if err != nil {
+			return err
+		}
+
+		*d = v
+	case *uuid.UUID:
+		v, err := res.GetStringValue(row, col)
+		if err != nil {
+			return err
+		}
+
+		if *d, err = uuid.Parse(v); err != nil {
+			return err
+		}
+	case *int64:
+		v, err := res.GetInt64Value(row, col)
+		if err != nil {
+			return err
+		}
+
+		*d = v
+	case *int:
+		v, err := res.GetInt64Value(row, col)
+		if err != nil {
+			return err
+		}
+
+		*d = int(v)
+	case *bool:
+		v, err := res.GetInt64Value(row, col)
+		if err != nil {
+			return err
+		}
+
+		*d = v == 1
+	case *time.Time:
+		v, err := res.GetInt64Value(row, col)
+		if err != nil {
+			return err
+		}
+
+		*d = time.UnixMilli(v)
+	case **time.Time:
+		*d = nil
+
+		if v := res.GetInt64Value_(row, col); v != 0 {
+			t := time.UnixMilli(v)
+			*d = &t
+		}
+	case encoding.TextUnmarshaler:
+		v, err := res.GetStringValue(row, col)
+		if err != nil {
+			return err
+		}
+
+		return d.UnmarshalText([]byte(v))
+	default:
+		return models.ErrInvalid(fmt.Sprintf("scan destination type %T", dest))
+	}
+
+	return nil
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindArgs(t *testing.T) {
+	id := uuid.New()
+	now := time.Now()
+
+	var nilTime *time.Time
+
+	got, err := bindArgs([]any{"a", 1, true, false, id, &id, now, &now, nilTime})
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{"a", int64(1), int64(1), int64(0), id.String(), id.String(),
+		now.UnixMilli(), now.UnixMilli(), nil}, got)
+
+	_, err = bindArgs([]any{struct{}{}})
+	assert.Error(t, err)
+}
+
+func TestScanRow(t *testing.T) {
+	db := newTestLocalDB(t)
+	id := uuid.New()
+	now := time.UnixMilli(time.Now().UnixMilli())
+	title := "Bob's report'); DROP TABLE t; --"
+
+	require.NoError(t, db.Execute("CREATE TABLE t(id TEXT, title TEXT, done BOOLEAN, at DATETIME, due DATE);"))
+	require.NoError(t, db.Execute("INSERT INTO t VALUES (?, ?, ?, ?, ?);", id, title, true, now, nil))
+
+	res, err := db.Select("SELECT id, title, done, at, due FROM t WHERE title=?;", title)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.GetNumberOfRows())
+
+	var (
+		gotID    uuid.UUID
+		gotTitle string
+		done     bool
+		at       time.Time
+		due      *time.Time
+	)
+
+	require.NoError(t, ScanRow(res, 0, &gotID, &gotTitle, &done, &at, &due))
+
+	assert.Equal(t, id, gotID)
+	assert.Equal(t, title, gotTitle)
+	assert.True(t, done)
+	assert.True(t, now.Equal(at))
+	assert.Nil(t, due)
+
+	assert.Error(t, ScanRow(res, 0, &struct{}{}))
+}
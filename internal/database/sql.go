@@ -0,0 +1,212 @@
+package database
+
+import (
+	"database/sql"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"todoapp/internal/models"
+)
+
+// sqlDB runs the queries through database/sql, it is shared by the local SQLite and the Postgres drivers
+type sqlDB struct {
+	sqlQuerier
+	db     *sql.DB
+	closed atomic.Bool
+}
+
+// sqlTx is a transaction started by sqlDB.Begin
+type sqlTx struct {
+	sqlQuerier
+	tx *sql.Tx
+}
+
+// sqlQuerier binds the args and rewrites the placeholders before handing the query to a *sql.DB or *sql.Tx
+type sqlQuerier struct {
+	conn   execQuerier
+	driver string
+	rebind func(query string) string
+}
+
+type execQuerier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func newSQLDB(db *sql.DB, driver string, rebind func(string) string) (*sqlDB, error) {
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return &sqlDB{sqlQuerier: sqlQuerier{conn: db, driver: driver, rebind: rebind}, db: db}, nil
+}
+
+func (q *sqlQuerier) Driver() string {
+	return q.driver
+}
+
+func (q *sqlQuerier) Execute(query string, args ...any) error {
+	values, err := bindArgs(args)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.conn.Exec(q.rebind(query), values...)
+
+	return err
+}
+
+func (q *sqlQuerier) Select(query string, args ...any) (Result, error) {
+	values, err := bindArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.conn.Query(q.rebind(query), values...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &rowsResult{}
+
+	for rows.Next() {
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		res.rows = append(res.rows, vals)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (l *sqlDB) Begin() (Tx, error) {
+	tx, err := l.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlTx{sqlQuerier: sqlQuerier{conn: tx, driver: l.driver, rebind: l.rebind}, tx: tx}, nil
+}
+
+func (t *sqlTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqlTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func (l *sqlDB) Ping() error {
+	return l.db.Ping()
+}
+
+func (l *sqlDB) IsConnected() bool {
+	return !l.closed.Load()
+}
+
+func (l *sqlDB) Close() error {
+	l.closed.Store(true)
+
+	return l.db.Close()
+}
+
+type rowsResult struct {
+	rows [][]any
+}
+
+func (r *rowsResult) GetNumberOfRows() uint64 {
+	return uint64(len(r.rows))
+}
+
+func (r *rowsResult) GetStringValue(row, col uint64) (string, error) {
+	v, err := r.value(row, col)
+	if err != nil {
+		return "", err
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case []byte:
+		return string(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case time.Time:
+		return val.Format(time.RFC3339Nano), nil
+	default:
+		return "", models.ErrInvalid("column type")
+	}
+}
+
+func (r *rowsResult) GetInt64Value(row, col uint64) (int64, error) {
+	v, err := r.value(row, col)
+	if err != nil {
+		return 0, err
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return val, nil
+	case float64:
+		return int64(val), nil
+	case bool:
+		if val {
+			return 1, nil
+		}
+
+		return 0, nil
+	case string:
+		return strconv.ParseInt(val, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(val), 10, 64)
+	case time.Time:
+		return val.UnixMilli(), nil
+	default:
+		return 0, models.ErrInvalid("column type")
+	}
+}
+
+// nolint:revive // keeping the same name as sqlitecloud.Result
+func (r *rowsResult) GetInt64Value_(row, col uint64) int64 {
+	v, _ := r.GetInt64Value(row, col)
+
+	return v
+}
+
+func (r *rowsResult) value(row, col uint64) (any, error) {
+	if row >= uint64(len(r.rows)) || col >= uint64(len(r.rows[row])) {
+		return nil, models.ErrInvalid("row or column index")
+	}
+
+	return r.rows[row][col], nil
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+
+	"todoapp/internal/models"
+)
+
+type txKey struct{}
+
+var errTxDone = models.NewConstError("transaction has already been committed or rolled back")
+
+// Transactor runs a unit of work inside a single database transaction
+type Transactor struct {
+	DB DB
+}
+
+func NewTransactor(db DB) *Transactor {
+	return &Transactor{DB: db}
+}
+
+// WithinTx begins a transaction and hands it to the stores through the ctx passed to fn.
+// The transaction is committed when fn returns nil and rolled back otherwise, a panic in fn rolls it
+// back before going on so the connection it holds is released.
+// Calls nested inside an open transaction join it instead of starting a new one.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while rolling back transaction",
+				slog.String("error", rErr.Error()),
+			)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none.
+// Stores must run every query through it so they take part in the caller's unit of work.
+func Conn(ctx context.Context, db DB) Querier {
+	if tx, ok := ctx.Value(txKey{}).(Tx); ok {
+		return tx
+	}
+
+	return db
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactor(t *testing.T) {
+	ctx := context.Background()
+	db := newTestLocalDB(t)
+	tr := NewTransactor(db)
+
+	require.NoError(t, db.Execute("CREATE TABLE t(id TEXT PRIMARY KEY);"))
+
+	count := func() uint64 {
+		res, err := db.Select("SELECT id FROM t;")
+		require.NoError(t, err)
+
+		return res.GetNumberOfRows()
+	}
+
+	err := tr.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, Conn(ctx, db).Execute("INSERT INTO t VALUES (?);", "a"))
+
+		return tr.WithinTx(ctx, func(ctx context.Context) error {
+			return Conn(ctx, db).Execute("INSERT INTO t VALUES (?);", "b")
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count())
+
+	err = tr.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, Conn(ctx, db).Execute("INSERT INTO t VALUES (?);", "c"))
+
+		// duplicate key fails the unit of work, "c" must not be kept
+		return Conn(ctx, db).Execute("INSERT INTO t VALUES (?);", "a")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, uint64(2), count())
+
+	// a panic rolls back and releases the connection, later queries must not block on it
+	assert.Panics(t, func() {
+		_ = tr.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, Conn(ctx, db).Execute("INSERT INTO t VALUES (?);", "d"))
+
+			panic("boom")
+		})
+	})
+	assert.Equal(t, uint64(2), count())
+}
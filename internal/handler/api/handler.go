@@ -0,0 +1,140 @@
+// Package apihttp serves the versioned JSON API under /api/v1, it shares the services of the
+// HTML handlers and answers every error with a JSON body.
+package apihttp
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Prefix is the path every route of the API starts with
+	Prefix = "/api/v1"
+
+	appJSON     = "application/json"
+	contentType = "Content-Type"
+	// maxBody is the largest request body read, in bytes
+	maxBody = 1 << 20
+)
+
+var (
+	errUnsupportedMedia = models.NewConstError("request body must be " + appJSON)
+	errUnsupportedPatch = models.NewConstError("request body must be " + models.MergePatchType)
+	errUnauthorized     = models.NewConstError("user not logged in")
+	// errInternal hides the cause of unexpected errors, it is logged instead
+	errInternal = models.NewConstError("internal server error")
+)
+
+// Handler serves the API, Tasks and Users are the services the HTML handlers use
+type Handler struct {
+	Tasks TodoServicer
+	Users UserServicer
+}
+
+func New(tasks TodoServicer, users UserServicer) *Handler {
+	return &Handler{Tasks: tasks, Users: users}
+}
+
+// NotFound answers the paths under Prefix that match no route
+func (*Handler) NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, http.StatusNotFound, models.ErrNotFound(r.URL.Path).Error())
+}
+
+// WriteError writes msg as the JSON error body of a status response
+func WriteError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, models.Error{Type: http.StatusText(status), IsError: true, Msg: msg})
+}
+
+// WriteProblems writes the JSON error body of a request that doesn't match the API specification
+func WriteProblems(w http.ResponseWriter, status int, msg string, problems []models.Problem) {
+	writeJSON(w, status, models.Error{Type: http.StatusText(status), IsError: true, Msg: msg, Problems: problems})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set(contentType, appJSON)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeErr answers err with the status it stands for, unexpected errors are logged and their
+// cause is not shown
+func writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	status := errStatus(err)
+	msg := err.Error()
+
+	if status == http.StatusInternalServerError {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, msg,
+			slog.String("method", r.Method), slog.String("path", r.URL.Path))
+
+		msg = errInternal.Error()
+	}
+
+	WriteError(w, status, msg)
+}
+
+// errStatus is the status code an error of the services is answered with
+func errStatus(err error) int {
+	msg := err.Error()
+
+	switch {
+	case errors.Is(err, errUnauthorized), errors.Is(err, models.ErrPsswdNotMatch), errors.Is(err, models.ErrUserNotFound),
+		errors.Is(err, models.ErrNotFound("user")), errors.Is(err, models.ErrInvalidCookie), errors.Is(err, models.ErrInvalidToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, models.ErrPermissionDenied), errors.Is(err, models.ErrScopeMissing):
+		return http.StatusForbidden
+	case errors.Is(err, errUnsupportedMedia), errors.Is(err, errUnsupportedPatch):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, models.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case strings.HasSuffix(msg, models.ErrNotFound("").Error()):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrUserAlreadyExists), errors.Is(err, models.ErrStatusTransition),
+		errors.Is(err, models.ErrTaskBlocked), errors.Is(err, models.ErrTagAlreadyExists),
+		errors.Is(err, models.ErrListArchived), errors.Is(err, models.ErrSubtaskDepth),
+		errors.Is(err, models.ErrSubtaskCycle), errors.Is(err, models.ErrSubtaskList), errors.Is(err, models.ErrDependencyCycle):
+		return http.StatusConflict
+	case strings.HasPrefix(msg, models.ErrInvalid("").Error()), strings.HasPrefix(msg, models.ErrRequired("").Error()):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// decode reads the JSON request body into v
+func decode(w http.ResponseWriter, r *http.Request, v any) error {
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get(contentType)); mt != appJSON {
+		return errUnsupportedMedia
+	}
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody)).Decode(v); err != nil {
+		return models.ErrInvalid("request body")
+	}
+
+	return nil
+}
+
+// methodNotAllowed answers a method the route doesn't serve, allow lists the ones it does
+func methodNotAllowed(w http.ResponseWriter, allow ...string) {
+	w.Header().Set("Allow", strings.Join(allow, ", "))
+	WriteError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+}
+
+// userID is the signed in user the auth middleware put in the request context
+func userID(r *http.Request) (*uuid.UUID, error) {
+	id, ok := r.Context().Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		return nil, errUnauthorized
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,148 @@
+package apihttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestErrStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: models.ErrNotFound("task"), want: http.StatusNotFound},
+		{err: models.ErrInvalid("priority"), want: http.StatusBadRequest},
+		{err: models.ErrRequired("task title"), want: http.StatusBadRequest},
+		{err: models.ErrTaskBlocked, want: http.StatusConflict},
+		{err: models.ErrUserAlreadyExists, want: http.StatusConflict},
+		{err: models.ErrPsswdNotMatch, want: http.StatusUnauthorized},
+		{err: models.ErrNotFound("user"), want: http.StatusUnauthorized},
+		{err: errUnsupportedMedia, want: http.StatusUnsupportedMediaType},
+		{err: models.ErrVersionMismatch, want: http.StatusPreconditionFailed},
+		{err: models.NewConstError("database is locked"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, errStatus(tt.err))
+		})
+	}
+}
+
+func TestHandleTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasks := NewMockTodoServicer(ctrl)
+	h := New(tasks, NewMockUserServicer(ctrl))
+	uid := uuid.New()
+	ctx := context.WithValue(context.Background(), models.CtxKeyUserID, uid)
+	due := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	tasks.EXPECT().QuickAdd(gomock.Any(), "Pay rent tomorrow 9am", "", &uid).
+		Return(&models.Task{ID: "task-a", Title: "Pay rent", Status: models.StatusTodo, DueDate: &due, HasDueTime: true}, nil)
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, Prefix+"/tasks", strings.NewReader(`{"quick":"Pay rent tomorrow 9am"}`))
+	req.Header.Set(contentType, appJSON)
+	rec := httptest.NewRecorder()
+	h.HandleTasks(rec, req)
+
+	var task models.TaskResp
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, Prefix+"/tasks/task-a", rec.Header().Get("Location"))
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&task))
+	assert.Equal(t, "Pay rent", task.Title)
+	assert.Equal(t, "09:00", task.DueTime)
+
+	// a form post is turned away before the service is called
+	req = httptest.NewRequestWithContext(ctx, http.MethodPost, Prefix+"/tasks", strings.NewReader("title=Pay+rent"))
+	req.Header.Set(contentType, "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	h.HandleTasks(rec, req)
+
+	var body models.Error
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, errUnsupportedMedia.Error(), body.Msg)
+
+	rec = httptest.NewRecorder()
+	h.HandleTasks(rec, httptest.NewRequestWithContext(ctx, http.MethodPatch, Prefix+"/tasks", http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, POST", rec.Header().Get("Allow"))
+}
+
+func TestHandleTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasks := NewMockTodoServicer(ctrl)
+	h := New(tasks, NewMockUserServicer(ctrl))
+	uid := uuid.New()
+	ctx := context.WithValue(context.Background(), models.CtxKeyUserID, uid)
+	patch := `{"priority":"high"}`
+
+	tasks.EXPECT().PatchTask(gomock.Any(), "task-a", []byte(patch), int64(3), &uid).
+		Return(&models.Task{ID: "task-a", Title: "Pay rent", Status: models.StatusTodo, Version: 4}, nil)
+	tasks.EXPECT().PatchTask(gomock.Any(), "task-a", []byte(patch), int64(2), &uid).Return(nil, models.ErrVersionMismatch)
+	// without If-Match the store still refuses a write that lost the race to another
+	tasks.EXPECT().PatchTask(gomock.Any(), "task-a", []byte(patch), int64(0), &uid).Return(nil, models.ErrVersionMismatch)
+
+	send := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(ctx, http.MethodPatch, Prefix+"/tasks/task-a", strings.NewReader(patch))
+		req.SetPathValue("id", "task-a")
+		req.Header.Set(contentType, models.MergePatchType)
+		req.Header.Set("If-Match", ifMatch)
+
+		rec := httptest.NewRecorder()
+		h.HandleTask(rec, req)
+
+		return rec
+	}
+
+	rec := send(`"3"`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+
+	assert.Equal(t, http.StatusPreconditionFailed, send(`"2"`).Code)
+	assert.Equal(t, http.StatusPreconditionFailed, send("").Code)
+
+	// a weak tag never matches, the service isn't asked
+	assert.Equal(t, http.StatusPreconditionFailed, send(`W/"3"`).Code)
+}
+
+func TestSetStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasks := NewMockTodoServicer(ctrl)
+	h := New(tasks, NewMockUserServicer(ctrl))
+	uid := uuid.New()
+	ctx := context.WithValue(context.Background(), models.CtxKeyUserID, uid)
+
+	tasks.EXPECT().SetStatus(gomock.Any(), "task-a", &models.StatusReq{Status: "done", Version: 2}, &uid).
+		Return(nil, models.ErrVersionMismatch)
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodPut, Prefix+"/tasks/task-a/status", strings.NewReader(`{"status":"done"}`))
+	req.SetPathValue("id", "task-a")
+	req.Header.Set(contentType, "application/json")
+	req.Header.Set("If-Match", `"2"`)
+
+	rec := httptest.NewRecorder()
+	h.SetStatus(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+}
@@ -0,0 +1,38 @@
+package apihttp
+
+import (
+	"context"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen --source=interface.go --destination=mock_interface.go --package=apihttp
+type TodoServicer interface {
+	GetAll(ctx context.Context, req *models.TaskListReq, userID *uuid.UUID) (*models.TaskPage, error)
+	GetTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	AddTask(ctx context.Context, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error)
+	QuickAdd(ctx context.Context, line, listID string, userID *uuid.UUID) (*models.Task, error)
+	UpdateTask(ctx context.Context, id string, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error)
+	PatchTask(ctx context.Context, id string, patch []byte, version int64, userID *uuid.UUID) (*models.Task, error)
+	DeleteTask(ctx context.Context, id string, cascade bool, version int64, userID *uuid.UUID) error
+	SetStatus(ctx context.Context, id string, req *models.StatusReq, userID *uuid.UUID) (*models.Task, error)
+	Search(ctx context.Context, query string, limit int, userID *uuid.UUID) ([]models.SearchResult, error)
+	ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error)
+	RestoreTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	TagTask(ctx context.Context, taskID, name string, userID *uuid.UUID) (*models.Task, error)
+	UntagTask(ctx context.Context, taskID, tagID string, userID *uuid.UUID) (*models.Task, error)
+}
+
+type UserServicer interface {
+	Register(ctx context.Context, req *models.RegisterReq) (*models.SessionData, error)
+	Login(ctx context.Context, req *models.LoginReq) (*models.SessionData, error)
+	Logout(ctx context.Context, token string) error
+	GetUser(ctx context.Context, userID *uuid.UUID) (*models.UserData, error)
+	SetTimeZone(ctx context.Context, userID *uuid.UUID, timeZone string) (*time.Location, error)
+	CreateToken(ctx context.Context, userID *uuid.UUID, req *models.TokenReq) (*models.NewAccessToken, error)
+	ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error)
+	RevokeToken(ctx context.Context, id string, userID *uuid.UUID) error
+}
@@ -0,0 +1,380 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen --source=interface.go --destination=mock_interface.go --package=apihttp
+//
+
+// Package apihttp is a generated GoMock package.
+package apihttp
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "todoapp/internal/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTodoServicer is a mock of TodoServicer interface.
+type MockTodoServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoServicerMockRecorder
+	isgomock struct{}
+}
+
+// MockTodoServicerMockRecorder is the mock recorder for MockTodoServicer.
+type MockTodoServicerMockRecorder struct {
+	mock *MockTodoServicer
+}
+
+// NewMockTodoServicer creates a new mock instance.
+func NewMockTodoServicer(ctrl *gomock.Controller) *MockTodoServicer {
+	mock := &MockTodoServicer{ctrl: ctrl}
+	mock.recorder = &MockTodoServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoServicer) EXPECT() *MockTodoServicerMockRecorder {
+	return m.recorder
+}
+
+// AddTask mocks base method.
+func (m *MockTodoServicer) AddTask(ctx context.Context, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTask", ctx, task, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTask indicates an expected call of AddTask.
+func (mr *MockTodoServicerMockRecorder) AddTask(ctx, task, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockTodoServicer)(nil).AddTask), ctx, task, userID)
+}
+
+// DeleteTask mocks base method.
+func (m *MockTodoServicer) DeleteTask(ctx context.Context, id string, cascade bool, version int64, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, id, cascade, version, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTodoServicerMockRecorder) DeleteTask(ctx, id, cascade, version, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoServicer)(nil).DeleteTask), ctx, id, cascade, version, userID)
+}
+
+// GetAll mocks base method.
+func (m *MockTodoServicer) GetAll(ctx context.Context, req *models.TaskListReq, userID *uuid.UUID) (*models.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, req, userID)
+	ret0, _ := ret[0].(*models.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTodoServicerMockRecorder) GetAll(ctx, req, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoServicer)(nil).GetAll), ctx, req, userID)
+}
+
+// GetTask mocks base method.
+func (m *MockTodoServicer) GetTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockTodoServicerMockRecorder) GetTask(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTodoServicer)(nil).GetTask), ctx, id, userID)
+}
+
+// ListTrash mocks base method.
+func (m *MockTodoServicer) ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, userID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockTodoServicerMockRecorder) ListTrash(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockTodoServicer)(nil).ListTrash), ctx, userID)
+}
+
+// PatchTask mocks base method.
+func (m *MockTodoServicer) PatchTask(ctx context.Context, id string, patch []byte, version int64, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, id, patch, version, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MockTodoServicerMockRecorder) PatchTask(ctx, id, patch, version, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MockTodoServicer)(nil).PatchTask), ctx, id, patch, version, userID)
+}
+
+// QuickAdd mocks base method.
+func (m *MockTodoServicer) QuickAdd(ctx context.Context, line, listID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuickAdd", ctx, line, listID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuickAdd indicates an expected call of QuickAdd.
+func (mr *MockTodoServicerMockRecorder) QuickAdd(ctx, line, listID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuickAdd", reflect.TypeOf((*MockTodoServicer)(nil).QuickAdd), ctx, line, listID, userID)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTodoServicer) RestoreTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTodoServicerMockRecorder) RestoreTask(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTodoServicer)(nil).RestoreTask), ctx, id, userID)
+}
+
+// Search mocks base method.
+func (m *MockTodoServicer) Search(ctx context.Context, query string, limit int, userID *uuid.UUID) ([]models.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, userID)
+	ret0, _ := ret[0].([]models.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoServicerMockRecorder) Search(ctx, query, limit, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoServicer)(nil).Search), ctx, query, limit, userID)
+}
+
+// SetStatus mocks base method.
+func (m *MockTodoServicer) SetStatus(ctx context.Context, id string, req *models.StatusReq, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, req, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockTodoServicerMockRecorder) SetStatus(ctx, id, req, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockTodoServicer)(nil).SetStatus), ctx, id, req, userID)
+}
+
+// TagTask mocks base method.
+func (m *MockTodoServicer) TagTask(ctx context.Context, taskID, name string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagTask", ctx, taskID, name, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagTask indicates an expected call of TagTask.
+func (mr *MockTodoServicerMockRecorder) TagTask(ctx, taskID, name, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagTask", reflect.TypeOf((*MockTodoServicer)(nil).TagTask), ctx, taskID, name, userID)
+}
+
+// UntagTask mocks base method.
+func (m *MockTodoServicer) UntagTask(ctx context.Context, taskID, tagID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagTask", ctx, taskID, tagID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagTask indicates an expected call of UntagTask.
+func (mr *MockTodoServicerMockRecorder) UntagTask(ctx, taskID, tagID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagTask", reflect.TypeOf((*MockTodoServicer)(nil).UntagTask), ctx, taskID, tagID, userID)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTodoServicer) UpdateTask(ctx context.Context, id string, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, id, task, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTodoServicerMockRecorder) UpdateTask(ctx, id, task, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoServicer)(nil).UpdateTask), ctx, id, task, userID)
+}
+
+// MockUserServicer is a mock of UserServicer interface.
+type MockUserServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServicerMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServicerMockRecorder is the mock recorder for MockUserServicer.
+type MockUserServicerMockRecorder struct {
+	mock *MockUserServicer
+}
+
+// NewMockUserServicer creates a new mock instance.
+func NewMockUserServicer(ctrl *gomock.Controller) *MockUserServicer {
+	mock := &MockUserServicer{ctrl: ctrl}
+	mock.recorder = &MockUserServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServicer) EXPECT() *MockUserServicerMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method.
+func (m *MockUserServicer) CreateToken(ctx context.Context, userID *uuid.UUID, req *models.TokenReq) (*models.NewAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, userID, req)
+	ret0, _ := ret[0].(*models.NewAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockUserServicerMockRecorder) CreateToken(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockUserServicer)(nil).CreateToken), ctx, userID, req)
+}
+
+// GetUser mocks base method.
+func (m *MockUserServicer) GetUser(ctx context.Context, userID *uuid.UUID) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserServicerMockRecorder) GetUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserServicer)(nil).GetUser), ctx, userID)
+}
+
+// ListTokens mocks base method.
+func (m *MockUserServicer) ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTokens", ctx, userID)
+	ret0, _ := ret[0].([]models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTokens indicates an expected call of ListTokens.
+func (mr *MockUserServicerMockRecorder) ListTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTokens", reflect.TypeOf((*MockUserServicer)(nil).ListTokens), ctx, userID)
+}
+
+// Login mocks base method.
+func (m *MockUserServicer) Login(ctx context.Context, req *models.LoginReq) (*models.SessionData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, req)
+	ret0, _ := ret[0].(*models.SessionData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockUserServicerMockRecorder) Login(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServicer)(nil).Login), ctx, req)
+}
+
+// Logout mocks base method.
+func (m *MockUserServicer) Logout(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServicerMockRecorder) Logout(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserServicer)(nil).Logout), ctx, token)
+}
+
+// Register mocks base method.
+func (m *MockUserServicer) Register(ctx context.Context, req *models.RegisterReq) (*models.SessionData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, req)
+	ret0, _ := ret[0].(*models.SessionData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockUserServicerMockRecorder) Register(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserServicer)(nil).Register), ctx, req)
+}
+
+// RevokeToken mocks base method.
+func (m *MockUserServicer) RevokeToken(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockUserServicerMockRecorder) RevokeToken(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockUserServicer)(nil).RevokeToken), ctx, id, userID)
+}
+
+// SetTimeZone mocks base method.
+func (m *MockUserServicer) SetTimeZone(ctx context.Context, userID *uuid.UUID, timeZone string) (*time.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimeZone", ctx, userID, timeZone)
+	ret0, _ := ret[0].(*time.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTimeZone indicates an expected call of SetTimeZone.
+func (mr *MockUserServicerMockRecorder) SetTimeZone(ctx, userID, timeZone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeZone", reflect.TypeOf((*MockUserServicer)(nil).SetTimeZone), ctx, userID, timeZone)
+}
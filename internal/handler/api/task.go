@@ -0,0 +1,384 @@
+package apihttp
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"todoapp/internal/models"
+)
+
+// taskInput adds or replaces a task, with Quick set the task is read from that single line instead
+type taskInput struct {
+	models.TaskReq
+	Quick string `json:"quick"`
+}
+
+// tagInput tags a task by tag name, the tag is created when missing
+type tagInput struct {
+	Name string `json:"name"`
+}
+
+// HandleTasks lists the user's tasks a page at a time or adds one
+func (h *Handler) HandleTasks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listTasks(w, r)
+	case http.MethodPost:
+		h.addTask(w, r)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+// HandleTask reads, replaces, patches or trashes one task
+func (h *Handler) HandleTask(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getTask(w, r)
+	case http.MethodPut:
+		h.updateTask(w, r)
+	case http.MethodPatch:
+		h.patchTask(w, r)
+	case http.MethodDelete:
+		h.deleteTask(w, r)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete)
+	}
+}
+
+func (h *Handler) listTasks(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	req, err := models.ParseTaskListReq(r.URL.Query())
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	page, err := h.Tasks.GetAll(r.Context(), req, uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page.ToTaskPageResp(models.GetLocationFromCtx(r.Context())))
+}
+
+func (h *Handler) addTask(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	var in taskInput
+	if err := decode(w, r, &in); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	var task *models.Task
+
+	if in.Quick != "" {
+		task, err = h.Tasks.QuickAdd(r.Context(), in.Quick, in.ListID, uid)
+	} else {
+		task, err = h.Tasks.AddTask(r.Context(), &in.TaskReq, uid)
+	}
+
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", Prefix+"/tasks/"+task.ID)
+	h.writeTask(w, r, http.StatusCreated, task)
+}
+
+func (h *Handler) getTask(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	task, err := h.Tasks.GetTask(r.Context(), r.PathValue("id"), uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+func (h *Handler) updateTask(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	var in models.TaskReq
+	if err := decode(w, r, &in); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	if in.Version, err = ifMatch(r); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	task, err := h.Tasks.UpdateTask(r.Context(), r.PathValue("id"), &in, uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// patchTask applies a JSON merge patch to a task, a member set to null clears the field
+func (h *Handler) patchTask(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get(contentType)); mt != models.MergePatchType {
+		writeErr(w, r, errUnsupportedPatch)
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		writeErr(w, r, models.ErrInvalid("request body"))
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	task, err := h.Tasks.PatchTask(r.Context(), r.PathValue("id"), patch, version, uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// deleteTask moves a task to the trash, with cascade=true its subtasks go along with it
+func (h *Handler) deleteTask(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	cascade, err := boolParam(r, "cascade")
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	if err := h.Tasks.DeleteTask(r.Context(), r.PathValue("id"), cascade, version, uid); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetStatus moves a task to the status in the body
+func (h *Handler) SetStatus(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	var req models.StatusReq
+	if err := decode(w, r, &req); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	if req.Version, err = ifMatch(r); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	task, err := h.Tasks.SetStatus(r.Context(), r.PathValue("id"), &req, uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// Restore takes a task out of the trash
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	task, err := h.Tasks.RestoreTask(r.Context(), r.PathValue("id"), uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// TagTask tags a task with the tag named in the body
+func (h *Handler) TagTask(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	var in tagInput
+	if err := decode(w, r, &in); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	task, err := h.Tasks.TagTask(r.Context(), r.PathValue("id"), in.Name, uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+func (h *Handler) UntagTask(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	task, err := h.Tasks.UntagTask(r.Context(), r.PathValue("id"), r.PathValue("tagId"), uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// Search lists the tasks matching the q query parameter, best match first
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	limit := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if limit, err = strconv.Atoi(l); err != nil {
+			writeErr(w, r, models.ErrInvalid("limit"))
+			return
+		}
+	}
+
+	results, err := h.Tasks.Search(r.Context(), r.URL.Query().Get("q"), limit, uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	loc := models.GetLocationFromCtx(r.Context())
+	resp := make([]models.TaskResp, 0, len(results))
+
+	for i := range results {
+		resp = append(resp, *results[i].ToTaskResp(loc))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Trash lists the user's trashed tasks
+func (h *Handler) Trash(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	tasks, err := h.Tasks.ListTrash(r.Context(), uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	loc := models.GetLocationFromCtx(r.Context())
+	resp := make([]models.TaskResp, 0, len(tasks))
+
+	for i := range tasks {
+		resp = append(resp, *tasks[i].ToTaskResp(loc))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (*Handler) writeTask(w http.ResponseWriter, r *http.Request, status int, task *models.Task) {
+	w.Header().Set("ETag", task.ETag())
+	writeJSON(w, status, task.ToTaskResp(models.GetLocationFromCtx(r.Context())))
+}
+
+// ifMatch is the task version the If-Match header makes the write conditional on, 0 when there is no
+// header or it is "*". A tag that is no version of a task never matches.
+func ifMatch(r *http.Request) (int64, error) {
+	tag := r.Header.Get("If-Match")
+	if tag == "" || strings.TrimSpace(tag) == "*" {
+		return 0, nil
+	}
+
+	version, ok := models.ParseETag(tag)
+	if !ok {
+		return 0, models.ErrVersionMismatch
+	}
+
+	return version, nil
+}
+
+// boolParam reads an optional boolean query parameter, false when it is missing
+func boolParam(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, models.ErrInvalid(name)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,74 @@
+package apihttp
+
+import (
+	"net/http"
+
+	"todoapp/internal/models"
+)
+
+// HandleTokens lists the user's personal access tokens or creates one
+func (h *Handler) HandleTokens(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listTokens(w, r)
+	case http.MethodPost:
+		h.createToken(w, r)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (h *Handler) listTokens(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	tokens, err := h.Users.ListTokens(r.Context(), uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokens)
+}
+
+// createToken answers with the new token, the only time it can be read
+func (h *Handler) createToken(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	var req models.TokenReq
+	if err := decode(w, r, &req); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	token, err := h.Users.CreateToken(r.Context(), uid, &req)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, token)
+}
+
+// RevokeToken deletes one of the user's access tokens
+func (h *Handler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	if err := h.Users.RevokeToken(r.Context(), r.PathValue("id"), uid); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
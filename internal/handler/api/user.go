@@ -0,0 +1,124 @@
+package apihttp
+
+import (
+	"net/http"
+	"time"
+
+	"todoapp/internal/models"
+)
+
+// cookieName is the session cookie the HTML pages are signed in with, the API sets it too
+const cookieName = "token"
+
+// sessionResp is the session a register or login starts, Token also signs in as the token cookie
+type sessionResp struct {
+	Token  string    `json:"token"`
+	Expiry time.Time `json:"expiry"`
+}
+
+type timeZoneInput struct {
+	TimeZone string `json:"timeZone"`
+}
+
+// Register signs up a user and starts their session
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	req := models.RegisterReq{LoginReq: &models.LoginReq{}}
+	if err := decode(w, r, &req); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	session, err := h.Users.Register(r.Context(), &req)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	writeSession(w, http.StatusCreated, session)
+}
+
+// Login starts a session for the email and password in the body
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	var req models.LoginReq
+	if err := decode(w, r, &req); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	session, err := h.Users.Login(r.Context(), &req)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	writeSession(w, http.StatusOK, session)
+}
+
+// Logout ends the session of the token cookie
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	c, err := r.Cookie(cookieName)
+	if err != nil {
+		writeErr(w, r, errUnauthorized)
+		return
+	}
+
+	if err := h.Users.Logout(r.Context(), c.Value); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: cookieName, HttpOnly: true, Path: "/", MaxAge: -1, Secure: true})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Me returns the signed in user
+func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	user, err := h.Users.GetUser(r.Context(), uid)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user.ToUserResp())
+}
+
+// SetTimeZone changes the IANA time zone the user's due dates are read and shown in
+func (h *Handler) SetTimeZone(w http.ResponseWriter, r *http.Request) {
+	uid, err := userID(r)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	var in timeZoneInput
+	if err := decode(w, r, &in); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	if _, err := h.Users.SetTimeZone(r.Context(), uid, in.TimeZone); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.Me(w, r)
+}
+
+func writeSession(w http.ResponseWriter, status int, session *models.SessionData) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieName,
+		Value:    session.Token,
+		HttpOnly: true,
+		Expires:  session.Expiry,
+		Path:     "/",
+		Secure:   true,
+	})
+
+	writeJSON(w, status, sessionResp{Token: session.Token, Expiry: session.Expiry})
+}
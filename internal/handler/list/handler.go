@@ -0,0 +1,227 @@
+package listhttp
+
+import (
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	invalidReqMethod    = "method not allowed"
+	userNotFound        = "user not found"
+	renderErr           = "error while rendering template"
+	templateLists       = "lists"
+	templateList        = "list"
+	templateListOptions = "list-options"
+)
+
+type Handler struct {
+	Service  ListServicer
+	template *template.Template
+}
+
+func New(listSvc ListServicer) *Handler {
+	return &Handler{template: models.NewTemplate(), Service: listSvc}
+}
+
+// listsView is the list manager, either of the active or of the archived lists
+type listsView struct {
+	Archived bool
+	Lists    []models.List
+}
+
+// listOptionsView fills a list select, Selected is the ID of the list the task is in
+type listOptionsView struct {
+	Selected string
+	Lists    []models.List
+}
+
+func (h *Handler) HandleLists(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getAll(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, invalidReqMethod, http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.update(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.Error(w, invalidReqMethod, http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleArchive archives a list on PUT and brings it back on DELETE, the empty response
+// removes it from the lists it was shown in
+func (h *Handler) HandleArchive(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.setArchived(w, r, true)
+	case http.MethodDelete:
+		h.setArchived(w, r, false)
+	default:
+		http.Error(w, invalidReqMethod, http.StatusMethodNotAllowed)
+	}
+}
+
+// Options renders the active lists as the options of a select, the one named by selected is chosen
+func (h *Handler) Options(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	lists, err := h.Service.GetAll(ctx, false, &userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.render(w, r, templateListOptions, listOptionsView{Selected: r.URL.Query().Get("selected"), Lists: lists})
+}
+
+func (h *Handler) getAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	archived := false
+
+	if a := r.URL.Query().Get("archived"); a != "" {
+		var err error
+
+		if archived, err = strconv.ParseBool(a); err != nil {
+			http.Error(w, models.ErrInvalid("archived").Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	lists, err := h.Service.GetAll(ctx, archived, &userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.render(w, r, templateLists, listsView{Archived: archived, Lists: lists})
+}
+
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	list, err := h.Service.Create(ctx, listReq(r), &userID)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	h.render(w, r, templateList, list)
+}
+
+func (h *Handler) update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	list, err := h.Service.Update(ctx, r.PathValue("id"), listReq(r), &userID)
+	if err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateList, list)
+}
+
+// delete deletes a list, the empty response removes it from the list manager
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.Delete(ctx, r.PathValue("id"), &userID); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := h.Service.SetArchived(ctx, r.PathValue("id"), archived, &userID); err != nil {
+		writeErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, tmpl string, data any) {
+	ctx := r.Context()
+
+	if err := h.template.ExecuteTemplate(w, tmpl, data); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", tmpl))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func listReq(r *http.Request) *models.ListReq {
+	return &models.ListReq{Name: r.PostFormValue("name"), Sort: r.PostFormValue("sort")}
+}
+
+func writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, err.Error(),
+		slog.String("path", r.URL.Path))
+
+	switch {
+	case errors.Is(err, models.ErrNotFound("list")):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, models.ErrListAlreadyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,18 @@
+package listhttp
+
+import (
+	"context"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen --source=interface.go --destination=mock_interface.go --package=listhttp
+type ListServicer interface {
+	GetAll(ctx context.Context, archived bool, userID *uuid.UUID) ([]models.List, error)
+	Create(ctx context.Context, req *models.ListReq, userID *uuid.UUID) (*models.List, error)
+	Update(ctx context.Context, id string, req *models.ListReq, userID *uuid.UUID) (*models.List, error)
+	SetArchived(ctx context.Context, id string, archived bool, userID *uuid.UUID) (*models.List, error)
+	Delete(ctx context.Context, id string, userID *uuid.UUID) error
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen --source=interface.go --destination=mock_interface.go --package=listhttp
+//
+
+// Package listhttp is a generated GoMock package.
+package listhttp
+
+import (
+	context "context"
+	reflect "reflect"
+	models "todoapp/internal/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockListServicer is a mock of ListServicer interface.
+type MockListServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockListServicerMockRecorder
+	isgomock struct{}
+}
+
+// MockListServicerMockRecorder is the mock recorder for MockListServicer.
+type MockListServicerMockRecorder struct {
+	mock *MockListServicer
+}
+
+// NewMockListServicer creates a new mock instance.
+func NewMockListServicer(ctrl *gomock.Controller) *MockListServicer {
+	mock := &MockListServicer{ctrl: ctrl}
+	mock.recorder = &MockListServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListServicer) EXPECT() *MockListServicerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockListServicer) Create(ctx context.Context, req *models.ListReq, userID *uuid.UUID) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req, userID)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockListServicerMockRecorder) Create(ctx, req, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockListServicer)(nil).Create), ctx, req, userID)
+}
+
+// Delete mocks base method.
+func (m *MockListServicer) Delete(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListServicerMockRecorder) Delete(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockListServicer)(nil).Delete), ctx, id, userID)
+}
+
+// GetAll mocks base method.
+func (m *MockListServicer) GetAll(ctx context.Context, archived bool, userID *uuid.UUID) ([]models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, archived, userID)
+	ret0, _ := ret[0].([]models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockListServicerMockRecorder) GetAll(ctx, archived, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockListServicer)(nil).GetAll), ctx, archived, userID)
+}
+
+// SetArchived mocks base method.
+func (m *MockListServicer) SetArchived(ctx context.Context, id string, archived bool, userID *uuid.UUID) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArchived", ctx, id, archived, userID)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetArchived indicates an expected call of SetArchived.
+func (mr *MockListServicerMockRecorder) SetArchived(ctx, id, archived, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArchived", reflect.TypeOf((*MockListServicer)(nil).SetArchived), ctx, id, archived, userID)
+}
+
+// Update mocks base method.
+func (m *MockListServicer) Update(ctx context.Context, id string, req *models.ListReq, userID *uuid.UUID) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req, userID)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockListServicerMockRecorder) Update(ctx, id, req, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockListServicer)(nil).Update), ctx, id, req, userID)
+}
@@ -0,0 +1,95 @@
+package notifyhttp
+
+import (
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	userNotFound          = "user not found"
+	renderErr             = "error while rendering template"
+	templateNotifications = "notifications"
+)
+
+type Handler struct {
+	Service  NotifyServicer
+	template *template.Template
+}
+
+func New(notifySvc NotifyServicer) *Handler {
+	return &Handler{template: models.NewTemplate(), Service: notifySvc}
+}
+
+// notificationView is a notification with its time in the user's time zone
+type notificationView struct {
+	models.Notification
+	At string
+}
+
+// List renders the user's latest notifications
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	notifications, err := h.Service.List(ctx, &userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	loc := models.GetLocationFromCtx(ctx)
+	views := make([]notificationView, 0, len(notifications))
+
+	for _, n := range notifications {
+		views = append(views, notificationView{Notification: n, At: n.CreatedAt.In(loc).Format(time.DateTime)})
+	}
+
+	h.render(w, r, templateNotifications, views)
+}
+
+// MarkRead marks a notification as read, the empty response takes its unread badge away
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.MarkRead(ctx, r.PathValue("id"), &userID); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, err.Error(), slog.String("path", r.URL.Path))
+
+		if errors.Is(err, models.ErrNotFound("notification")) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, tmpl string, data any) {
+	ctx := r.Context()
+
+	if err := h.template.ExecuteTemplate(w, tmpl, data); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", tmpl))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
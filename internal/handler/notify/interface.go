@@ -0,0 +1,15 @@
+package notifyhttp
+
+import (
+	"context"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen --source=interface.go --destination=mock_interface.go --package=notifyhttp
+type NotifyServicer interface {
+	List(ctx context.Context, userID *uuid.UUID) ([]models.Notification, error)
+	MarkRead(ctx context.Context, id string, userID *uuid.UUID) error
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen --source=interface.go --destination=mock_interface.go --package=notifyhttp
+//
+
+// Package notifyhttp is a generated GoMock package.
+package notifyhttp
+
+import (
+	context "context"
+	reflect "reflect"
+	models "todoapp/internal/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotifyServicer is a mock of NotifyServicer interface.
+type MockNotifyServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifyServicerMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifyServicerMockRecorder is the mock recorder for MockNotifyServicer.
+type MockNotifyServicerMockRecorder struct {
+	mock *MockNotifyServicer
+}
+
+// NewMockNotifyServicer creates a new mock instance.
+func NewMockNotifyServicer(ctrl *gomock.Controller) *MockNotifyServicer {
+	mock := &MockNotifyServicer{ctrl: ctrl}
+	mock.recorder = &MockNotifyServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifyServicer) EXPECT() *MockNotifyServicerMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockNotifyServicer) List(ctx context.Context, userID *uuid.UUID) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotifyServicerMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotifyServicer)(nil).List), ctx, userID)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotifyServicer) MarkRead(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotifyServicerMockRecorder) MarkRead(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotifyServicer)(nil).MarkRead), ctx, id, userID)
+}
@@ -0,0 +1,72 @@
+package todohttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// conflictView is a write rejected because the task changed since it was shown: Current is the task
+// as saved, Mine the values of a rejected update, Status a rejected status change and Delete a rejected
+// move to the trash, with its subtasks when Cascade is set
+type conflictView struct {
+	Current models.TaskResp
+	Mine    *models.TaskReq
+	Status  *models.StatusReq
+	Delete  bool
+	Cascade bool
+}
+
+// conflict answers a write made from an outdated version with the task as saved, which replaces
+// the row and its form, and a dialog offering to make the rejected write over it
+func (h *Handler) conflict(w http.ResponseWriter, r *http.Request, view conflictView, id string, version int64, userID *uuid.UUID) {
+	ctx := r.Context()
+	logger := models.GetLoggerFromCtx(ctx)
+
+	task, err := h.Service.GetTask(ctx, id, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, models.ErrNotFound("task")) {
+			status = http.StatusNotFound
+		}
+
+		http.Error(w, err.Error(), status)
+
+		return
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, "task write rejected, the task changed since it was read",
+		slog.String("user", userID.String()),
+		slog.String("task", id),
+		slog.Int64("version", version),
+		slog.Int64("current", task.Version),
+	)
+
+	view.Current = *task.ToTaskResp(models.GetLocationFromCtx(ctx))
+
+	w.WriteHeader(http.StatusPreconditionFailed)
+
+	if err := h.template.ExecuteTemplate(w, templateConflict, view); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateConflict))
+	}
+}
+
+// formVersion is the version of the task a form was filled from, 0 for an older form without one
+func formVersion(r *http.Request) (int64, error) {
+	v := r.FormValue("version")
+	if v == "" {
+		return 0, nil
+	}
+
+	version, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, models.ErrInvalid("version")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,104 @@
+package todohttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const templateBlockerOptions = "blocker-options"
+
+type blockerOptionsView struct {
+	TaskID string
+	Tasks  []models.TaskResp
+}
+
+// AddBlocker makes a task wait for the task posted as blockerId and renders the task again
+func (h *Handler) AddBlocker(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.AddBlocker(ctx, r.PathValue("id"), r.PostFormValue("blockerId"), &userID)
+	if err != nil {
+		writeDependencyErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+// RemoveBlocker stops a task from waiting for another one and renders the task again
+func (h *Handler) RemoveBlocker(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.RemoveBlocker(ctx, r.PathValue("id"), r.PathValue("blockerId"), &userID)
+	if err != nil {
+		writeDependencyErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+// BlockerOptions renders the open tasks a task can be made to wait for as select options
+func (h *Handler) BlockerOptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	req := models.TaskListReq{Done: "false", Sort: "title", Limit: models.MaxPageSize}
+
+	page, err := h.Service.GetAll(ctx, &req, &userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.render(w, r, templateBlockerOptions, blockerOptionsView{
+		TaskID: r.PathValue("id"),
+		Tasks:  page.ToTaskPageResp(models.GetLocationFromCtx(ctx)).Tasks,
+	})
+}
+
+// flag reads the boolean parameter name of the query or the form, it is off when missing
+func flag(r *http.Request, name string) (bool, error) {
+	v := r.FormValue(name)
+	if v == "" {
+		return false, nil
+	}
+
+	on, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, models.ErrInvalid(name)
+	}
+
+	return on, nil
+}
+
+func writeDependencyErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, models.ErrDependencyCycle) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	writeListErr(w, r, err)
+}
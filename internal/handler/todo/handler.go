@@ -1,9 +1,13 @@
 package todohttp
 
 import (
+	"errors"
 	"html/template"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"todoapp/internal/models"
 
 	"github.com/google/uuid"
@@ -13,6 +17,11 @@ const (
 	invalidReqMethod = "method not allowed"
 	templateAddTask  = "add"
 	templateIndex    = "index"
+	templateTasks    = "tasks"
+	templateSearch   = "searchResults"
+	templateTrash    = "trash"
+	templateHistory  = "history"
+	templateConflict = "task-conflict"
 	userNotFound     = "user not found"
 	renderErr        = "error while rendering template"
 	hxRedirect       = "HX-Redirect"
@@ -30,13 +39,14 @@ func New(todoSvc TodoServicer) *Handler {
 }
 
 func (h *Handler) TaskPage(w http.ResponseWriter, r *http.Request) {
-	h.getAll(w, r)
+	h.getAll(w, r, templateIndex)
 }
 
 func (h *Handler) HandleTasks(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		h.getAll(w, r)
+		// only the rows, this is how the list is re-sorted and scrolled
+		h.getAll(w, r, templateTasks)
 	case http.MethodPost:
 		h.addTask(w, r)
 	default:
@@ -44,7 +54,17 @@ func (h *Handler) HandleTasks(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Done moves a task to done
 func (h *Handler) Done(w http.ResponseWriter, r *http.Request) {
+	h.setStatus(w, r, models.StatusDone.String())
+}
+
+// Status moves a task to the status posted in the form
+func (h *Handler) Status(w http.ResponseWriter, r *http.Request) {
+	h.setStatus(w, r, r.PostFormValue("status"))
+}
+
+func (h *Handler) setStatus(w http.ResponseWriter, r *http.Request, status string) {
 	var (
 		ctx    = r.Context()
 		logger = models.GetLoggerFromCtx(ctx)
@@ -58,39 +78,62 @@ func (h *Handler) Done(w http.ResponseWriter, r *http.Request) {
 
 	id := r.PathValue("id")
 
-	resp, err := h.Service.MarkDone(ctx, id, &userID)
+	req, err := statusReq(r, status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.Service.SetStatus(ctx, id, req, &userID)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		h.conflict(w, r, conflictView{Status: req}, id, req.Version, &userID)
+		return
+	}
+
 	if err != nil {
 		switch {
-		case err.Error() == models.ErrNotFound("task").Error():
-			http.Error(w, "task not found", http.StatusNotFound)
+		case errors.Is(err, models.ErrNotFound("task")):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrStatusTransition), errors.Is(err, models.ErrTaskBlocked):
+			http.Error(w, err.Error(), http.StatusConflict)
 		default:
-			w.WriteHeader(http.StatusBadRequest)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
 
-		logger.LogAttrs(ctx, slog.LevelError, "error while marking task done",
+		logger.LogAttrs(ctx, slog.LevelError, "error while changing task status",
 			slog.String("error", err.Error()), slog.String("task", id))
 
+		return
+	}
+
+	if err := h.template.ExecuteTemplate(w, templateAddTask, resp.ToTaskResp(models.GetLocationFromCtx(ctx))); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateAddTask))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
 
-		return
+// statusReq reads the cascade and force flags and the version of the row sent along with a status change
+func statusReq(r *http.Request, status string) (*models.StatusReq, error) {
+	cascade, err := flag(r, "cascade")
+	if err != nil {
+		return nil, err
 	}
 
-	if resp == nil {
-		w.WriteHeader(http.StatusNoContent)
-		return
+	force, err := flag(r, "force")
+	if err != nil {
+		return nil, err
 	}
 
-	if err := h.template.ExecuteTemplate(w, templateAddTask, resp.ToTaskResp()); err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateAddTask))
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := formVersion(r)
+	if err != nil {
+		return nil, err
 	}
+
+	return &models.StatusReq{Status: status, Cascade: cascade, Force: force, Version: version}, nil
 }
 
 func (h *Handler) addTask(w http.ResponseWriter, r *http.Request) {
-	var (
-		ctx    = r.Context()
-		logger = models.GetLoggerFromCtx(ctx)
-	)
+	ctx := r.Context()
 
 	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
 	if !ok {
@@ -98,26 +141,46 @@ func (h *Handler) addTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a quick add line holds the whole task, like "Pay rent tomorrow 9am #home !high"
+	if line := r.PostFormValue("quick"); line != "" {
+		task, err := h.Service.QuickAdd(ctx, line, r.PostFormValue("listId"), &userID)
+		h.renderAdded(w, r, task, err)
+
+		return
+	}
+
 	t := models.TaskReq{
 		Title:       r.PostFormValue("title"),
 		Description: r.PostFormValue("description"),
 		DueDate:     r.PostFormValue("dueDate"),
+		DueTime:     r.PostFormValue("dueTime"),
+		Priority:    r.PostFormValue("priority"),
+		ListID:      r.PostFormValue("listId"),
+		ParentID:    r.PostFormValue("parentId"),
+		Recurrence:  r.PostFormValue("recurrence"),
 	}
 
 	task, err := h.Service.AddTask(ctx, &t, &userID)
+	h.renderAdded(w, r, task, err)
+}
+
+// renderAdded renders the task just added or the error adding it failed with
+func (h *Handler) renderAdded(w http.ResponseWriter, r *http.Request, task *models.Task, err error) {
+	ctx := r.Context()
+
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeSubtaskErr(w, r, err)
 		return
 	}
 
-	if err := h.template.ExecuteTemplate(w, templateAddTask, task.ToTaskResp()); err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateAddTask))
+	if err := h.template.ExecuteTemplate(w, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx))); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateAddTask))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
 // nolint:revive // this is a handler get not returning
-func (h *Handler) getAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) getAll(w http.ResponseWriter, r *http.Request, tmpl string) {
 	var (
 		ctx    = r.Context()
 		logger = models.GetLoggerFromCtx(ctx)
@@ -133,12 +196,25 @@ func (h *Handler) getAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tasks, err := h.Service.GetAll(r.Context(), &userID)
+	req, err := models.ParseTaskListReq(r.URL.Query())
 	if err != nil {
-		if models.ErrNotFound("user").Error() == err.Error() {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.Service.GetAll(r.Context(), req, &userID)
+	if err != nil {
+		switch {
+		case models.ErrNotFound("user").Error() == err.Error():
 			w.Header().Add(hxRedirect, "/?page=register")
 			w.WriteHeader(http.StatusOK)
 
+			return
+		case strings.HasPrefix(err.Error(), models.ErrInvalid("").Error()):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, models.ErrNotFound("list")):
+			http.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
 
@@ -150,18 +226,142 @@ func (h *Handler) getAll(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 
-	trs := []models.TaskResp{}
+	resp := page.ToTaskPageResp(models.GetLocationFromCtx(ctx))
+	resp.Query = nextPageQuery(r.URL.Query(), page)
+
+	if err := h.template.ExecuteTemplate(w, tmpl, resp); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", tmpl))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// nextPageQuery keeps the listing parameters of the current page, without its cursor
+func nextPageQuery(query url.Values, page *models.TaskPage) string {
+	next := url.Values{}
+
+	for key := range query {
+		if key != "cursor" && query.Get(key) != "" {
+			next.Set(key, query.Get(key))
+		}
+	}
+
+	next.Set("limit", strconv.Itoa(page.Limit))
+	next.Set("sort", page.Sort)
+
+	return next.Encode()
+}
+
+// Search renders the tasks matching the q query parameter, best match first with the matched
+// words highlighted
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = models.GetLoggerFromCtx(ctx)
+	)
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			http.Error(w, models.ErrInvalid("limit").Error(), http.StatusBadRequest)
+			return
+		}
+
+		limit = n
+	}
+
+	results, err := h.Service.Search(ctx, r.URL.Query().Get("q"), limit, &userID)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, err.Error(), slog.String("user", userID.String()))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	trs := make([]models.TaskResp, 0, len(results))
+
+	for i := range results {
+		trs = append(trs, *results[i].ToTaskResp(models.GetLocationFromCtx(ctx)))
+	}
+
+	if err := h.template.ExecuteTemplate(w, templateSearch, trs); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateSearch))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Trash renders the user's trashed tasks
+func (h *Handler) Trash(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = models.GetLoggerFromCtx(ctx)
+	)
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	tasks, err := h.Service.ListTrash(ctx, &userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	trs := make([]models.TaskResp, 0, len(tasks))
 
 	for i := range tasks {
-		trs = append(trs, *tasks[i].ToTaskResp())
+		trs = append(trs, *tasks[i].ToTaskResp(models.GetLocationFromCtx(ctx)))
 	}
 
-	if err := h.template.ExecuteTemplate(w, templateIndex, trs); err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateIndex))
+	if err := h.template.ExecuteTemplate(w, templateTrash, trs); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateTrash))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
+// Restore takes a task out of the trash, the empty response removes it from the trash view
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = models.GetLoggerFromCtx(ctx)
+	)
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	if _, err := h.Service.RestoreTask(ctx, id, &userID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound("task in trash")):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+
+		logger.LogAttrs(ctx, slog.LevelError, err.Error(),
+			slog.String("user", userID.String()),
+			slog.String("task", id),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
 func (h *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	var (
 		ctx    = r.Context()
@@ -176,10 +376,30 @@ func (h *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 
 	id := r.PathValue("id")
 
-	if err := h.Service.DeleteTask(ctx, id, &userID); err != nil {
+	all, err := flag(r, "cascade")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := formVersion(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.Service.DeleteTask(ctx, id, all, version, &userID)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		h.conflict(w, r, conflictView{Delete: true, Cascade: all}, id, version, &userID)
+		return
+	}
+
+	if err != nil {
 		switch {
 		case models.ErrNotFound("user").Error() == err.Error():
 			http.Error(w, userNotFound, http.StatusNotFound)
+		case errors.Is(err, models.ErrNotFound("task")):
+			http.Error(w, err.Error(), http.StatusNotFound)
 		default:
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
@@ -212,13 +432,31 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		Title:       r.PostFormValue("title"),
 		Description: r.PostFormValue("description"),
 		DueDate:     r.PostFormValue("dueDate"),
+		DueTime:     r.PostFormValue("dueTime"),
+		Priority:    r.PostFormValue("priority"),
+		Recurrence:  r.PostFormValue("recurrence"),
+	}
+
+	version, err := formVersion(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t.Version = version
+
+	resp, err := h.Service.UpdateTask(ctx, t.ID, &t, &userID)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		h.conflict(w, r, conflictView{Mine: &t}, t.ID, t.Version, &userID)
+		return
 	}
 
-	resp, err := h.Service.UpdateTask(ctx, t.ID, &t, false, &userID)
 	if err != nil {
 		switch {
 		case models.ErrNotFound("user").Error() == err.Error():
 			http.Error(w, userNotFound, http.StatusNotFound)
+		case errors.Is(err, models.ErrNotFound("task")):
+			http.Error(w, err.Error(), http.StatusNotFound)
 		default:
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
@@ -236,7 +474,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.template.ExecuteTemplate(w, templateAddTask, *resp.ToTaskResp()); err != nil {
+	if err := h.template.ExecuteTemplate(w, templateAddTask, *resp.ToTaskResp(models.GetLocationFromCtx(ctx))); err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateAddTask))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
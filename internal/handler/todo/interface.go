@@ -9,9 +9,30 @@ import (
 
 //go:generate mockgen --source=interface.go --destination=mock_interface.go --package=todohttp
 type TodoServicer interface {
-	GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, error)
+	GetAll(ctx context.Context, req *models.TaskListReq, userID *uuid.UUID) (*models.TaskPage, error)
+	GetTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
 	AddTask(ctx context.Context, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error)
-	DeleteTask(ctx context.Context, id string, userID *uuid.UUID) error
-	UpdateTask(ctx context.Context, id string, task *models.TaskReq, isDone bool, userID *uuid.UUID) (*models.Task, error)
-	MarkDone(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	QuickAdd(ctx context.Context, line, listID string, userID *uuid.UUID) (*models.Task, error)
+	DeleteTask(ctx context.Context, id string, cascade bool, version int64, userID *uuid.UUID) error
+	UpdateTask(ctx context.Context, id string, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error)
+	SetStatus(ctx context.Context, id string, req *models.StatusReq, userID *uuid.UUID) (*models.Task, error)
+	Search(ctx context.Context, query string, limit int, userID *uuid.UUID) ([]models.SearchResult, error)
+	ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error)
+	RestoreTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	History(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, []models.Revision, error)
+	RestoreRevision(ctx context.Context, id string, number, version int64, userID *uuid.UUID) (*models.Task, error)
+	ListTags(ctx context.Context, userID *uuid.UUID) ([]models.Tag, error)
+	CreateTag(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error)
+	RenameTag(ctx context.Context, id, name string, userID *uuid.UUID) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error
+	TagTask(ctx context.Context, taskID, name string, userID *uuid.UUID) (*models.Task, error)
+	UntagTask(ctx context.Context, taskID, tagID string, userID *uuid.UUID) (*models.Task, error)
+	MoveTask(ctx context.Context, id, listID string, userID *uuid.UUID) (*models.Task, error)
+	SetParent(ctx context.Context, id, parentID string, userID *uuid.UUID) (*models.Task, error)
+	AddBlocker(ctx context.Context, id, blockerID string, userID *uuid.UUID) (*models.Task, error)
+	RemoveBlocker(ctx context.Context, id, blockerID string, userID *uuid.UUID) (*models.Task, error)
+	SkipOccurrence(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	EndSeries(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	AddReminder(ctx context.Context, id string, req *models.ReminderReq, userID *uuid.UUID) (*models.Task, error)
+	RemoveReminder(ctx context.Context, id, reminderID string, userID *uuid.UUID) (*models.Task, error)
 }
@@ -0,0 +1,46 @@
+package todohttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MoveTask moves a task to the list posted in the form, an empty listId takes it out of its list
+func (h *Handler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.MoveTask(ctx, r.PathValue("id"), r.PostFormValue("listId"), &userID)
+	if err != nil {
+		writeListErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+func writeListErr(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, err.Error(),
+		slog.String("path", r.URL.Path))
+
+	switch {
+	case errors.Is(err, models.ErrNotFound("task")), errors.Is(err, models.ErrNotFound("list")):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, models.ErrListArchived), errors.Is(err, models.ErrSubtaskList):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
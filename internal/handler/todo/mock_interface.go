@@ -42,6 +42,36 @@ func (m *MockTodoServicer) EXPECT() *MockTodoServicerMockRecorder {
 	return m.recorder
 }
 
+// AddBlocker mocks base method.
+func (m *MockTodoServicer) AddBlocker(ctx context.Context, id, blockerID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlocker", ctx, id, blockerID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBlocker indicates an expected call of AddBlocker.
+func (mr *MockTodoServicerMockRecorder) AddBlocker(ctx, id, blockerID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlocker", reflect.TypeOf((*MockTodoServicer)(nil).AddBlocker), ctx, id, blockerID, userID)
+}
+
+// AddReminder mocks base method.
+func (m *MockTodoServicer) AddReminder(ctx context.Context, id string, req *models.ReminderReq, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReminder", ctx, id, req, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReminder indicates an expected call of AddReminder.
+func (mr *MockTodoServicerMockRecorder) AddReminder(ctx, id, req, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminder", reflect.TypeOf((*MockTodoServicer)(nil).AddReminder), ctx, id, req, userID)
+}
+
 // AddTask mocks base method.
 func (m *MockTodoServicer) AddTask(ctx context.Context, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error) {
 	m.ctrl.T.Helper()
@@ -57,61 +87,346 @@ func (mr *MockTodoServicerMockRecorder) AddTask(ctx, task, userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockTodoServicer)(nil).AddTask), ctx, task, userID)
 }
 
+// CreateTag mocks base method.
+func (m *MockTodoServicer) CreateTag(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, name, userID)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTodoServicerMockRecorder) CreateTag(ctx, name, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTodoServicer)(nil).CreateTag), ctx, name, userID)
+}
+
+// DeleteTag mocks base method.
+func (m *MockTodoServicer) DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTodoServicerMockRecorder) DeleteTag(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTodoServicer)(nil).DeleteTag), ctx, id, userID)
+}
+
 // DeleteTask mocks base method.
-func (m *MockTodoServicer) DeleteTask(ctx context.Context, id string, userID *uuid.UUID) error {
+func (m *MockTodoServicer) DeleteTask(ctx context.Context, id string, cascade bool, version int64, userID *uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, id, userID)
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, id, cascade, version, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTodoServicerMockRecorder) DeleteTask(ctx, id, userID any) *gomock.Call {
+func (mr *MockTodoServicerMockRecorder) DeleteTask(ctx, id, cascade, version, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoServicer)(nil).DeleteTask), ctx, id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoServicer)(nil).DeleteTask), ctx, id, cascade, version, userID)
+}
+
+// EndSeries mocks base method.
+func (m *MockTodoServicer) EndSeries(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndSeries", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndSeries indicates an expected call of EndSeries.
+func (mr *MockTodoServicerMockRecorder) EndSeries(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndSeries", reflect.TypeOf((*MockTodoServicer)(nil).EndSeries), ctx, id, userID)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoServicer) GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+func (m *MockTodoServicer) GetAll(ctx context.Context, req *models.TaskListReq, userID *uuid.UUID) (*models.TaskPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
-	ret0, _ := ret[0].([]models.Task)
+	ret := m.ctrl.Call(m, "GetAll", ctx, req, userID)
+	ret0, _ := ret[0].(*models.TaskPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoServicerMockRecorder) GetAll(ctx, userID any) *gomock.Call {
+func (mr *MockTodoServicerMockRecorder) GetAll(ctx, req, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoServicer)(nil).GetAll), ctx, req, userID)
+}
+
+// GetTask mocks base method.
+func (m *MockTodoServicer) GetTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockTodoServicerMockRecorder) GetTask(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTodoServicer)(nil).GetTask), ctx, id, userID)
+}
+
+// History mocks base method.
+func (m *MockTodoServicer) History(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, []models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].([]models.Revision)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// History indicates an expected call of History.
+func (mr *MockTodoServicerMockRecorder) History(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockTodoServicer)(nil).History), ctx, id, userID)
+}
+
+// ListTags mocks base method.
+func (m *MockTodoServicer) ListTags(ctx context.Context, userID *uuid.UUID) ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, userID)
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockTodoServicerMockRecorder) ListTags(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockTodoServicer)(nil).ListTags), ctx, userID)
+}
+
+// ListTrash mocks base method.
+func (m *MockTodoServicer) ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, userID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockTodoServicerMockRecorder) ListTrash(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockTodoServicer)(nil).ListTrash), ctx, userID)
+}
+
+// MoveTask mocks base method.
+func (m *MockTodoServicer) MoveTask(ctx context.Context, id, listID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, id, listID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTodoServicerMockRecorder) MoveTask(ctx, id, listID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTodoServicer)(nil).MoveTask), ctx, id, listID, userID)
+}
+
+// QuickAdd mocks base method.
+func (m *MockTodoServicer) QuickAdd(ctx context.Context, line, listID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuickAdd", ctx, line, listID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuickAdd indicates an expected call of QuickAdd.
+func (mr *MockTodoServicerMockRecorder) QuickAdd(ctx, line, listID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuickAdd", reflect.TypeOf((*MockTodoServicer)(nil).QuickAdd), ctx, line, listID, userID)
+}
+
+// RemoveBlocker mocks base method.
+func (m *MockTodoServicer) RemoveBlocker(ctx context.Context, id, blockerID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBlocker", ctx, id, blockerID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveBlocker indicates an expected call of RemoveBlocker.
+func (mr *MockTodoServicerMockRecorder) RemoveBlocker(ctx, id, blockerID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBlocker", reflect.TypeOf((*MockTodoServicer)(nil).RemoveBlocker), ctx, id, blockerID, userID)
+}
+
+// RemoveReminder mocks base method.
+func (m *MockTodoServicer) RemoveReminder(ctx context.Context, id, reminderID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReminder", ctx, id, reminderID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReminder indicates an expected call of RemoveReminder.
+func (mr *MockTodoServicerMockRecorder) RemoveReminder(ctx, id, reminderID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReminder", reflect.TypeOf((*MockTodoServicer)(nil).RemoveReminder), ctx, id, reminderID, userID)
+}
+
+// RenameTag mocks base method.
+func (m *MockTodoServicer) RenameTag(ctx context.Context, id, name string, userID *uuid.UUID) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, id, name, userID)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockTodoServicerMockRecorder) RenameTag(ctx, id, name, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockTodoServicer)(nil).RenameTag), ctx, id, name, userID)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockTodoServicer) RestoreRevision(ctx context.Context, id string, number, version int64, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, id, number, version, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockTodoServicerMockRecorder) RestoreRevision(ctx, id, number, version, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockTodoServicer)(nil).RestoreRevision), ctx, id, number, version, userID)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTodoServicer) RestoreTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTodoServicerMockRecorder) RestoreTask(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTodoServicer)(nil).RestoreTask), ctx, id, userID)
+}
+
+// Search mocks base method.
+func (m *MockTodoServicer) Search(ctx context.Context, query string, limit int, userID *uuid.UUID) ([]models.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, userID)
+	ret0, _ := ret[0].([]models.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoServicerMockRecorder) Search(ctx, query, limit, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoServicer)(nil).Search), ctx, query, limit, userID)
+}
+
+// SetParent mocks base method.
+func (m *MockTodoServicer) SetParent(ctx context.Context, id, parentID string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParent", ctx, id, parentID, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetParent indicates an expected call of SetParent.
+func (mr *MockTodoServicerMockRecorder) SetParent(ctx, id, parentID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockTodoServicer)(nil).SetParent), ctx, id, parentID, userID)
+}
+
+// SetStatus mocks base method.
+func (m *MockTodoServicer) SetStatus(ctx context.Context, id string, req *models.StatusReq, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, req, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockTodoServicerMockRecorder) SetStatus(ctx, id, req, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockTodoServicer)(nil).SetStatus), ctx, id, req, userID)
+}
+
+// SkipOccurrence mocks base method.
+func (m *MockTodoServicer) SkipOccurrence(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipOccurrence", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkipOccurrence indicates an expected call of SkipOccurrence.
+func (mr *MockTodoServicerMockRecorder) SkipOccurrence(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipOccurrence", reflect.TypeOf((*MockTodoServicer)(nil).SkipOccurrence), ctx, id, userID)
+}
+
+// TagTask mocks base method.
+func (m *MockTodoServicer) TagTask(ctx context.Context, taskID, name string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagTask", ctx, taskID, name, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagTask indicates an expected call of TagTask.
+func (mr *MockTodoServicerMockRecorder) TagTask(ctx, taskID, name, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoServicer)(nil).GetAll), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagTask", reflect.TypeOf((*MockTodoServicer)(nil).TagTask), ctx, taskID, name, userID)
 }
 
-// MarkDone mocks base method.
-func (m *MockTodoServicer) MarkDone(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+// UntagTask mocks base method.
+func (m *MockTodoServicer) UntagTask(ctx context.Context, taskID, tagID string, userID *uuid.UUID) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkDone", ctx, id, userID)
+	ret := m.ctrl.Call(m, "UntagTask", ctx, taskID, tagID, userID)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MarkDone indicates an expected call of MarkDone.
-func (mr *MockTodoServicerMockRecorder) MarkDone(ctx, id, userID any) *gomock.Call {
+// UntagTask indicates an expected call of UntagTask.
+func (mr *MockTodoServicerMockRecorder) UntagTask(ctx, taskID, tagID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDone", reflect.TypeOf((*MockTodoServicer)(nil).MarkDone), ctx, id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagTask", reflect.TypeOf((*MockTodoServicer)(nil).UntagTask), ctx, taskID, tagID, userID)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTodoServicer) UpdateTask(ctx context.Context, id string, task *models.TaskReq, isDone bool, userID *uuid.UUID) (*models.Task, error) {
+func (m *MockTodoServicer) UpdateTask(ctx context.Context, id string, task *models.TaskReq, userID *uuid.UUID) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, id, task, isDone, userID)
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, id, task, userID)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTodoServicerMockRecorder) UpdateTask(ctx, id, task, isDone, userID any) *gomock.Call {
+func (mr *MockTodoServicerMockRecorder) UpdateTask(ctx, id, task, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoServicer)(nil).UpdateTask), ctx, id, task, isDone, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoServicer)(nil).UpdateTask), ctx, id, task, userID)
 }
@@ -0,0 +1,57 @@
+package todohttp
+
+import (
+	"errors"
+	"net/http"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SkipOccurrence moves a recurring task on to its next occurrence and renders the task again
+func (h *Handler) SkipOccurrence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.SkipOccurrence(ctx, r.PathValue("id"), &userID)
+	if err != nil {
+		writeRecurrenceErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+// EndSeries stops a recurring task from coming back and renders the task again
+func (h *Handler) EndSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.EndSeries(ctx, r.PathValue("id"), &userID)
+	if err != nil {
+		writeRecurrenceErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+func writeRecurrenceErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, models.ErrNotRecurring) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	writeListErr(w, r, err)
+}
@@ -0,0 +1,62 @@
+package todohttp
+
+import (
+	"errors"
+	"net/http"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AddReminder reminds the user of a task, either the posted before its due date or at the posted
+// time, and renders the task again
+func (h *Handler) AddReminder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	req := models.ReminderReq{Before: r.PostFormValue("before"), At: r.PostFormValue("at")}
+
+	task, err := h.Service.AddReminder(ctx, r.PathValue("id"), &req, &userID)
+	if err != nil {
+		writeReminderErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+// RemoveReminder drops a reminder of a task and renders the task again
+func (h *Handler) RemoveReminder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.RemoveReminder(ctx, r.PathValue("id"), r.PathValue("reminderId"), &userID)
+	if err != nil {
+		writeReminderErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+func writeReminderErr(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, models.ErrTooManyReminders):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, models.ErrNotFound("reminder")):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		writeListErr(w, r, err)
+	}
+}
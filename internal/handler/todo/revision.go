@@ -0,0 +1,117 @@
+package todohttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// historyView is what the history template renders, Stale when a restore was refused because the task
+// changed since the history was shown
+type historyView struct {
+	Task      models.TaskResp
+	Revisions []models.Revision
+	Stale     bool
+}
+
+// History renders the revisions of a task, newest first
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	h.renderHistory(w, r, r.PathValue("id"), false, &userID)
+}
+
+// RestoreRevision brings a task back to one of its revisions and renders its updated history
+func (h *Handler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = models.GetLoggerFromCtx(ctx)
+	)
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	number, err := strconv.ParseInt(r.PathValue("number"), 10, 64)
+	if err != nil {
+		http.Error(w, models.ErrInvalid("revision number").Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := formVersion(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.Service.RestoreRevision(ctx, id, number, version, &userID)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		h.renderHistory(w, r, id, true, &userID)
+		return
+	}
+
+	if err != nil {
+		writeRevisionErr(w, err)
+
+		logger.LogAttrs(ctx, slog.LevelError, err.Error(),
+			slog.String("user", userID.String()),
+			slog.String("task", id),
+		)
+
+		return
+	}
+
+	h.renderHistory(w, r, id, false, &userID)
+}
+
+// renderHistory renders the history of the task as it is now, a stale one answers a restore made from
+// an outdated history with 412
+func (h *Handler) renderHistory(w http.ResponseWriter, r *http.Request, id string, stale bool, userID *uuid.UUID) {
+	var (
+		ctx    = r.Context()
+		logger = models.GetLoggerFromCtx(ctx)
+	)
+
+	task, revs, err := h.Service.History(ctx, id, userID)
+	if err != nil {
+		writeRevisionErr(w, err)
+		return
+	}
+
+	view := historyView{Task: *task.ToTaskResp(models.GetLocationFromCtx(ctx)), Revisions: revs, Stale: stale}
+
+	if stale {
+		w.WriteHeader(http.StatusPreconditionFailed)
+	}
+
+	if err := h.template.ExecuteTemplate(w, templateHistory, view); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateHistory))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeRevisionErr(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotFound("task")), errors.Is(err, models.ErrNotFound("revision")):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, models.ErrStatusTransition), errors.Is(err, models.ErrTaskBlocked):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,38 @@
+package todohttp
+
+import (
+	"errors"
+	"net/http"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SetParent makes a task a subtask of the task posted as parentId, an empty parentId makes it a top level task
+func (h *Handler) SetParent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.SetParent(ctx, r.PathValue("id"), r.PostFormValue("parentId"), &userID)
+	if err != nil {
+		writeSubtaskErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+func writeSubtaskErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, models.ErrSubtaskCycle) || errors.Is(err, models.ErrSubtaskDepth) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	writeListErr(w, r, err)
+}
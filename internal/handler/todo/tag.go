@@ -0,0 +1,174 @@
+package todohttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	templateTags = "tags"
+	templateTag  = "tag"
+)
+
+func (h *Handler) HandleTags(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listTags(w, r)
+	case http.MethodPost:
+		h.createTag(w, r)
+	default:
+		http.Error(w, invalidReqMethod, http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) HandleTag(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.renameTag(w, r)
+	case http.MethodDelete:
+		h.deleteTag(w, r)
+	default:
+		http.Error(w, invalidReqMethod, http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) listTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	tags, err := h.Service.ListTags(ctx, &userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.render(w, r, templateTags, tags)
+}
+
+func (h *Handler) createTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	tag, err := h.Service.CreateTag(ctx, r.PostFormValue("name"), &userID)
+	if err != nil {
+		writeTagErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	h.render(w, r, templateTag, tag)
+}
+
+func (h *Handler) renameTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	tag, err := h.Service.RenameTag(ctx, r.PathValue("id"), r.PostFormValue("name"), &userID)
+	if err != nil {
+		writeTagErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateTag, tag)
+}
+
+// deleteTag deletes a tag, the empty response removes it from the tag list
+func (h *Handler) deleteTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.DeleteTag(ctx, r.PathValue("id"), &userID); err != nil {
+		writeTagErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// TagTask attaches the tag named in the form to a task and renders the task again
+func (h *Handler) TagTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.TagTask(ctx, r.PathValue("id"), r.PostFormValue("name"), &userID)
+	if err != nil {
+		writeTagErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+// UntagTask takes a tag off a task and renders the task again
+func (h *Handler) UntagTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, userNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	task, err := h.Service.UntagTask(ctx, r.PathValue("id"), r.PathValue("tagId"), &userID)
+	if err != nil {
+		writeTagErr(w, r, err)
+		return
+	}
+
+	h.render(w, r, templateAddTask, task.ToTaskResp(models.GetLocationFromCtx(ctx)))
+}
+
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, tmpl string, data any) {
+	ctx := r.Context()
+
+	if err := h.template.ExecuteTemplate(w, tmpl, data); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", tmpl))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeTagErr(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, err.Error(),
+		slog.String("path", r.URL.Path))
+
+	switch {
+	case errors.Is(err, models.ErrNotFound("task")), errors.Is(err, models.ErrNotFound("tag")):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, models.ErrTagAlreadyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -2,10 +2,13 @@ package userhttp
 
 import (
 	"errors"
+	"html/template"
 	"log/slog"
 	"net/http"
 
 	"todoapp/internal/models"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -13,14 +16,16 @@ const (
 	contentType = "Content-Type"
 	token       = "token"
 	hxRedirect  = "HX-Redirect"
+	hxRefresh   = "HX-Refresh"
 )
 
 type Handler struct {
-	Service UserServicer
+	Service  UserServicer
+	template *template.Template
 }
 
 func New(usrSvc UserServicer) *Handler {
-	return &Handler{Service: usrSvc}
+	return &Handler{Service: usrSvc, template: models.NewTemplate()}
 }
 
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
@@ -30,6 +35,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	var user models.RegisterReq
 
 	user.Name = r.FormValue("name")
+	user.TimeZone = r.FormValue("timeZone")
 	user.LoginReq = &models.LoginReq{
 		Email:    r.FormValue("email"),
 		Password: r.FormValue("password"),
@@ -158,3 +164,31 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 
 	logger.LogAttrs(ctx, slog.LevelDebug, "user logout success!", slog.String("token", c.Value))
 }
+
+// SetTimeZone changes the time zone posted as timeZone and reloads the page to show the due dates in it
+func (h *Handler) SetTimeZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, "invalid user", http.StatusUnauthorized)
+		return
+	}
+
+	loc, err := h.Service.SetTimeZone(ctx, &userID, r.FormValue("timeZone"))
+	if err != nil {
+		if errors.Is(err, models.ErrInvalid("time zone")) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Add(hxRefresh, "true")
+	w.WriteHeader(http.StatusOK)
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelDebug, "time zone changed",
+		slog.String("user", userID.String()), slog.String("timeZone", loc.String()))
+}
@@ -2,8 +2,11 @@ package userhttp
 
 import (
 	"context"
+	"time"
 
 	"todoapp/internal/models"
+
+	"github.com/google/uuid"
 )
 
 //go:generate mockgen --source=interface.go --destination=mock_interface.go --package=userhttp
@@ -11,4 +14,8 @@ type UserServicer interface {
 	Register(ctx context.Context, req *models.RegisterReq) (*models.SessionData, error)
 	Login(ctx context.Context, req *models.LoginReq) (*models.SessionData, error)
 	Logout(ctx context.Context, token string) error
+	SetTimeZone(ctx context.Context, userID *uuid.UUID, timeZone string) (*time.Location, error)
+	CreateToken(ctx context.Context, userID *uuid.UUID, req *models.TokenReq) (*models.NewAccessToken, error)
+	ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error)
+	RevokeToken(ctx context.Context, id string, userID *uuid.UUID) error
 }
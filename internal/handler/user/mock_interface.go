@@ -12,8 +12,10 @@ package userhttp
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	models "todoapp/internal/models"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +43,36 @@ func (m *MockUserServicer) EXPECT() *MockUserServicerMockRecorder {
 	return m.recorder
 }
 
+// CreateToken mocks base method.
+func (m *MockUserServicer) CreateToken(ctx context.Context, userID *uuid.UUID, req *models.TokenReq) (*models.NewAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, userID, req)
+	ret0, _ := ret[0].(*models.NewAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockUserServicerMockRecorder) CreateToken(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockUserServicer)(nil).CreateToken), ctx, userID, req)
+}
+
+// ListTokens mocks base method.
+func (m *MockUserServicer) ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTokens", ctx, userID)
+	ret0, _ := ret[0].([]models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTokens indicates an expected call of ListTokens.
+func (mr *MockUserServicerMockRecorder) ListTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTokens", reflect.TypeOf((*MockUserServicer)(nil).ListTokens), ctx, userID)
+}
+
 // Login mocks base method.
 func (m *MockUserServicer) Login(ctx context.Context, req *models.LoginReq) (*models.SessionData, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +116,32 @@ func (mr *MockUserServicerMockRecorder) Register(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserServicer)(nil).Register), ctx, req)
 }
+
+// RevokeToken mocks base method.
+func (m *MockUserServicer) RevokeToken(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockUserServicerMockRecorder) RevokeToken(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockUserServicer)(nil).RevokeToken), ctx, id, userID)
+}
+
+// SetTimeZone mocks base method.
+func (m *MockUserServicer) SetTimeZone(ctx context.Context, userID *uuid.UUID, timeZone string) (*time.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimeZone", ctx, userID, timeZone)
+	ret0, _ := ret[0].(*time.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTimeZone indicates an expected call of SetTimeZone.
+func (mr *MockUserServicerMockRecorder) SetTimeZone(ctx, userID, timeZone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeZone", reflect.TypeOf((*MockUserServicer)(nil).SetTimeZone), ctx, userID, timeZone)
+}
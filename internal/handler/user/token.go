@@ -0,0 +1,128 @@
+package userhttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	renderErr      = "error while rendering template"
+	templateTokens = "tokens"
+)
+
+// tokenView is an access token with its times in the user's time zone
+type tokenView struct {
+	models.AccessToken
+	Created  string
+	LastUsed string
+}
+
+// tokensPage is the token list, New is the token just created which is shown this once
+type tokensPage struct {
+	Tokens []tokenView
+	Scopes []models.Scope
+	New    string
+}
+
+// HandleTokens lists the user's personal access tokens or creates one from the posted name and
+// scopes
+func (h *Handler) HandleTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, "invalid user", http.StatusUnauthorized)
+		return
+	}
+
+	var page tokensPage
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created, err := h.Service.CreateToken(ctx, &userID, &models.TokenReq{Name: r.PostForm.Get("name"), Scopes: r.PostForm["scopes"]})
+		if err != nil {
+			tokenError(w, r, err)
+			return
+		}
+
+		page.New = created.Token
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	tokens, err := h.Service.ListTokens(ctx, &userID)
+	if err != nil {
+		tokenError(w, r, err)
+		return
+	}
+
+	loc := models.GetLocationFromCtx(ctx)
+	page.Scopes = models.Scopes()
+	page.Tokens = make([]tokenView, 0, len(tokens))
+
+	for _, t := range tokens {
+		view := tokenView{AccessToken: t, Created: t.CreatedAt.In(loc).Format(time.DateTime)}
+		if t.LastUsedAt != nil {
+			view.LastUsed = t.LastUsedAt.In(loc).Format(time.DateTime)
+		}
+
+		page.Tokens = append(page.Tokens, view)
+	}
+
+	if err := h.template.ExecuteTemplate(w, templateTokens, page); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, renderErr, slog.String("template", templateTokens))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RevokeToken deletes an access token, the empty response takes its row away
+func (h *Handler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(models.CtxKeyUserID).(uuid.UUID)
+	if !ok {
+		http.Error(w, "invalid user", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.RevokeToken(ctx, r.PathValue("id"), &userID); err != nil {
+		tokenError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func tokenError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	msg := err.Error()
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, msg, slog.String("path", r.URL.Path))
+
+	switch {
+	case errors.Is(err, models.ErrNotFound("token")):
+		http.Error(w, msg, http.StatusNotFound)
+	case errors.Is(err, models.ErrScopeMissing):
+		http.Error(w, msg, http.StatusForbidden)
+	case strings.HasPrefix(msg, models.ErrInvalid("").Error()), strings.HasPrefix(msg, models.ErrRequired("").Error()):
+		http.Error(w, msg, http.StatusBadRequest)
+	default:
+		http.Error(w, msg, http.StatusInternalServerError)
+	}
+}
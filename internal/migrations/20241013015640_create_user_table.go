@@ -1,8 +1,6 @@
 package migrations
 
-import (
-	"github.com/sqlitecloud/sqlitecloud-go"
-)
+import "todoapp/internal/database"
 
 const (
 	userDown = "DROP TABLE IF EXISTS users;"
@@ -16,11 +14,11 @@ const (
 type M20241013015640 string
 
 // nolint:revive // unused but need this as method
-func (m M20241013015640) up(db *sqlitecloud.SQCloud) error {
+func (m M20241013015640) up(db database.Querier) error {
 	return db.Execute(userUp)
 }
 
 // nolint:revive // unused but need this as method
-func (m M20241013015640) down(db *sqlitecloud.SQCloud) error {
+func (m M20241013015640) down(db database.Querier) error {
 	return db.Execute(userDown)
 }
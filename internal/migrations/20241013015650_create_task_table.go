@@ -1,6 +1,6 @@
 package migrations
 
-import "github.com/sqlitecloud/sqlitecloud-go"
+import "todoapp/internal/database"
 
 const (
 	tasksDown = "DROP TABLE IF EXISTS tasks;"
@@ -13,16 +13,25 @@ const (
     due_date DATE,
     added_at DATETIME NOT NULL,
     modified_at DATETIME);`
+	tasksUpPostgres = `CREATE TABLE IF NOT EXISTS tasks(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    done_status SMALLINT NOT NULL CHECK (done_status IN (0, 1)),
+    due_date BIGINT,
+    added_at BIGINT NOT NULL,
+    modified_at BIGINT);`
 )
 
 type M20241013015650 string
 
 // nolint:revive // unused but need this as method
-func (m M20241013015650) up(db *sqlitecloud.SQCloud) error {
-	return db.Execute(tasksUp)
+func (m M20241013015650) up(db database.Querier) error {
+	return db.Execute(dialect(db, tasksUp, tasksUpPostgres))
 }
 
 // nolint:revive // unused but need this as method
-func (m M20241013015650) down(db *sqlitecloud.SQCloud) error {
+func (m M20241013015650) down(db database.Querier) error {
 	return db.Execute(tasksDown)
 }
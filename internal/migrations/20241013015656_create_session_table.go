@@ -1,6 +1,6 @@
 package migrations
 
-import "github.com/sqlitecloud/sqlitecloud-go"
+import "todoapp/internal/database"
 
 const (
 	sessionDown = "DROP TABLE IF EXISTS sessions;"
@@ -9,16 +9,21 @@ const (
     user_id TEXT NOT NULL UNIQUE,
     token TEXT NOT NULL UNIQUE, 
     expiry DATETIME NOT NULL);`
+	sessionUpPostgres = `CREATE TABLE IF NOT EXISTS sessions(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL UNIQUE,
+    token TEXT NOT NULL UNIQUE,
+    expiry BIGINT NOT NULL);`
 )
 
 type M20241013015656 string
 
 // nolint:revive // unused but need this as method
-func (m M20241013015656) up(db *sqlitecloud.SQCloud) error {
-	return db.Execute(sessionUp)
+func (m M20241013015656) up(db database.Querier) error {
+	return db.Execute(dialect(db, sessionUp, sessionUpPostgres))
 }
 
 // nolint:revive // unused but need this as method
-func (m M20241013015656) down(db *sqlitecloud.SQCloud) error {
+func (m M20241013015656) down(db database.Querier) error {
 	return db.Execute(sessionDown)
 }
@@ -0,0 +1,21 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskListIndexUp   = "CREATE INDEX IF NOT EXISTS idx_tasks_user_added ON tasks(user_id, added_at, id);"
+	taskListIndexDown = "DROP INDEX IF EXISTS idx_tasks_user_added;"
+)
+
+// M20261017090000 backs the default task listing order so paging doesn't scan all the user's tasks
+type M20261017090000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017090000) up(db database.Querier) error {
+	return db.Execute(taskListIndexUp)
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017090000) down(db database.Querier) error {
+	return db.Execute(taskListIndexDown)
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskSearchUp = `CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+    task_id UNINDEXED,
+    user_id UNINDEXED,
+    title,
+    description,
+    tokenize='unicode61 remove_diacritics 2');`
+	taskSearchFill = "INSERT INTO tasks_fts(task_id, user_id, title, description) " +
+		"SELECT id, user_id, title, COALESCE(description, '') FROM tasks;"
+	taskSearchDown = "DROP TABLE IF EXISTS tasks_fts;"
+
+	// postgres has no FTS5, the search runs on a text search index over the tasks table instead
+	taskSearchUpPostgres = "CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks " +
+		"USING GIN (to_tsvector('simple', title || ' ' || COALESCE(description, '')));"
+	taskSearchDownPostgres = "DROP INDEX IF EXISTS idx_tasks_search;"
+)
+
+// M20261017100000 adds the full-text index behind the task search, the existing tasks are indexed
+type M20261017100000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017100000) up(db database.Querier) error {
+	if db.Driver() == database.DriverPostgres {
+		return db.Execute(taskSearchUpPostgres)
+	}
+
+	if err := db.Execute(taskSearchUp); err != nil {
+		return err
+	}
+
+	return db.Execute(taskSearchFill)
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017100000) down(db database.Querier) error {
+	return db.Execute(dialect(db, taskSearchDown, taskSearchDownPostgres))
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskTrashUp         = "ALTER TABLE tasks ADD COLUMN deleted_at DATETIME;"
+	taskTrashUpPostgres = "ALTER TABLE tasks ADD COLUMN deleted_at BIGINT;"
+	taskTrashIndex      = "CREATE INDEX IF NOT EXISTS idx_tasks_deleted ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;"
+	taskTrashIndexDown  = "DROP INDEX IF EXISTS idx_tasks_deleted;"
+	taskTrashDown       = "ALTER TABLE tasks DROP COLUMN deleted_at;"
+)
+
+// M20261017110000 lets tasks be moved to the trash instead of being deleted right away
+type M20261017110000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017110000) up(db database.Querier) error {
+	if err := db.Execute(dialect(db, taskTrashUp, taskTrashUpPostgres)); err != nil {
+		return err
+	}
+
+	return db.Execute(taskTrashIndex)
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017110000) down(db database.Querier) error {
+	if err := db.Execute(taskTrashIndexDown); err != nil {
+		return err
+	}
+
+	return db.Execute(taskTrashDown)
+}
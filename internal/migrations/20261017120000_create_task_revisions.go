@@ -0,0 +1,34 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	revisionsDown = "DROP TABLE IF EXISTS task_revisions;"
+	revisionsUp   = `CREATE TABLE IF NOT EXISTS task_revisions(
+    task_id TEXT NOT NULL,
+    number INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    changed_at DATETIME NOT NULL,
+    changes TEXT NOT NULL,
+    PRIMARY KEY (task_id, number));`
+	revisionsUpPostgres = `CREATE TABLE IF NOT EXISTS task_revisions(
+    task_id TEXT NOT NULL,
+    number BIGINT NOT NULL,
+    user_id TEXT NOT NULL,
+    changed_at BIGINT NOT NULL,
+    changes TEXT NOT NULL,
+    PRIMARY KEY (task_id, number));`
+)
+
+// M20261017120000 keeps the field changes of every task update
+type M20261017120000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017120000) up(db database.Querier) error {
+	return db.Execute(dialect(db, revisionsUp, revisionsUpPostgres))
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017120000) down(db database.Querier) error {
+	return db.Execute(revisionsDown)
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskStatusUp = "ALTER TABLE tasks ADD COLUMN status TEXT NOT NULL DEFAULT 'todo' " +
+		"CHECK (status IN ('todo', 'in_progress', 'blocked', 'done', 'cancelled'));"
+	taskCompletedUp         = "ALTER TABLE tasks ADD COLUMN completed_at DATETIME;"
+	taskCompletedUpPostgres = "ALTER TABLE tasks ADD COLUMN completed_at BIGINT;"
+	// tasks done before statuses existed, the last change is the best guess of their completion
+	taskStatusBackfill = "UPDATE tasks SET status='done', completed_at=COALESCE(modified_at, added_at) WHERE done_status=1;"
+	taskCompletedDown  = "ALTER TABLE tasks DROP COLUMN completed_at;"
+	taskStatusDown     = "ALTER TABLE tasks DROP COLUMN status;"
+)
+
+// M20261017130000 replaces the done flag with a status workflow, done_status is kept in step
+// with the status for sorting and filtering
+type M20261017130000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017130000) up(db database.Querier) error {
+	for _, query := range []string{taskStatusUp, dialect(db, taskCompletedUp, taskCompletedUpPostgres), taskStatusBackfill} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017130000) down(db database.Querier) error {
+	if err := db.Execute(taskCompletedDown); err != nil {
+		return err
+	}
+
+	return db.Execute(taskStatusDown)
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskPriorityUp = "ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 0 " +
+		"CHECK (priority BETWEEN 0 AND 4);"
+	taskPriorityIndex     = "CREATE INDEX IF NOT EXISTS idx_tasks_user_priority ON tasks(user_id, priority, due_date);"
+	taskPriorityIndexDown = "DROP INDEX IF EXISTS idx_tasks_user_priority;"
+	taskPriorityDown      = "ALTER TABLE tasks DROP COLUMN priority;"
+)
+
+// M20261017140000 adds the task priority, from 0 for none up to 4 for urgent
+type M20261017140000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017140000) up(db database.Querier) error {
+	if err := db.Execute(taskPriorityUp); err != nil {
+		return err
+	}
+
+	return db.Execute(taskPriorityIndex)
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017140000) down(db database.Querier) error {
+	if err := db.Execute(taskPriorityIndexDown); err != nil {
+		return err
+	}
+
+	return db.Execute(taskPriorityDown)
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	tagsUp = `CREATE TABLE IF NOT EXISTS tags(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (user_id, name));`
+	tagsUpPostgres = `CREATE TABLE IF NOT EXISTS tags(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    UNIQUE (user_id, name));`
+	taskTagsUp = `CREATE TABLE IF NOT EXISTS task_tags(
+    task_id TEXT NOT NULL,
+    tag_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, tag_id));`
+	taskTagsIndex = "CREATE INDEX IF NOT EXISTS idx_task_tags_tag ON task_tags(tag_id);"
+	taskTagsDown  = "DROP TABLE IF EXISTS task_tags;"
+	tagsDown      = "DROP TABLE IF EXISTS tags;"
+)
+
+// M20261017150000 adds the user tags and their assignment to tasks
+type M20261017150000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017150000) up(db database.Querier) error {
+	for _, query := range []string{dialect(db, tagsUp, tagsUpPostgres), taskTagsUp, taskTagsIndex} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017150000) down(db database.Querier) error {
+	if err := db.Execute(taskTagsDown); err != nil {
+		return err
+	}
+
+	return db.Execute(tagsDown)
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	listsUp = `CREATE TABLE IF NOT EXISTS lists(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    sort TEXT NOT NULL DEFAULT '',
+    archived_at DATETIME,
+    created_at DATETIME NOT NULL,
+    modified_at DATETIME,
+    UNIQUE (user_id, name));`
+	listsUpPostgres = `CREATE TABLE IF NOT EXISTS lists(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    sort TEXT NOT NULL DEFAULT '',
+    archived_at BIGINT,
+    created_at BIGINT NOT NULL,
+    modified_at BIGINT,
+    UNIQUE (user_id, name));`
+	taskListIDUp        = "ALTER TABLE tasks ADD COLUMN list_id TEXT;"
+	taskListIDIndex     = "CREATE INDEX IF NOT EXISTS idx_tasks_user_list ON tasks(user_id, list_id);"
+	taskListIDIndexDown = "DROP INDEX IF EXISTS idx_tasks_user_list;"
+	taskListIDDown      = "ALTER TABLE tasks DROP COLUMN list_id;"
+	listsDown           = "DROP TABLE IF EXISTS lists;"
+)
+
+// M20261017160000 adds the user lists, a task belongs to at most one list
+type M20261017160000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017160000) up(db database.Querier) error {
+	for _, query := range []string{dialect(db, listsUp, listsUpPostgres), taskListIDUp, taskListIDIndex} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017160000) down(db database.Querier) error {
+	for _, query := range []string{taskListIDIndexDown, taskListIDDown, listsDown} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
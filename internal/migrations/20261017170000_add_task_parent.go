@@ -0,0 +1,31 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskParentUp        = "ALTER TABLE tasks ADD COLUMN parent_id TEXT;"
+	taskParentIndex     = "CREATE INDEX IF NOT EXISTS idx_tasks_parent ON tasks(parent_id);"
+	taskParentIndexDown = "DROP INDEX IF EXISTS idx_tasks_parent;"
+	taskParentDown      = "ALTER TABLE tasks DROP COLUMN parent_id;"
+)
+
+// M20261017170000 adds the parent of a subtask, top level tasks have none
+type M20261017170000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017170000) up(db database.Querier) error {
+	if err := db.Execute(taskParentUp); err != nil {
+		return err
+	}
+
+	return db.Execute(taskParentIndex)
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017170000) down(db database.Querier) error {
+	if err := db.Execute(taskParentIndexDown); err != nil {
+		return err
+	}
+
+	return db.Execute(taskParentDown)
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskDependenciesUp = `CREATE TABLE IF NOT EXISTS task_dependencies(
+    task_id TEXT NOT NULL,
+    blocker_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, blocker_id));`
+	taskDependenciesIndex = "CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocker ON task_dependencies(blocker_id);"
+	taskDependenciesDown  = "DROP TABLE IF EXISTS task_dependencies;"
+)
+
+// M20261017180000 adds the dependencies between tasks, a task waits for every one of its blockers
+type M20261017180000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017180000) up(db database.Querier) error {
+	for _, query := range []string{taskDependenciesUp, taskDependenciesIndex} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017180000) down(db database.Querier) error {
+	return db.Execute(taskDependenciesDown)
+}
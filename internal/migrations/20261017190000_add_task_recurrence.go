@@ -0,0 +1,31 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskRecurrenceUp   = "ALTER TABLE tasks ADD COLUMN recurrence TEXT;"
+	taskOccurrenceUp   = "ALTER TABLE tasks ADD COLUMN occurrence INTEGER NOT NULL DEFAULT 0;"
+	taskOccurrenceDown = "ALTER TABLE tasks DROP COLUMN occurrence;"
+	taskRecurrenceDown = "ALTER TABLE tasks DROP COLUMN recurrence;"
+)
+
+// M20261017190000 adds the recurrence rule of a task and which occurrence of its series it is
+type M20261017190000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017190000) up(db database.Querier) error {
+	if err := db.Execute(taskRecurrenceUp); err != nil {
+		return err
+	}
+
+	return db.Execute(taskOccurrenceUp)
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017190000) down(db database.Querier) error {
+	if err := db.Execute(taskOccurrenceDown); err != nil {
+		return err
+	}
+
+	return db.Execute(taskRecurrenceDown)
+}
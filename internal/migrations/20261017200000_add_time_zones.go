@@ -0,0 +1,39 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	userTimeZoneUp = "ALTER TABLE users ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';"
+	taskDueTimeUp  = "ALTER TABLE tasks ADD COLUMN due_time SMALLINT NOT NULL DEFAULT 0 CHECK (due_time IN (0, 1));"
+	// due dates were UTC midnights, a day without a time of day is now due by its end
+	taskDueEndOfDay   = "UPDATE tasks SET due_date=due_date+86399999 WHERE due_date IS NOT NULL AND due_date<>0;"
+	taskDueStartOfDay = "UPDATE tasks SET due_date=due_date-86399999 WHERE due_date IS NOT NULL AND due_date<>0 AND due_time=0;"
+	taskDueTimeDown   = "ALTER TABLE tasks DROP COLUMN due_time;"
+	userTimeZoneDown  = "ALTER TABLE users DROP COLUMN time_zone;"
+)
+
+// M20261017200000 adds the time zone of a user and lets a task be due at a time of day, due dates
+// are kept as the time they fall due
+type M20261017200000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017200000) up(db database.Querier) error {
+	for _, query := range []string{userTimeZoneUp, taskDueTimeUp, taskDueEndOfDay} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017200000) down(db database.Querier) error {
+	for _, query := range []string{taskDueStartOfDay, taskDueTimeDown, userTimeZoneDown} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	remindersUp = `CREATE TABLE IF NOT EXISTS reminders(
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    before_minutes INTEGER NOT NULL DEFAULT 0,
+    remind_at DATETIME,
+    sent_at DATETIME,
+    created_at DATETIME NOT NULL);`
+	remindersUpPostgres = `CREATE TABLE IF NOT EXISTS reminders(
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    before_minutes INTEGER NOT NULL DEFAULT 0,
+    remind_at BIGINT,
+    sent_at BIGINT,
+    created_at BIGINT NOT NULL);`
+	remindersTaskIndex = "CREATE INDEX IF NOT EXISTS idx_reminders_task ON reminders(task_id);"
+	remindersSentIndex = "CREATE INDEX IF NOT EXISTS idx_reminders_sent ON reminders(sent_at);"
+	notificationsUp    = `CREATE TABLE IF NOT EXISTS notifications(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    task_id TEXT,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    read_at DATETIME);`
+	notificationsUpPostgres = `CREATE TABLE IF NOT EXISTS notifications(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    task_id TEXT,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    read_at BIGINT);`
+	notificationsUserIndex = "CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at);"
+	notificationsDown      = "DROP TABLE IF EXISTS notifications;"
+	remindersDown          = "DROP TABLE IF EXISTS reminders;"
+)
+
+// M20261017210000 adds the task reminders and the notifications shown in the app once they fire
+type M20261017210000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017210000) up(db database.Querier) error {
+	queries := []string{
+		dialect(db, remindersUp, remindersUpPostgres), remindersTaskIndex, remindersSentIndex,
+		dialect(db, notificationsUp, notificationsUpPostgres), notificationsUserIndex,
+	}
+
+	for _, query := range queries {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017210000) down(db database.Querier) error {
+	for _, query := range []string{notificationsDown, remindersDown} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	accessTokensUp = `CREATE TABLE IF NOT EXISTS access_tokens(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    last_used_at DATETIME);`
+	accessTokensUpPostgres = `CREATE TABLE IF NOT EXISTS access_tokens(
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    last_used_at BIGINT);`
+	accessTokensUserIndex = "CREATE INDEX IF NOT EXISTS idx_access_tokens_user ON access_tokens(user_id);"
+	accessTokensDown      = "DROP TABLE IF EXISTS access_tokens;"
+)
+
+// M20261017220000 adds the personal access tokens, kept by the hash of the token
+type M20261017220000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261017220000) up(db database.Querier) error {
+	for _, query := range []string{dialect(db, accessTokensUp, accessTokensUpPostgres), accessTokensUserIndex} {
+		if err := db.Execute(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261017220000) down(db database.Querier) error {
+	return db.Execute(accessTokensDown)
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import "todoapp/internal/database"
+
+const (
+	taskVersionUp   = "ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;"
+	taskVersionDown = "ALTER TABLE tasks DROP COLUMN version;"
+)
+
+// M20261018090000 adds the version of a task, every write of the task raises it by one
+type M20261018090000 string
+
+// nolint:revive // unused but need this as method
+func (m M20261018090000) up(db database.Querier) error {
+	return db.Execute(taskVersionUp)
+}
+
+// nolint:revive // unused but need this as method
+func (m M20261018090000) down(db database.Querier) error {
+	return db.Execute(taskVersionDown)
+}
@@ -4,22 +4,33 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 	"time"
+	"todoapp/internal/database"
 	"todoapp/internal/models"
 	"todoapp/internal/server"
-
-	"github.com/sqlitecloud/sqlitecloud-go"
 )
 
 const (
 	migTableName = "todo_migrations"
 	migInsertErr = "Migration table insert error"
+
+	createMigTable = "CREATE TABLE IF NOT EXISTS " + migTableName +
+		"(version TEXT, start_time DATETIME, end_time DATETIME, method TEXT);"
+	createMigTablePostgres = "CREATE TABLE IF NOT EXISTS " + migTableName +
+		"(version TEXT, start_time BIGINT, end_time BIGINT, method TEXT);"
+	getAllVersions  = "SELECT version FROM " + migTableName + " ORDER BY version DESC;"
+	getLastVersion  = "SELECT version FROM " + migTableName + " ORDER BY version DESC LIMIT 1;"
+	insertMigration = "INSERT INTO " + migTableName + " (version, start_time, method) VALUES (?, ?, ?);"
+	endMigration    = "UPDATE " + migTableName + " SET end_time=? WHERE version=?;"
+	deleteMigration = "DELETE FROM " + migTableName + " WHERE version=?;"
 )
 
 type migrator interface {
-	up(db *sqlitecloud.SQCloud) error
-	down(db *sqlitecloud.SQCloud) error
+	up(db database.Querier) error
+	down(db database.Querier) error
 }
 
 func RunMigrations(ctx context.Context, s *server.Server, method string) error {
@@ -29,12 +40,7 @@ func RunMigrations(ctx context.Context, s *server.Server, method string) error {
 
 	t := time.Now()
 
-	query := fmt.Sprintf(
-		"CREATE TABLE IF NOT EXISTS %s(version TEXT, start_time DATETIME, end_time DATETIME, method TEXT);",
-		migTableName,
-	)
-
-	err := s.DB.Execute(query)
+	err := s.DB.Execute(dialect(s.DB, createMigTable, createMigTablePostgres))
 	if err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, "not able to create the migration table")
 
@@ -69,12 +75,13 @@ func runUpMigrations(ctx context.Context, s *server.Server, migs map[string]migr
 		return err
 	}
 
-	for version, val := range migs {
+	// versions are timestamps, later migrations may depend on the tables of earlier ones
+	for _, version := range slices.Sorted(maps.Keys(migs)) {
 		if version <= lastRun {
 			continue
 		}
 
-		if err := performUpMigrations(ctx, s, val, version); err != nil {
+		if err := performUpMigrations(ctx, s, migs[version], version); err != nil {
 			return err
 		}
 
@@ -91,8 +98,6 @@ func runDownMigrations(ctx context.Context, s *server.Server, migs map[string]mi
 	run := []string{}
 	versions := []string{}
 
-	getAllVersions := fmt.Sprintf("SELECT version from %s ORDER BY version DESC", migTableName)
-
 	rows, err := s.DB.Select(getAllVersions)
 	if err != nil {
 		return err
@@ -127,12 +132,9 @@ func runDownMigrations(ctx context.Context, s *server.Server, migs map[string]mi
 }
 
 func getLastRunMigration(ctx context.Context, s *server.Server) (string, error) {
-	var (
-		lastRun         string
-		queryGetLastRun = fmt.Sprintf("SELECT version FROM %s ORDER BY version DESC LIMIT 1;", migTableName)
-	)
+	var lastRun string
 
-	res, err := s.DB.Select(queryGetLastRun)
+	res, err := s.DB.Select(getLastVersion)
 	if err != nil {
 		return "", err
 	}
@@ -161,7 +163,8 @@ func getLastRunMigration(ctx context.Context, s *server.Server) (string, error)
 func performUpMigrations(ctx context.Context, s *server.Server, val migrator, version string) error {
 	const method = "UP"
 
-	if err := s.DB.BeginTransaction(); err != nil {
+	tx, err := s.DB.Begin()
+	if err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, "unable to start transaction",
 			slog.String("error", err.Error()),
 		)
@@ -169,51 +172,39 @@ func performUpMigrations(ctx context.Context, s *server.Server, val migrator, ve
 		return err
 	}
 
-	defer func() {
-		_ = s.DB.EndTransaction()
-	}()
-
-	query := fmt.Sprintf("INSERT INTO %s (version, start_time, method) VALUES ('%s', %v,'%s');",
-		migTableName, version, time.Now().UnixMilli(), method)
-
-	if err := s.DB.Execute(query); err != nil {
+	if err := tx.Execute(insertMigration, version, time.Now(), method); err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, migInsertErr,
 			slog.String("migration", version),
 			slog.String("error", err.Error()),
 		)
 
-		return handleRollback(s, err)
+		return handleRollback(tx, err)
 	}
 
-	if err := val.up(s.DB); err != nil {
+	if err := val.up(tx); err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, "Migration error",
 			slog.String("migration", version),
 			slog.String("error", err.Error()),
 		)
 
-		return handleRollback(s, err)
+		return handleRollback(tx, err)
 	}
 
-	query = fmt.Sprintf("UPDATE %s SET end_time=%v WHERE version='%s';",
-		migTableName,
-		time.Now().UnixMilli(),
-		version,
-	)
-
-	if err := s.DB.Execute(query); err != nil {
+	if err := tx.Execute(endMigration, time.Now(), version); err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, migInsertErr,
 			slog.String("migration", version),
 			slog.String("error", err.Error()),
 		)
 
-		return handleRollback(s, err)
+		return handleRollback(tx, err)
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func performDownMigrations(ctx context.Context, s *server.Server, val migrator, key string) error {
-	if err := s.DB.BeginTransaction(); err != nil {
+	tx, err := s.DB.Begin()
+	if err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, "unable to start transaction",
 			slog.String("error", err.Error()),
 		)
@@ -221,33 +212,39 @@ func performDownMigrations(ctx context.Context, s *server.Server, val migrator,
 		return err
 	}
 
-	defer func() {
-		_ = s.DB.EndTransaction()
-	}()
-
-	if err := val.down(s.DB); err != nil {
+	if err := val.down(tx); err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, "Migration error",
 			slog.String("migration", key),
 			slog.String("error", err.Error()),
 		)
 
-		return handleRollback(s, err)
+		return handleRollback(tx, err)
 	}
 
-	if err := s.DB.Execute(fmt.Sprintf("DELETE FROM %s WHERE version = %v", migTableName, key)); err != nil {
+	if err := tx.Execute(deleteMigration, key); err != nil {
 		s.Logger.LogAttrs(ctx, slog.LevelError, migInsertErr,
 			slog.String("migration", key),
 			slog.String("error", err.Error()),
 		)
 
-		return handleRollback(s, err)
+		return handleRollback(tx, err)
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+// dialect picks the postgres flavour of a statement when running on postgres,
+// all the SQLite based drivers share the sqlite one.
+func dialect(db database.Querier, sqlite, postgres string) string {
+	if db.Driver() == database.DriverPostgres {
+		return postgres
+	}
+
+	return sqlite
 }
 
-func handleRollback(s *server.Server, err error) error {
-	if rErr := s.DB.RollBackTransaction(); rErr != nil {
+func handleRollback(tx database.Tx, err error) error {
+	if rErr := tx.Rollback(); rErr != nil {
 		return rErr
 	}
 
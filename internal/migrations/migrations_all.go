@@ -5,4 +5,19 @@ var migrations = map[string]migrator{
 	"20241013015640": M20241013015640(""),
 	"20241013015650": M20241013015650(""),
 	"20241013015656": M20241013015656(""),
+	"20261017090000": M20261017090000(""),
+	"20261017100000": M20261017100000(""),
+	"20261017110000": M20261017110000(""),
+	"20261017120000": M20261017120000(""),
+	"20261017130000": M20261017130000(""),
+	"20261017140000": M20261017140000(""),
+	"20261017150000": M20261017150000(""),
+	"20261017160000": M20261017160000(""),
+	"20261017170000": M20261017170000(""),
+	"20261017180000": M20261017180000(""),
+	"20261017190000": M20261017190000(""),
+	"20261017200000": M20261017200000(""),
+	"20261017210000": M20261017210000(""),
+	"20261017220000": M20261017220000(""),
+	"20261018090000": M20261018090000(""),
 }
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"todoapp/internal/database"
+	"todoapp/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMigrations(t *testing.T) {
+	configs := map[string]*database.Config{
+		database.DriverSQLite: {Driver: database.DriverSQLite, Path: filepath.Join(t.TempDir(), "mig.db")},
+	}
+
+	if url := os.Getenv("TEST_POSTGRES_URL"); url != "" {
+		configs[database.DriverPostgres] = &database.Config{Driver: database.DriverPostgres, URL: url}
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			db, err := database.Open(cfg)
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			ctx := context.Background()
+			s := &server.Server{DB: db, Logger: slog.Default()}
+
+			require.NoError(t, RunMigrations(ctx, s, "UP"))
+			require.NoError(t, RunMigrations(ctx, s, "UP"))
+
+			for _, table := range []string{"users", "tasks", "sessions", "task_revisions", "tags", "task_tags", "lists", "task_dependencies",
+				"reminders", "notifications", "access_tokens"} {
+				_, err := db.Select("SELECT * FROM " + table + ";")
+				assert.NoErrorf(t, err, "table %s", table)
+			}
+
+			require.NoError(t, RunMigrations(ctx, s, "DOWN"))
+
+			_, err = db.Select("SELECT * FROM tasks;")
+			assert.Error(t, err)
+
+			assert.Error(t, RunMigrations(ctx, s, "SIDEWAYS"))
+		})
+	}
+}
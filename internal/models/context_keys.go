@@ -3,3 +3,10 @@ package models
 type ContextKey string
 
 const CtxKeyUserID ContextKey = "user_id"
+
+// CtxKeyLocation holds the *time.Location of the signed in user's time zone
+const CtxKeyLocation ContextKey = "location"
+
+// CtxKeyScopes holds the []Scope of the personal access token a request is signed in with, a
+// session cookie sets none and is allowed everything
+const CtxKeyScopes ContextKey = "scopes"
@@ -0,0 +1,35 @@
+package models
+
+// Dependency makes TaskID wait for BlockerID, the task is blocked until its blocker is done
+type Dependency struct {
+	TaskID    string `json:"taskId"`
+	BlockerID string `json:"blockerId"`
+}
+
+// TaskRef is the short form of a task shown next to the tasks depending on it
+type TaskRef struct {
+	ID     string     `json:"id"`
+	Title  string     `json:"title"`
+	Status TaskStatus `json:"status"`
+}
+
+// TaskRefs lists related tasks ordered by title
+type TaskRefs []TaskRef
+
+// IsOpen reports whether the task still holds up the tasks waiting for it, a cancelled task does not
+func (r TaskRef) IsOpen() bool {
+	return r.Status.IsOpen()
+}
+
+// Open keeps the tasks that are neither done nor cancelled
+func (refs TaskRefs) Open() TaskRefs {
+	res := make(TaskRefs, 0, len(refs))
+
+	for i := range refs {
+		if refs[i].IsOpen() {
+			res = append(res, refs[i])
+		}
+	}
+
+	return res
+}
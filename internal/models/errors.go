@@ -16,6 +16,20 @@ var (
 	ErrPsswdNotMatch     = ConstError("password does not match")
 	ErrUserNotFound      = ConstError("user not found")
 	ErrInvalidCookie     = ConstError("invalid cookie")
+	ErrStatusTransition  = ConstError("status transition not allowed")
+	ErrTagAlreadyExists  = ConstError("tag already exists")
+	ErrListAlreadyExists = ConstError("list already exists")
+	ErrListArchived      = ConstError("list is archived")
+	ErrSubtaskDepth      = ConstError("subtasks nested too deep")
+	ErrSubtaskCycle      = ConstError("a task can't be a subtask of itself or of its subtasks")
+	ErrSubtaskList       = ConstError("a subtask is kept in the list of its parent")
+	ErrDependencyCycle   = ConstError("a task can't wait for itself or for a task waiting for it")
+	ErrTaskBlocked       = ConstError("task is blocked by open tasks")
+	ErrNotRecurring      = ConstError("task does not recur")
+	ErrTooManyReminders  = ConstError("task has too many reminders")
+	ErrInvalidToken      = ConstError("invalid access token")
+	ErrScopeMissing      = ConstError("access token lacks the scope")
+	ErrVersionMismatch   = ConstError("task was changed since it was read")
 )
 
 type ConstError string
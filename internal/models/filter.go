@@ -0,0 +1,93 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// TaskFilter narrows the task list, the zero value matches every task. The time bounds are
+// exclusive and a task without a due date never matches a due bound. Tags keeps the tasks
+// carrying any of the named tags, or all of them with AllTags. ListID keeps the tasks of one list,
+// an empty ID the tasks in no list. TopLevel keeps the tasks that are no subtask. Open keeps the
+// tasks that are neither done nor cancelled.
+type TaskFilter struct {
+	Done          *bool
+	Open          bool
+	DueAfter      *time.Time
+	DueBefore     *time.Time
+	AddedAfter    *time.Time
+	AddedBefore   *time.Time
+	TitleContains string
+	Tags          []string
+	AllTags       bool
+	ListID        *string
+	TopLevel      bool
+}
+
+// Match reports whether task passes the filter, times are compared in unix millis like the
+// stores keep them
+func (f *TaskFilter) Match(task *Task) bool {
+	if f.Done != nil && task.IsDone != *f.Done {
+		return false
+	}
+
+	if f.Open && !task.Status.IsOpen() {
+		return false
+	}
+
+	if f.ListID != nil && task.ListID != *f.ListID {
+		return false
+	}
+
+	if f.TopLevel && task.ParentID != "" {
+		return false
+	}
+
+	if !inRange(task.DueDate, f.DueAfter, f.DueBefore) || !inRange(&task.AddedAt, f.AddedAfter, f.AddedBefore) {
+		return false
+	}
+
+	if f.TitleContains != "" && !strings.Contains(strings.ToLower(task.Title), strings.ToLower(f.TitleContains)) {
+		return false
+	}
+
+	return f.matchTags(task.Tags)
+}
+
+func (f *TaskFilter) matchTags(tags []Tag) bool {
+	if len(f.Tags) == 0 {
+		return true
+	}
+
+	names := TagNames(tags)
+	found := 0
+
+	for _, name := range f.Tags {
+		if slices.Contains(names, name) {
+			found++
+		}
+	}
+
+	if f.AllTags {
+		return found == len(f.Tags)
+	}
+
+	return found > 0
+}
+
+func inRange(t, after, before *time.Time) bool {
+	if after == nil && before == nil {
+		return true
+	}
+
+	if t == nil {
+		return false
+	}
+
+	if after != nil && t.UnixMilli() <= after.UnixMilli() {
+		return false
+	}
+
+	return before == nil || t.UnixMilli() < before.UnixMilli()
+}
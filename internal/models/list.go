@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxListName is the longest list name in characters
+	MaxListName = 100
+	// InboxList names the tasks that are in no list when filtering by list
+	InboxList = "inbox"
+)
+
+// List is a user owned group of tasks, a list name is unique per user. Sort is the task order
+// of the list view, empty for the default order. An archived list keeps its tasks but takes no new ones.
+type List struct {
+	ID         string     `json:"id"`
+	UserID     uuid.UUID  `json:"userId"`
+	Name       string     `json:"name"`
+	Sort       string     `json:"sort"`
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ModifiedAt *time.Time `json:"modifiedAt"`
+}
+
+type ListReq struct {
+	Name string `json:"name"`
+	Sort string `json:"sort"`
+}
+
+func (l List) IsArchived() bool {
+	return l.ArchivedAt != nil
+}
@@ -0,0 +1,336 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// SortField is a task attribute the task list can be ordered by
+type SortField string
+
+const (
+	SortDueDate  SortField = "due"
+	SortAddedAt  SortField = "added"
+	SortTitle    SortField = "title"
+	SortDone     SortField = "done"
+	SortPriority SortField = "priority"
+)
+
+// SortKey orders the task list by one field, ties are broken by the next key and finally by task ID
+type SortKey struct {
+	Field SortField
+	Desc  bool
+}
+
+// TaskListReq is the raw listing request, Sort is a comma separated list of fields where
+// a leading "-" sorts that field in descending order, e.g. "done,-due".
+// The time bounds take a date or an RFC 3339 timestamp, Overdue keeps the open tasks due before now.
+// Tags is a comma separated list of tag names, TagMatch "all" keeps the tasks carrying every one
+// of them instead of any. List keeps the tasks of one list, InboxList the tasks in none.
+// Tree lists the top level tasks only, each one carrying its subtasks.
+type TaskListReq struct {
+	Cursor      string `json:"cursor"`
+	Limit       int    `json:"limit"`
+	Sort        string `json:"sort"`
+	Done        string `json:"done"`
+	Overdue     string `json:"overdue"`
+	DueAfter    string `json:"dueAfter"`
+	DueBefore   string `json:"dueBefore"`
+	AddedAfter  string `json:"addedAfter"`
+	AddedBefore string `json:"addedBefore"`
+	Title       string `json:"title"`
+	Tags        string `json:"tags"`
+	TagMatch    string `json:"tagMatch"`
+	List        string `json:"list"`
+	Tree        string `json:"tree"`
+}
+
+// ParseTaskListReq reads the paging, sort and filter query parameters of a listing request,
+// tags may be repeated
+func ParseTaskListReq(query url.Values) (*TaskListReq, error) {
+	req := TaskListReq{
+		Cursor:      query.Get("cursor"),
+		Sort:        query.Get("sort"),
+		Done:        query.Get("done"),
+		Overdue:     query.Get("overdue"),
+		DueAfter:    query.Get("dueAfter"),
+		DueBefore:   query.Get("dueBefore"),
+		AddedAfter:  query.Get("addedAfter"),
+		AddedBefore: query.Get("addedBefore"),
+		Title:       query.Get("title"),
+		Tags:        strings.Join(query["tags"], ","),
+		TagMatch:    query.Get("tagMatch"),
+		List:        query.Get("list"),
+		Tree:        query.Get("tree"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, ErrInvalid("limit")
+		}
+
+		req.Limit = n
+	}
+
+	return &req, nil
+}
+
+// TaskQuery is what the stores need to fetch one page of tasks
+type TaskQuery struct {
+	Filter TaskFilter
+	Sort   []SortKey
+	After  *Cursor
+	Limit  int
+}
+
+// TaskPage is one page of tasks, NextCursor is empty on the last page. List is set when the
+// page lists the tasks of one list.
+type TaskPage struct {
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	Sort       string `json:"sort"`
+	Limit      int    `json:"limit"`
+	List       *List  `json:"list,omitempty"`
+}
+
+// Cursor marks the last task of a page by its sort values, so the next page starts right
+// after it even if that task has been changed or deleted in the meantime
+type Cursor struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+	ID     string `json:"id"`
+}
+
+// ParseSort reads the sort parameter, an empty one orders the tasks by the time they were added
+func ParseSort(sort string) ([]SortKey, error) {
+	if strings.TrimSpace(sort) == "" {
+		return []SortKey{{Field: SortAddedAt}}, nil
+	}
+
+	keys := make([]SortKey, 0)
+	seen := make(map[SortField]bool)
+
+	for _, part := range strings.Split(sort, ",") {
+		key := SortKey{Field: SortField(strings.TrimSpace(part))}
+
+		if strings.HasPrefix(string(key.Field), "-") {
+			key.Field, key.Desc = key.Field[1:], true
+		}
+
+		switch key.Field {
+		case SortDueDate, SortAddedAt, SortTitle, SortDone, SortPriority:
+		default:
+			return nil, ErrInvalid("sort")
+		}
+
+		if seen[key.Field] {
+			return nil, ErrInvalid("sort")
+		}
+
+		seen[key.Field] = true
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// FormatSort is the inverse of ParseSort
+func FormatSort(keys []SortKey) string {
+	parts := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if key.Desc {
+			parts = append(parts, "-"+string(key.Field))
+			continue
+		}
+
+		parts = append(parts, string(key.Field))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// NewCursor builds the cursor pointing right after task
+func NewCursor(keys []SortKey, task *Task) *Cursor {
+	c := Cursor{Sort: FormatSort(keys), ID: task.ID, Values: make([]any, 0, len(keys))}
+
+	for _, key := range keys {
+		c.Values = append(c.Values, SortValue(key.Field, task))
+	}
+
+	return &c
+}
+
+func (c *Cursor) Encode() string {
+	// only strings and integers are marshaled, this can't fail
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses an encoded cursor and checks it was made for the given sort keys
+func DecodeCursor(s string, keys []SortKey) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalid("cursor")
+	}
+
+	var c Cursor
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+
+	if err := dec.Decode(&c); err != nil || c.Sort != FormatSort(keys) || len(c.Values) != len(keys) || c.ID == "" {
+		return nil, ErrInvalid("cursor")
+	}
+
+	for i, key := range keys {
+		v, ok := cursorValue(key.Field, c.Values[i])
+		if !ok {
+			return nil, ErrInvalid("cursor")
+		}
+
+		c.Values[i] = v
+	}
+
+	return &c, nil
+}
+
+// cursorValue converts a decoded JSON value back to the type SortValue returns for field
+func cursorValue(field SortField, v any) (any, bool) {
+	if field == SortTitle {
+		s, ok := v.(string)
+
+		return s, ok
+	}
+
+	num, ok := v.(json.Number)
+	if !ok {
+		return nil, false
+	}
+
+	n, err := num.Int64()
+
+	return n, err == nil
+}
+
+// SortValue is the value a task is ordered by for field, times are in unix millis as stored in
+// the database and a missing due date sorts after every other one
+func SortValue(field SortField, task *Task) any {
+	switch field {
+	case SortDueDate:
+		if task.DueDate == nil {
+			return int64(math.MaxInt64)
+		}
+
+		return task.DueDate.UnixMilli()
+	case SortTitle:
+		return task.Title
+	case SortDone:
+		if task.IsDone {
+			return int64(1)
+		}
+
+		return int64(0)
+	case SortPriority:
+		return int64(task.Priority)
+	default:
+		return task.AddedAt.UnixMilli()
+	}
+}
+
+// Less reports whether a comes before b in the query's order
+func (q *TaskQuery) Less(a, b *Task) bool {
+	values := make([]any, 0, len(q.Sort))
+
+	for _, key := range q.Sort {
+		values = append(values, SortValue(key.Field, b))
+	}
+
+	return q.compare(a, values, b.ID) < 0
+}
+
+// IsAfter reports whether task belongs to a page following the query's cursor
+func (q *TaskQuery) IsAfter(task *Task) bool {
+	if q.After == nil {
+		return true
+	}
+
+	return q.compare(task, q.After.Values, q.After.ID) > 0
+}
+
+func (q *TaskQuery) compare(task *Task, values []any, id string) int {
+	for i, key := range q.Sort {
+		c := compareValues(SortValue(key.Field, task), values[i])
+		if key.Desc {
+			c = -c
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return strings.Compare(task.ID, id)
+}
+
+func compareValues(a, b any) int {
+	if s, ok := a.(string); ok {
+		t, _ := b.(string)
+
+		return strings.Compare(s, t)
+	}
+
+	x, _ := a.(int64)
+	y, _ := b.(int64)
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// TaskPageResp is a TaskPage ready to be rendered, Query holds the listing parameters
+// other than the cursor so the next page keeps the same filters
+type TaskPageResp struct {
+	Tasks      []TaskResp `json:"tasks"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+	Sort       string     `json:"sort"`
+	Limit      int        `json:"limit"`
+	List       *List      `json:"list,omitempty"`
+	TimeZone   string     `json:"timeZone"`
+	Query      string     `json:"-"`
+}
+
+// ToTaskPageResp prepares the page to be rendered with the due dates in loc
+func (p *TaskPage) ToTaskPageResp(loc *time.Location) *TaskPageResp {
+	resp := TaskPageResp{
+		Tasks:      make([]TaskResp, 0, len(p.Tasks)),
+		NextCursor: p.NextCursor,
+		Sort:       p.Sort,
+		Limit:      p.Limit,
+		List:       p.List,
+		TimeZone:   loc.String(),
+	}
+
+	for i := range p.Tasks {
+		resp.Tasks = append(resp.Tasks, *p.Tasks[i].ToTaskResp(loc))
+	}
+
+	return &resp
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    string
+		want    []SortKey
+		wantErr error
+	}{
+		{name: "default", sort: "", want: []SortKey{{Field: SortAddedAt}}},
+		{name: "multiple keys", sort: "done, -due,title",
+			want: []SortKey{{Field: SortDone}, {Field: SortDueDate, Desc: true}, {Field: SortTitle}}},
+		{name: "priority then due", sort: "-priority,due",
+			want: []SortKey{{Field: SortPriority, Desc: true}, {Field: SortDueDate}}},
+		{name: "unknown field", sort: "color", wantErr: ErrInvalid("sort")},
+		{name: "repeated field", sort: "due,-due", wantErr: ErrInvalid("sort")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.sort)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	keys, err := ParseSort("-due,title")
+	require.NoError(t, err)
+
+	dd := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	task := Task{ID: "task-1", Title: "hello", DueDate: &dd}
+
+	c, err := DecodeCursor(NewCursor(keys, &task).Encode(), keys)
+	require.NoError(t, err)
+	assert.Equal(t, []any{dd.UnixMilli(), "hello"}, c.Values)
+
+	q := TaskQuery{Sort: keys, After: c}
+	assert.False(t, q.IsAfter(&task))
+	assert.True(t, q.IsAfter(&Task{ID: "task-2", Title: "hello", DueDate: &dd}))
+	assert.True(t, q.IsAfter(&Task{ID: "task-0", Title: "a", DueDate: &time.Time{}}))
+	assert.False(t, q.IsAfter(&Task{ID: "task-3", Title: "hello"}), "missing due date sorts last, so first when descending")
+
+	_, err = DecodeCursor(NewCursor(keys, &task).Encode(), []SortKey{{Field: SortTitle}})
+	assert.Equal(t, ErrInvalid("cursor"), err)
+
+	_, err = DecodeCursor("not a cursor", keys)
+	assert.Equal(t, ErrInvalid("cursor"), err)
+}
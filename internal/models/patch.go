@@ -0,0 +1,138 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// MergePatchType is the media type of an RFC 7396 JSON merge patch
+const MergePatchType = "application/merge-patch+json"
+
+// TaskField is a part of a task a partial update writes, the due date and time and the recurrence
+// rule and its occurrence are each written together
+type TaskField string
+
+const (
+	FieldTitle       TaskField = "title"
+	FieldDescription TaskField = "description"
+	FieldDue         TaskField = "due"
+	FieldPriority    TaskField = "priority"
+	FieldList        TaskField = "list"
+	FieldParent      TaskField = "parent"
+	FieldRecurrence  TaskField = "recurrence"
+)
+
+// taskDoc is the part of a task a merge patch changes, an empty field is left out of the document
+type taskDoc struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	DueDate     string `json:"dueDate,omitempty"`
+	DueTime     string `json:"dueTime,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	ListID      string `json:"listId,omitempty"`
+	ParentID    string `json:"parentId,omitempty"`
+	Recurrence  string `json:"recurrence,omitempty"`
+}
+
+// PatchTask applies a merge patch to the fields of t a client can change, with its due date read in loc,
+// and returns them as a full update. A member the patch removes is left empty.
+func PatchTask(t *Task, patch []byte, loc *time.Location) (*TaskReq, error) {
+	resp := t.ToTaskResp(loc)
+	doc := taskDoc{
+		Title:       t.Title,
+		Description: t.Description,
+		DueTime:     resp.DueTime,
+		Priority:    t.Priority.String(),
+		ListID:      t.ListID,
+		ParentID:    t.ParentID,
+		Recurrence:  t.Recurrence,
+	}
+
+	if resp.DueDate != nil {
+		doc.DueDate = *resp.DueDate
+	}
+
+	current := make(map[string]any)
+
+	// a document of strings always encodes and decodes
+	data, _ := json.Marshal(doc)
+	_ = json.Unmarshal(data, &current)
+
+	merged, err := MergePatch(current, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ = json.Marshal(merged)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var patched taskDoc
+	if err := dec.Decode(&patched); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, ErrInvalid("merge patch, " + typeErr.Field + " is not a string")
+		}
+
+		return nil, ErrInvalid("merge patch, only title, description, dueDate, dueTime, priority, listId, " +
+			"parentId and recurrence can be changed")
+	}
+
+	return &TaskReq{
+		ID:          t.ID,
+		Title:       patched.Title,
+		Description: patched.Description,
+		DueDate:     patched.DueDate,
+		DueTime:     patched.DueTime,
+		Priority:    patched.Priority,
+		ListID:      patched.ListID,
+		ParentID:    patched.ParentID,
+		Recurrence:  patched.Recurrence,
+	}, nil
+}
+
+// MergePatch applies the RFC 7396 merge patch to doc: a member set to null is removed, an object
+// is merged member by member and any other value replaces the one in doc
+func MergePatch(doc map[string]any, patch []byte) (map[string]any, error) {
+	var p any
+
+	dec := json.NewDecoder(bytes.NewReader(patch))
+	dec.UseNumber()
+
+	if err := dec.Decode(&p); err != nil {
+		return nil, ErrInvalid("merge patch")
+	}
+
+	if _, ok := p.(map[string]any); !ok {
+		return nil, ErrInvalid("merge patch, not an object")
+	}
+
+	merged, _ := mergeValue(doc, p).(map[string]any)
+
+	return merged, nil
+}
+
+func mergeValue(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+
+		t[k] = mergeValue(t[k], v)
+	}
+
+	return t
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchTask(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	due := time.Date(2026, 10, 20, 17, 30, 0, 0, loc)
+	task := Task{ID: "task-1", Title: "Pay rent", DueDate: &due, HasDueTime: true, Priority: PriorityHigh,
+		Recurrence: "FREQ=MONTHLY"}
+
+	tests := []struct {
+		name  string
+		patch string
+		want  *TaskReq
+		err   error
+	}{
+		{
+			name:  "empty patch",
+			patch: `{}`,
+			want: &TaskReq{ID: "task-1", Title: "Pay rent", DueDate: "2026-10-20", DueTime: "17:30", Priority: "high",
+				Recurrence: "FREQ=MONTHLY"},
+		},
+		{
+			name:  "set and clear",
+			patch: `{"title":"Pay the rent","dueTime":null,"recurrence":null,"description":"by transfer"}`,
+			want:  &TaskReq{ID: "task-1", Title: "Pay the rent", Description: "by transfer", DueDate: "2026-10-20", Priority: "high"},
+		},
+		{name: "not an object", patch: `["title"]`, err: ErrInvalid("merge patch, not an object")},
+		{name: "not JSON", patch: `{"title":`, err: ErrInvalid("merge patch")},
+		{name: "wrong type", patch: `{"title":5}`, err: ErrInvalid("merge patch, title is not a string")},
+		{
+			name: "read only field", patch: `{"status":"done"}`,
+			err: ErrInvalid("merge patch, only title, description, dueDate, dueTime, priority, listId, parentId and " +
+				"recurrence can be changed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PatchTask(&task, []byte(tt.patch), loc)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	doc := map[string]any{"a": "b", "c": map[string]any{"d": "e", "f": "g"}}
+
+	got, err := MergePatch(doc, []byte(`{"a":"z","c":{"f":null},"h":[1]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "map[a:z c:map[d:e] h:[1]]", fmt.Sprint(got))
+}
@@ -0,0 +1,80 @@
+package models
+
+import "slices"
+
+// Priority is how much a task matters, a higher priority sorts first in descending order
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// Priorities lists every priority from the lowest up
+func Priorities() []Priority {
+	return []Priority{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+}
+
+// ParsePriority returns the priority named s, an empty name is no priority
+func ParsePriority(s string) (Priority, error) {
+	if s == "" {
+		return PriorityNone, nil
+	}
+
+	i := slices.IndexFunc(Priorities(), func(p Priority) bool { return p.String() == s })
+	if i < 0 {
+		return PriorityNone, ErrInvalid("priority")
+	}
+
+	return Priority(i), nil
+}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityMedium:
+		return "medium"
+	case PriorityHigh:
+		return "high"
+	case PriorityUrgent:
+		return "urgent"
+	default:
+		return "none"
+	}
+}
+
+// MarshalText writes the priority by name in JSON
+func (p Priority) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Priority) UnmarshalText(text []byte) error {
+	priority, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+
+	*p = priority
+
+	return nil
+}
+
+// Label is the priority as shown to users
+func (p Priority) Label() string {
+	switch p {
+	case PriorityLow:
+		return "Low"
+	case PriorityMedium:
+		return "Medium"
+	case PriorityHigh:
+		return "High"
+	case PriorityUrgent:
+		return "Urgent"
+	default:
+		return "None"
+	}
+}
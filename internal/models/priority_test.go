@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriority(t *testing.T) {
+	p, err := ParsePriority("urgent")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityUrgent, p)
+
+	p, err = ParsePriority("")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityNone, p)
+
+	_, err = ParsePriority("asap")
+	assert.Equal(t, ErrInvalid("priority"), err)
+
+	data, err := json.Marshal(Task{Priority: PriorityHigh, Status: StatusTodo})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"priority":"high"`)
+
+	var task Task
+	require.NoError(t, json.Unmarshal(data, &task))
+	assert.Equal(t, PriorityHigh, task.Priority)
+}
@@ -0,0 +1,355 @@
+package models
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a recurring task comes back
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+	FreqYearly  Frequency = "YEARLY"
+)
+
+// maxRecurrenceSteps bounds the search for the next occurrence, a rule like the fifth Monday of
+// every other month can skip a few periods before it matches
+const maxRecurrenceSteps = 1000
+
+// nolint:gochecknoglobals // read only lookup table
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// WeekdayNum is one BYDAY entry. N picks the nth such weekday of the month, counted from its end
+// when negative, 0 picks all of them.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Recurrence is the subset of an RFC 5545 RRULE a task can repeat by: FREQ, INTERVAL, BYDAY, COUNT
+// and UNTIL. Count is how many occurrences the series has and Until the last day one may fall on,
+// they are unbounded when unset. A numbered BYDAY needs FREQ=MONTHLY and BYDAY can't be used yearly.
+type Recurrence struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []WeekdayNum
+	Count    int
+	Until    *time.Time
+}
+
+// ParseRecurrence reads an RRULE, with or without its "RRULE:" prefix. UNTIL takes a date or a UTC
+// date-time of which only the date is kept.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	r := Recurrence{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || seen[key] {
+			return nil, ErrInvalid("recurrence")
+		}
+
+		seen[key] = true
+
+		if err := r.set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return &r, r.validate()
+}
+
+func (r *Recurrence) set(key, value string) error {
+	var err error
+
+	switch key {
+	case "FREQ":
+		r.Freq = Frequency(value)
+	case "INTERVAL":
+		r.Interval, err = parsePositive(value, "recurrence interval")
+	case "COUNT":
+		r.Count, err = parsePositive(value, "recurrence count")
+	case "UNTIL":
+		r.Until, err = parseUntil(value)
+	case "BYDAY":
+		r.ByDay, err = parseByDay(value)
+	default:
+		err = ErrInvalid("recurrence part " + key)
+	}
+
+	return err
+}
+
+func (r *Recurrence) validate() error {
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly:
+	case FreqYearly:
+		if len(r.ByDay) > 0 {
+			return ErrInvalid("recurrence, BYDAY can't repeat yearly")
+		}
+	case "":
+		return ErrRequired("recurrence frequency")
+	default:
+		return ErrInvalid("recurrence frequency")
+	}
+
+	if r.Count > 0 && r.Until != nil {
+		return ErrInvalid("recurrence, COUNT and UNTIL can't be used together")
+	}
+
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != FreqMonthly {
+			return ErrInvalid("recurrence, a numbered BYDAY needs FREQ=MONTHLY")
+		}
+	}
+
+	return nil
+}
+
+func parsePositive(value, field string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, ErrInvalid(field)
+	}
+
+	return n, nil
+}
+
+func parseUntil(value string) (*time.Time, error) {
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		if until, err = time.Parse("20060102T150405Z", value); err != nil {
+			return nil, ErrInvalid("recurrence until")
+		}
+	}
+
+	until = dateOf(until)
+
+	return &until, nil
+}
+
+// parseByDay reads a list like "MO,WE" or "1MO,-1FR"
+func parseByDay(value string) ([]WeekdayNum, error) {
+	res := make([]WeekdayNum, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, ErrInvalid("recurrence day")
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, ErrInvalid("recurrence day")
+		}
+
+		var n int
+
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, ErrInvalid("recurrence day")
+			}
+		}
+
+		res = append(res, WeekdayNum{N: n, Day: day})
+	}
+
+	return res, nil
+}
+
+// String is the rule in its canonical RRULE form, without the "RRULE:" prefix
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+
+		for _, day := range r.ByDay {
+			days = append(days, day.String())
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func (d WeekdayNum) String() string {
+	day := strings.ToUpper(d.Day.String()[:2])
+	if d.N == 0 {
+		return day
+	}
+
+	return strconv.Itoa(d.N) + day
+}
+
+// Next returns the occurrence following the one falling on from, the occurrence-th of the series.
+// It keeps the time of day of from and reports false once the series is over.
+func (r *Recurrence) Next(from time.Time, occurrence int) (time.Time, bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	var (
+		next time.Time
+		ok   bool
+	)
+
+	switch r.Freq {
+	case FreqDaily:
+		next, ok = r.nextDaily(from)
+	case FreqWeekly:
+		next, ok = r.nextWeekly(from), true
+	case FreqMonthly:
+		next, ok = r.nextMonthly(from)
+	default:
+		next, ok = r.nextYearly(from)
+	}
+
+	if !ok || (r.Until != nil && dateOf(next).After(*r.Until)) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+func (r *Recurrence) nextDaily(from time.Time) (time.Time, bool) {
+	next := from
+
+	for range maxRecurrenceSteps {
+		next = next.AddDate(0, 0, r.Interval)
+
+		if len(r.ByDay) == 0 || slices.ContainsFunc(r.ByDay, func(d WeekdayNum) bool { return d.Day == next.Weekday() }) {
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// nextWeekly takes the next listed day of the week of from, weeks start on Monday, or the first
+// one of the week interval weeks later
+func (r *Recurrence) nextWeekly(from time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return from.AddDate(0, 0, 7*r.Interval)
+	}
+
+	offsets := make([]int, 0, len(r.ByDay))
+	for _, day := range r.ByDay {
+		offsets = append(offsets, mondayOffset(day.Day))
+	}
+
+	slices.Sort(offsets)
+
+	current := mondayOffset(from.Weekday())
+	for _, offset := range offsets {
+		if offset > current {
+			return from.AddDate(0, 0, offset-current)
+		}
+	}
+
+	return from.AddDate(0, 0, 7*r.Interval+offsets[0]-current)
+}
+
+func (r *Recurrence) nextMonthly(from time.Time) (time.Time, bool) {
+	first := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for k := range maxRecurrenceSteps {
+		month := first.AddDate(0, k*r.Interval, 0)
+
+		for _, day := range r.monthDays(month, from.Day()) {
+			next := onDay(from, month.Year(), month.Month(), day)
+			if next.After(from) {
+				return next, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// monthDays lists in order the days of month the rule falls on, day is the day of the month of
+// the series when the rule has no BYDAY, months too short for it are skipped
+func (r *Recurrence) monthDays(month time.Time, day int) []int {
+	last := month.AddDate(0, 1, -1).Day()
+
+	if len(r.ByDay) == 0 {
+		if day > last {
+			return nil
+		}
+
+		return []int{day}
+	}
+
+	days := make([]int, 0)
+
+	for _, wd := range r.ByDay {
+		firstDay := 1 + (int(wd.Day)-int(month.Weekday())+7)%7
+
+		switch {
+		case wd.N > 0:
+			days = append(days, firstDay+(wd.N-1)*7)
+		case wd.N < 0:
+			lastDay := firstDay + (last-firstDay)/7*7
+			days = append(days, lastDay+(wd.N+1)*7)
+		default:
+			for d := firstDay; d <= last; d += 7 {
+				days = append(days, d)
+			}
+		}
+	}
+
+	days = slices.DeleteFunc(days, func(d int) bool { return d < 1 || d > last })
+	slices.Sort(days)
+
+	return slices.Compact(days)
+}
+
+// nextYearly keeps the month and day of from, February 29 only comes back on leap years
+func (r *Recurrence) nextYearly(from time.Time) (time.Time, bool) {
+	for k := 1; k <= maxRecurrenceSteps; k++ {
+		year := from.Year() + k*r.Interval
+
+		if next := onDay(from, year, from.Month(), from.Day()); next.Day() == from.Day() {
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// mondayOffset is how many days day comes after Monday
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// onDay is the given day at the time of day of t
+func onDay(t time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// dateOf is the calendar day of t as a UTC midnight
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
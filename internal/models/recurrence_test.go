@@ -0,0 +1,90 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr error
+	}{
+		{name: "daily", rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefix and case", rule: "rrule:freq=weekly;byday=mo,fr;interval=2", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{name: "numbered days", rule: "FREQ=MONTHLY;BYDAY=1MO,-1FR;COUNT=3", want: "FREQ=MONTHLY;BYDAY=1MO,-1FR;COUNT=3"},
+		{name: "until date-time", rule: "FREQ=YEARLY;UNTIL=20301231T235959Z", want: "FREQ=YEARLY;UNTIL=20301231"},
+		{name: "no frequency", rule: "INTERVAL=2", wantErr: ErrRequired("recurrence frequency")},
+		{name: "unknown frequency", rule: "FREQ=HOURLY", wantErr: ErrInvalid("recurrence frequency")},
+		{name: "unsupported part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: ErrInvalid("recurrence part BYHOUR")},
+		{name: "repeated part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: ErrInvalid("recurrence")},
+		{name: "zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: ErrInvalid("recurrence interval")},
+		{name: "bad day", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: ErrInvalid("recurrence day")},
+		{name: "numbered weekly day", rule: "FREQ=WEEKLY;BYDAY=2MO", wantErr: ErrInvalid("recurrence, a numbered BYDAY needs FREQ=MONTHLY")},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20300101",
+			wantErr: ErrInvalid("recurrence, COUNT and UNTIL can't be used together")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+
+			assert.Equal(t, tt.wantErr, err)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, r.String())
+			}
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		require.NoError(t, err)
+
+		return d
+	}
+
+	tests := []struct {
+		name       string
+		rule       string
+		from       string
+		occurrence int
+		want       string
+	}{
+		{name: "every other day", rule: "FREQ=DAILY;INTERVAL=2", from: "2026-10-17", want: "2026-10-19"},
+		{name: "weekdays only", rule: "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", from: "2026-10-16", want: "2026-10-19"},
+		{name: "weekly", rule: "FREQ=WEEKLY", from: "2026-10-17", want: "2026-10-24"},
+		{name: "same week", rule: "FREQ=WEEKLY;BYDAY=MO,TH", from: "2026-10-19", want: "2026-10-22"},
+		{name: "weeks later", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", from: "2026-10-22", want: "2026-11-02"},
+		{name: "monthly skips short months", rule: "FREQ=MONTHLY", from: "2026-01-31", want: "2026-03-31"},
+		{name: "last friday", rule: "FREQ=MONTHLY;BYDAY=-1FR", from: "2026-10-30", want: "2026-11-27"},
+		{name: "first monday", rule: "FREQ=MONTHLY;BYDAY=1MO", from: "2026-10-05", want: "2026-11-02"},
+		{name: "leap day", rule: "FREQ=YEARLY", from: "2028-02-29", want: "2032-02-29"},
+		{name: "count reached", rule: "FREQ=DAILY;COUNT=3", from: "2026-10-17", occurrence: 3},
+		{name: "count left", rule: "FREQ=DAILY;COUNT=3", from: "2026-10-17", occurrence: 2, want: "2026-10-18"},
+		{name: "until passed", rule: "FREQ=WEEKLY;UNTIL=20261023", from: "2026-10-17"},
+		{name: "until day", rule: "FREQ=WEEKLY;UNTIL=20261024", from: "2026-10-17", want: "2026-10-24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			require.NoError(t, err)
+
+			next, ok := r.Next(date(tt.from), tt.occurrence)
+
+			assert.Equal(t, tt.want != "", ok)
+
+			if tt.want != "" {
+				assert.Equal(t, date(tt.want), next)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxReminders is how many reminders a task can carry
+	MaxReminders = 5
+	// MaxReminderBefore is how long before its due date a task can be reminded of
+	MaxReminderBefore = 365 * 24 * time.Hour
+)
+
+// Reminder tells the user about a task, either Before its due date or At a given time when At
+// is set. SentAt is set once it has fired.
+type Reminder struct {
+	ID        string        `json:"id"`
+	TaskID    string        `json:"taskId"`
+	UserID    uuid.UUID     `json:"userId"`
+	Before    time.Duration `json:"before,omitempty"`
+	At        *time.Time    `json:"at,omitempty"`
+	SentAt    *time.Time    `json:"sentAt,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// ReminderReq adds a reminder, Before like "1d" or "2h30m" counts back from the due date while At
+// is a local date-time like "2026-10-18T09:00" or an RFC 3339 time. Only one of them is set.
+type ReminderReq struct {
+	Before string `json:"before"`
+	At     string `json:"at"`
+}
+
+// ReminderResp is a reminder ready to be rendered in the user's time zone
+type ReminderResp struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Sent  bool   `json:"sent"`
+}
+
+// DueReminder is a reminder whose time has come along with the task it is about
+type DueReminder struct {
+	Reminder
+	Task Task
+}
+
+// Notification is a message sent to a user, ReadAt is set once it has been seen in the app
+type Notification struct {
+	ID        string     `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	TaskID    string     `json:"taskId,omitempty"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+}
+
+// FireAt is when the reminder goes off for a task due at due, a relative reminder of a task
+// without a due date never does
+func (r *Reminder) FireAt(due *time.Time) (time.Time, bool) {
+	if r.At != nil {
+		return *r.At, true
+	}
+
+	if due == nil || due.IsZero() {
+		return time.Time{}, false
+	}
+
+	return due.Add(-r.Before), true
+}
+
+// Label describes the reminder, an absolute one with its time in loc
+func (r *Reminder) Label(loc *time.Location) string {
+	if r.At != nil {
+		return r.At.In(loc).Format("2006-01-02 15:04")
+	}
+
+	return FormatBefore(r.Before) + " before"
+}
+
+func (r *Reminder) toResp(loc *time.Location) ReminderResp {
+	return ReminderResp{ID: r.ID, Label: r.Label(loc), Sent: r.SentAt != nil}
+}
+
+// nolint:gochecknoglobals // read only lookup table
+var beforeUnits = []struct {
+	unit byte
+	size time.Duration
+}{
+	{'w', 7 * 24 * time.Hour}, {'d', 24 * time.Hour}, {'h', time.Hour}, {'m', time.Minute},
+}
+
+// ParseBefore reads how long before the due date a reminder fires, as numbers of weeks, days,
+// hours and minutes in that order like "1w", "1d12h" or "90m"
+func ParseBefore(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	rest, next := value, 0
+
+	var d time.Duration
+
+	for rest != "" && next < len(beforeUnits) {
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0, ErrInvalid("reminder before")
+		}
+
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return 0, ErrInvalid("reminder before")
+		}
+
+		for next < len(beforeUnits) && beforeUnits[next].unit != rest[i] {
+			next++
+		}
+
+		if next == len(beforeUnits) {
+			return 0, ErrInvalid("reminder before")
+		}
+
+		d += time.Duration(n) * beforeUnits[next].size
+		rest, next = rest[i+1:], next+1
+	}
+
+	if rest != "" || d <= 0 || d > MaxReminderBefore {
+		return 0, ErrInvalid("reminder before")
+	}
+
+	return d, nil
+}
+
+// FormatBefore writes d the way ParseBefore reads it, "0m" for no time
+func FormatBefore(d time.Duration) string {
+	var b strings.Builder
+
+	for _, u := range beforeUnits {
+		if n := d / u.size; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10))
+			b.WriteByte(u.unit)
+			d -= n * u.size
+		}
+	}
+
+	if b.Len() == 0 {
+		return "0m"
+	}
+
+	return b.String()
+}
+
+// ParseRemindAt reads the time of an absolute reminder, a local date-time is read in loc
+func ParseRemindAt(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range []string{"2006-01-02T15:04", time.RFC3339} {
+		if at, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return at, nil
+		}
+	}
+
+	return time.Time{}, ErrInvalid("reminder at")
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBefore(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   bool
+	}{
+		{value: "15m", want: 15 * time.Minute},
+		{value: " 1D12h ", want: 36 * time.Hour},
+		{value: "1w2d3h4m", want: 9*24*time.Hour + 3*time.Hour + 4*time.Minute},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "", err: true},
+		{value: "0m", err: true},
+		{value: "2h1d", err: true},
+		{value: "1h1h", err: true},
+		{value: "3x", err: true},
+		{value: "h", err: true},
+		{value: "10", err: true},
+		{value: "53w", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseBefore(tt.value)
+			if tt.err {
+				assert.Equal(t, ErrInvalid("reminder before"), err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, "1w2d3h4m", FormatBefore(9*24*time.Hour+3*time.Hour+4*time.Minute))
+	assert.Equal(t, "1h30m", FormatBefore(90*time.Minute))
+}
+
+func TestReminderFireAt(t *testing.T) {
+	due := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	at := time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)
+
+	got, ok := (&Reminder{Before: time.Hour}).FireAt(&due)
+	assert.True(t, ok)
+	assert.Equal(t, due.Add(-time.Hour), got)
+
+	got, ok = (&Reminder{At: &at}).FireAt(nil)
+	assert.True(t, ok)
+	assert.Equal(t, at, got)
+
+	_, ok = (&Reminder{Before: time.Hour}).FireAt(nil)
+	assert.False(t, ok)
+
+	paris, _ := time.LoadLocation("Europe/Paris")
+	assert.Equal(t, "2026-10-17 22:00", (&Reminder{At: &at}).Label(paris))
+	assert.Equal(t, "1d before", (&Reminder{Before: 24 * time.Hour}).Label(paris))
+}
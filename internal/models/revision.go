@@ -0,0 +1,126 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldChange is the old and new value of one task field, formatted as text
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Revision records one change of a task, Number counts the revisions of the task from 1
+type Revision struct {
+	TaskID    string        `json:"taskId"`
+	Number    int64         `json:"number"`
+	UserID    uuid.UUID     `json:"userId"`
+	ChangedAt time.Time     `json:"changedAt"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// revisionFields are the task fields a revision tracks, in the order they are listed. The due date
+// is the day in loc, or the RFC 3339 time when the task is due at a time of day.
+func revisionFields(t *Task, loc *time.Location) []FieldChange {
+	dd := ""
+
+	switch {
+	case t.DueDate == nil:
+	case t.HasDueTime:
+		dd = t.DueDate.In(loc).Format(time.RFC3339)
+	default:
+		dd = t.DueDate.In(loc).Format(time.DateOnly)
+	}
+
+	return []FieldChange{
+		{Field: "title", New: t.Title},
+		{Field: "description", New: t.Description},
+		{Field: "dueDate", New: dd},
+		{Field: "status", New: t.Status.String()},
+		{Field: "priority", New: t.Priority.String()},
+	}
+}
+
+// DiffTasks lists the tracked fields whose value differs between old and next, due dates are
+// compared in loc
+func DiffTasks(old, next *Task, loc *time.Location) []FieldChange {
+	before, after := revisionFields(old, loc), revisionFields(next, loc)
+	changes := make([]FieldChange, 0)
+
+	for i := range after {
+		if before[i].New != after[i].New {
+			changes = append(changes, FieldChange{Field: after[i].Field, Old: before[i].New, New: after[i].New})
+		}
+	}
+
+	return changes
+}
+
+// SetField sets a tracked field from its text value, as found in a FieldChange. A due date without
+// a time of day is read in loc.
+func (t *Task) SetField(field, value string, loc *time.Location) error {
+	switch field {
+	case "title":
+		t.Title = value
+	case "description":
+		t.Description = value
+	case "dueDate":
+		return t.setDueDate(value, loc)
+	case "status":
+		status, err := ParseStatus(value)
+		if err != nil {
+			return err
+		}
+
+		t.SetStatus(status, time.Now().UTC())
+	case "priority":
+		p, err := ParsePriority(value)
+		if err != nil {
+			return err
+		}
+
+		t.Priority = p
+	case "isDone":
+		// revisions recorded before tasks had a status
+		done, err := strconv.ParseBool(value)
+		if err != nil {
+			return ErrInvalid(field)
+		}
+
+		status := StatusTodo
+		if done {
+			status = StatusDone
+		}
+
+		t.SetStatus(status, time.Now().UTC())
+	default:
+		return ErrInvalid("revision field " + field)
+	}
+
+	return nil
+}
+
+func (t *Task) setDueDate(value string, loc *time.Location) error {
+	if value == "" {
+		t.DueDate, t.HasDueTime = nil, false
+		return nil
+	}
+
+	if due, err := time.Parse(time.RFC3339, value); err == nil {
+		t.DueDate, t.HasDueTime = &due, true
+		return nil
+	}
+
+	due, err := ParseDue(value, "", loc)
+	if err != nil {
+		return ErrInvalid("dueDate")
+	}
+
+	t.DueDate, t.HasDueTime = &due, false
+
+	return nil
+}
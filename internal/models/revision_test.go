@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTasks(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	dd := time.Date(2025, 5, 1, 23, 59, 59, 0, loc)
+	old := Task{Title: "old", Description: "same", DueDate: &dd, Status: StatusTodo}
+	next := Task{Title: "new", Description: "same", Status: StatusDone, IsDone: true}
+
+	changes := DiffTasks(&old, &next, loc)
+	assert.Equal(t, []FieldChange{
+		{Field: "title", Old: "old", New: "new"},
+		{Field: "dueDate", Old: "2025-05-01", New: ""},
+		{Field: "status", Old: "todo", New: "done"},
+	}, changes)
+
+	for _, c := range changes {
+		require.NoError(t, next.SetField(c.Field, c.Old, loc))
+	}
+
+	assert.Empty(t, DiffTasks(&old, &next, loc))
+	assert.False(t, next.IsDone)
+
+	// a due time is kept as an exact time
+	at := time.Date(2025, 5, 1, 9, 30, 0, 0, loc)
+	next.DueDate, next.HasDueTime = &at, true
+	changes = DiffTasks(&old, &next, loc)
+	assert.Equal(t, []FieldChange{{Field: "dueDate", Old: "2025-05-01", New: "2025-05-01T09:30:00+05:30"}}, changes)
+	require.NoError(t, old.SetField("dueDate", changes[0].New, loc))
+	assert.True(t, old.DueDate.Equal(*next.DueDate))
+
+	assert.Error(t, next.SetField("status", "finished", loc))
+	assert.Error(t, next.SetField("dueDate", "tomorrow", loc))
+	assert.Error(t, next.SetField("addedAt", "", loc))
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"html"
+	"html/template"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// HighlightStart and HighlightEnd surround the matched words of a search snippet, they are
+	// control characters that survive HTML escaping and that a task title or description can't hold
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+
+	maxSearchTerms = 10
+)
+
+// SearchResult is a task matching a search, the snippets carry the highlight markers
+type SearchResult struct {
+	Task               Task
+	TitleSnippet       string
+	DescriptionSnippet string
+}
+
+// SearchTerms splits a search query into lower cased words, every word must prefix a word of the
+// title or the description. Splitting on anything but letters and digits keeps the query free of
+// the search engine's syntax.
+func SearchTerms(query string) []string {
+	terms := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, term := range strings.FieldsFunc(strings.ToLower(query), isNotWordRune) {
+		if seen[term] || len(terms) == maxSearchTerms {
+			continue
+		}
+
+		seen[term] = true
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+// HighlightHTML escapes a snippet and turns its highlight markers into <mark> elements
+func HighlightHTML(snippet string) template.HTML {
+	escaped := strings.NewReplacer(HighlightStart, "<mark>", HighlightEnd, "</mark>").Replace(html.EscapeString(snippet))
+
+	return template.HTML(escaped) // nolint:gosec // the task text is escaped above, only the marks are raw HTML
+}
+
+func (r *SearchResult) ToTaskResp(loc *time.Location) *TaskResp {
+	tr := r.Task.ToTaskResp(loc)
+	tr.TitleHighlight = HighlightHTML(r.TitleSnippet)
+	tr.DescriptionHighlight = HighlightHTML(r.DescriptionSnippet)
+
+	return tr
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"bob", "s", "report", "near", "2025"}, SearchTerms(`Bob's "report" bob* NEAR(2025)`))
+	assert.Empty(t, SearchTerms(` "*" - `))
+}
+
+func TestHighlightHTML(t *testing.T) {
+	got := HighlightHTML("a " + HighlightStart + "<b>" + HighlightEnd + " & c")
+	assert.Equal(t, "a <mark>&lt;b&gt;</mark> &amp; c", string(got))
+}
@@ -0,0 +1,110 @@
+package models
+
+import "slices"
+
+// TaskStatus is where a task is in its workflow, only StatusDone counts as done
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+)
+
+// Statuses lists every status in workflow order
+func Statuses() []TaskStatus {
+	return []TaskStatus{StatusTodo, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled}
+}
+
+// transitions are the statuses a task can move to from each status, a done or cancelled task
+// has to be reopened before it is worked on again
+// nolint:gochecknoglobals // read only lookup table
+var transitions = map[TaskStatus][]TaskStatus{
+	StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+	StatusInProgress: {StatusTodo, StatusBlocked, StatusDone, StatusCancelled},
+	StatusBlocked:    {StatusTodo, StatusInProgress, StatusCancelled},
+	StatusDone:       {StatusTodo},
+	StatusCancelled:  {StatusTodo},
+}
+
+// ParseStatus returns the status named s
+func ParseStatus(s string) (TaskStatus, error) {
+	status := TaskStatus(s)
+	if _, ok := transitions[status]; !ok {
+		return "", ErrInvalid("status")
+	}
+
+	return status, nil
+}
+
+func (s TaskStatus) String() string {
+	return string(s)
+}
+
+// UnmarshalText lets the stores scan a status column
+func (s *TaskStatus) UnmarshalText(text []byte) error {
+	status, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = status
+
+	return nil
+}
+
+// Label is the status as shown to users
+func (s TaskStatus) Label() string {
+	switch s {
+	case StatusInProgress:
+		return "In progress"
+	case StatusBlocked:
+		return "Blocked"
+	case StatusDone:
+		return "Done"
+	case StatusCancelled:
+		return "Cancelled"
+	default:
+		return "To do"
+	}
+}
+
+// CanMoveTo reports whether a task in status s may be moved to next
+func (s TaskStatus) CanMoveTo(next TaskStatus) bool {
+	return slices.Contains(transitions[s], next)
+}
+
+// Next lists the statuses a task in status s may be moved to
+func (s TaskStatus) Next() []TaskStatus {
+	return transitions[s]
+}
+
+// IsOpen reports whether a task in status s is neither done nor cancelled
+func (s TaskStatus) IsOpen() bool {
+	return s != StatusDone && s != StatusCancelled
+}
+
+// OpenStatuses lists the statuses of the tasks that are neither done nor cancelled, in workflow order
+func OpenStatuses() []TaskStatus {
+	res := make([]TaskStatus, 0)
+
+	for _, s := range Statuses() {
+		if s.IsOpen() {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
+
+// StatusReq moves a task to Status. Cascade moves its subtasks to done along with it and Force
+// moves it to done while it still has open blockers.
+type StatusReq struct {
+	Status  string `json:"status"`
+	Cascade bool   `json:"cascade"`
+	Force   bool   `json:"force"`
+	// Version is the version of the task the move was made from, 0 skips the check
+	Version int64 `json:"-"`
+}
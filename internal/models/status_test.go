@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStatus(t *testing.T) {
+	status, err := ParseStatus("in_progress")
+	require.NoError(t, err)
+	assert.Equal(t, StatusInProgress, status)
+
+	_, err = ParseStatus("finished")
+	assert.Equal(t, ErrInvalid("status"), err)
+
+	assert.True(t, StatusTodo.CanMoveTo(StatusDone))
+	assert.True(t, StatusDone.CanMoveTo(StatusTodo))
+	assert.False(t, StatusDone.CanMoveTo(StatusCancelled))
+	assert.False(t, StatusBlocked.CanMoveTo(StatusDone))
+	assert.False(t, StatusTodo.CanMoveTo(StatusTodo))
+
+	at := time.Now()
+	task := Task{Status: StatusInProgress}
+
+	task.SetStatus(StatusDone, at)
+	assert.True(t, task.IsDone)
+	assert.Equal(t, &at, task.CompletedAt)
+
+	task.SetStatus(StatusTodo, at)
+	assert.False(t, task.IsDone)
+	assert.Nil(t, task.CompletedAt)
+}
@@ -0,0 +1,10 @@
+package models
+
+// DefaultMaxDepth is how deep subtasks nest unless configured otherwise, a top level task is at depth 0
+const DefaultMaxDepth = 3
+
+// Progress counts the subtasks of a task, cancelled ones left out, and how many of them are done
+type Progress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
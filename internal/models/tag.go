@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxTagName is the longest tag name in characters
+const MaxTagName = 50
+
+// Tag is a user owned label, a task can carry any number of tags and a tag name is unique per user
+type Tag struct {
+	ID        string    `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TagNames lists the names of tags in order
+func TagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+
+	for i := range tags {
+		names = append(names, tags[i].Name)
+	}
+
+	return names
+}
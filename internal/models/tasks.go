@@ -1,6 +1,7 @@
 package models
 
 import (
+	"html/template"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,23 +10,65 @@ import (
 type Task struct {
 	ID          string     `json:"id"`
 	UserID      uuid.UUID  `json:"user_id"`
+	ListID      string     `json:"listId,omitempty"`
+	ParentID    string     `json:"parentId,omitempty"`
+	Recurrence  string     `json:"recurrence,omitempty"`
+	Occurrence  int        `json:"occurrence,omitempty"`
 	Title       string     `json:"title"`
 	Description string     `json:"description"`
 	IsDone      bool       `json:"isDone"`
+	Status      TaskStatus `json:"status"`
+	Priority    Priority   `json:"priority"`
 	DueDate     *time.Time `json:"dueDate"`
+	HasDueTime  bool       `json:"hasDueTime"`
 	AddedAt     time.Time  `json:"addedAt"`
 	ModifiedAt  *time.Time `json:"modifiedAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	Tags        []Tag      `json:"tags"`
+	Progress    Progress   `json:"progress"`
+	BlockedBy   TaskRefs   `json:"blockedBy"`
+	Blocking    TaskRefs   `json:"blocking"`
+	Reminders   []Reminder `json:"reminders"`
+	// Version counts the writes of the task, it starts at 1. Its tags, reminders and blockers and a restore
+	// from the trash count as writes, a reminder going off, a tag renamed or deleted and changes to other
+	// tasks it shows (blocker statuses, subtask progress) don't.
+	Version int64 `json:"version"`
+
+	// set when the tasks are listed as a tree only
+	Children []Task `json:"children,omitempty"`
 }
 
 type TaskResp struct {
-	ID          string     `json:"id"`
-	UserID      uuid.UUID  `json:"user_id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	IsDone      bool       `json:"isDone"`
-	DueDate     *string    `json:"dueDate"`
-	AddedAt     time.Time  `json:"addedAt"`
-	ModifiedAt  *time.Time `json:"modifiedAt"`
+	ID          string         `json:"id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	ListID      string         `json:"listId,omitempty"`
+	ParentID    string         `json:"parentId,omitempty"`
+	Recurrence  string         `json:"recurrence,omitempty"`
+	Occurrence  int            `json:"occurrence,omitempty"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	IsDone      bool           `json:"isDone"`
+	Status      TaskStatus     `json:"status"`
+	Priority    Priority       `json:"priority"`
+	DueDate     *string        `json:"dueDate"`
+	DueTime     string         `json:"dueTime,omitempty"`
+	Overdue     bool           `json:"overdue"`
+	AddedAt     time.Time      `json:"addedAt"`
+	ModifiedAt  *time.Time     `json:"modifiedAt"`
+	CompletedAt *time.Time     `json:"completedAt,omitempty"`
+	DeletedAt   *time.Time     `json:"deletedAt,omitempty"`
+	Tags        []Tag          `json:"tags"`
+	Progress    Progress       `json:"progress"`
+	BlockedBy   TaskRefs       `json:"blockedBy"`
+	Blocking    TaskRefs       `json:"blocking"`
+	Reminders   []ReminderResp `json:"reminders"`
+	Version     int64          `json:"version"`
+	Children    []TaskResp     `json:"children,omitempty"`
+
+	// set on search results only
+	TitleHighlight       template.HTML `json:"titleHighlight,omitempty"`
+	DescriptionHighlight template.HTML `json:"descriptionHighlight,omitempty"`
 }
 
 type TaskReq struct {
@@ -34,28 +77,91 @@ type TaskReq struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	DueDate     string `json:"dueDate"`
+	DueTime     string `json:"dueTime"`
+	Priority    string `json:"priority"`
+	ListID      string `json:"listId"`
+	ParentID    string `json:"parentId"`
+	Recurrence  string `json:"recurrence"`
 	IsDone      bool   `json:"isDone"`
+	// Version is the version of the task the update was made from, 0 skips the check
+	Version int64 `json:"-"`
 }
 
 type Error struct {
-	Type    string `json:"type"`
-	IsError bool   `json:"isError"`
-	Msg     string `json:"msg"`
+	Type     string    `json:"type"`
+	IsError  bool      `json:"isError"`
+	Msg      string    `json:"msg"`
+	Problems []Problem `json:"problems,omitempty"`
 }
 
-func (t *Task) ToTaskResp() *TaskResp {
+// Problem is one way a request doesn't match the API specification, In is where the value is
+// (path, query, header or body) and Name the parameter or the dotted path of the body field
+type Problem struct {
+	In   string `json:"in"`
+	Name string `json:"name,omitempty"`
+	Msg  string `json:"msg"`
+}
+
+// ToTaskResp prepares the task to be rendered, the due date and time are given in loc
+func (t *Task) ToTaskResp(loc *time.Location) *TaskResp {
 	tr := TaskResp{
 		ID:          t.ID,
 		UserID:      t.UserID,
+		ListID:      t.ListID,
+		ParentID:    t.ParentID,
+		Recurrence:  t.Recurrence,
+		Occurrence:  t.Occurrence,
 		Title:       t.Title,
 		Description: t.Description,
 		IsDone:      t.IsDone,
+		Status:      t.Status,
+		Priority:    t.Priority,
 		AddedAt:     t.AddedAt,
 		ModifiedAt:  t.ModifiedAt,
+		CompletedAt: t.CompletedAt,
+		DeletedAt:   t.DeletedAt,
+		Tags:        t.Tags,
+		Progress:    t.Progress,
+		BlockedBy:   t.BlockedBy,
+		Blocking:    t.Blocking,
+		Reminders:   make([]ReminderResp, 0, len(t.Reminders)),
+		Version:     t.Version,
 	}
 
-	dd := t.DueDate.Format(time.DateOnly)
+	for i := range t.Reminders {
+		tr.Reminders = append(tr.Reminders, t.Reminders[i].toResp(loc))
+	}
+
+	for i := range t.Children {
+		tr.Children = append(tr.Children, *t.Children[i].ToTaskResp(loc))
+	}
+
+	if t.DueDate == nil || t.DueDate.IsZero() {
+		return &tr
+	}
+
+	due := t.DueDate.In(loc)
+	dd := due.Format(time.DateOnly)
 	tr.DueDate = &dd
+	tr.Overdue = t.Status.IsOpen() && due.Before(time.Now())
+
+	if t.HasDueTime {
+		tr.DueTime = due.Format(dueTimeLayout)
+	}
 
 	return &tr
 }
+
+// SetStatus moves the task to status, CompletedAt is set to at when it becomes done and cleared
+// when it leaves done. IsDone follows the status.
+func (t *Task) SetStatus(status TaskStatus, at time.Time) {
+	switch {
+	case status == StatusDone && t.Status != StatusDone:
+		t.CompletedAt = &at
+	case status != StatusDone:
+		t.CompletedAt = nil
+	}
+
+	t.Status = status
+	t.IsDone = status == StatusDone
+}
@@ -5,5 +5,7 @@ import "html/template"
 func NewTemplate() *template.Template {
 	pattern := "views/*"
 
-	return template.Must(template.ParseGlob(pattern))
+	funcs := template.FuncMap{"priorities": Priorities}
+
+	return template.Must(template.New("views").Funcs(funcs).ParseGlob(pattern))
 }
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DefaultTimeZone is the zone of the users who never picked one
+const DefaultTimeZone = "UTC"
+
+const dueTimeLayout = "15:04"
+
+// LoadTimeZone reads an IANA time zone name, an empty name is the default zone
+func LoadTimeZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultTimeZone
+	}
+
+	// LoadLocation also takes "Local" and file paths, neither of which is a zone a user can have
+	if name == "Local" || strings.HasPrefix(name, "/") || strings.Contains(name, "..") {
+		return nil, ErrInvalid("time zone")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalid("time zone")
+	}
+
+	return loc, nil
+}
+
+// GetLocationFromCtx returns the time zone of the signed in user, UTC when ctx carries none
+func GetLocationFromCtx(ctx context.Context) *time.Location {
+	loc, ok := ctx.Value(CtxKeyLocation).(*time.Location)
+	if !ok {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// ParseDue reads a due date and an optional due time of day as a time in loc. A due date without
+// a time is due by the end of that day.
+func ParseDue(date, clock string, loc *time.Location) (time.Time, error) {
+	day, err := time.ParseInLocation(time.DateOnly, date, loc)
+	if err != nil {
+		return time.Time{}, ErrInvalid("due date")
+	}
+
+	if clock == "" {
+		return endOfDay(day), nil
+	}
+
+	at, err := time.Parse(dueTimeLayout, clock)
+	if err != nil {
+		return time.Time{}, ErrInvalid("due time")
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, loc), nil
+}
+
+// EndOfDay is the last millisecond of the day of t in loc, the stores keep times in millis
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	return endOfDay(t.In(loc))
+}
+
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()).Add(-time.Millisecond)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDue(t *testing.T) {
+	loc, err := LoadTimeZone("Asia/Kolkata")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		date    string
+		clock   string
+		want    time.Time
+		wantErr error
+	}{
+		{name: "end of the day", date: "2026-10-18", want: time.Date(2026, 10, 18, 18, 29, 59, 999e6, time.UTC)},
+		{name: "time of day", date: "2026-10-18", clock: "09:30", want: time.Date(2026, 10, 18, 4, 0, 0, 0, time.UTC)},
+		{name: "invalid date", date: "18/10/2026", wantErr: ErrInvalid("due date")},
+		{name: "invalid time", date: "2026-10-18", clock: "9pm", wantErr: ErrInvalid("due time")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDue(tt.date, tt.clock, loc)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.True(t, tt.want.Equal(got), "got %v", got)
+		})
+	}
+
+	_, err = LoadTimeZone("Mars/Olympus")
+	assert.Equal(t, ErrInvalid("time zone"), err)
+}
+
+func TestToTaskRespDue(t *testing.T) {
+	loc, err := LoadTimeZone("America/New_York")
+	require.NoError(t, err)
+
+	// 02:30 UTC is still the evening before in New York
+	due := time.Date(2025, 10, 18, 2, 30, 0, 0, time.UTC)
+	task := Task{Status: StatusTodo, DueDate: &due, HasDueTime: true}
+
+	resp := task.ToTaskResp(loc)
+	require.NotNil(t, resp.DueDate)
+	assert.Equal(t, "2025-10-17", *resp.DueDate)
+	assert.Equal(t, "22:30", resp.DueTime)
+	assert.True(t, resp.Overdue)
+
+	task.Status = StatusDone
+	assert.False(t, task.ToTaskResp(loc).Overdue)
+
+	task.DueDate = nil
+	assert.Nil(t, task.ToTaskResp(loc).DueDate)
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope is what a personal access token may be used for
+type Scope string
+
+const (
+	ScopeTasksRead  Scope = "tasks:read"
+	ScopeTasksWrite Scope = "tasks:write"
+	ScopeAccount    Scope = "account"
+
+	// TokenPrefix starts every personal access token, it tells them apart from session tokens
+	TokenPrefix = "todo_pat_"
+	// MaxTokenName is the longest token name, in characters
+	MaxTokenName = 100
+)
+
+// Scopes lists every scope
+func Scopes() []Scope {
+	return []Scope{ScopeTasksRead, ScopeTasksWrite, ScopeAccount}
+}
+
+// AccessToken is a named personal access token, only the SHA-256 hash of the token is kept
+type AccessToken struct {
+	ID         string     `json:"id"`
+	UserID     uuid.UUID  `json:"userId"`
+	Name       string     `json:"name"`
+	Hash       string     `json:"-"`
+	Scopes     []Scope    `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+// TokenReq creates a token with the given name and scopes
+type TokenReq struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// NewAccessToken is a token just created, Token is shown this once and can't be read again
+type NewAccessToken struct {
+	AccessToken
+	Token string `json:"token"`
+}
+
+// Allows tells whether the token may be used for scope
+func (t *AccessToken) Allows(scope Scope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// ParseScopes reads the scope names, at least one, in the order of Scopes without repeats
+func ParseScopes(names []string) ([]Scope, error) {
+	if len(names) == 0 {
+		return nil, ErrRequired("scopes")
+	}
+
+	for _, name := range names {
+		if !slices.Contains(Scopes(), Scope(strings.TrimSpace(name))) {
+			return nil, ErrInvalid("scope " + name)
+		}
+	}
+
+	res := make([]Scope, 0, len(names))
+
+	for _, scope := range Scopes() {
+		if slices.ContainsFunc(names, func(name string) bool { return Scope(strings.TrimSpace(name)) == scope }) {
+			res = append(res, scope)
+		}
+	}
+
+	return res, nil
+}
+
+// FormatScopes writes the scopes the way the stores keep them, comma separated
+func FormatScopes(scopes []Scope) string {
+	names := make([]string, 0, len(scopes))
+
+	for _, scope := range scopes {
+		names = append(names, string(scope))
+	}
+
+	return strings.Join(names, ",")
+}
+
+// SplitScopes reads scopes written by FormatScopes
+func SplitScopes(value string) []Scope {
+	res := make([]Scope, 0)
+
+	for _, name := range strings.Split(value, ",") {
+		if name != "" {
+			res = append(res, Scope(name))
+		}
+	}
+
+	return res
+}
+
+// HashToken is the hex SHA-256 of a token, tokens are random enough not to need a slow hash
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
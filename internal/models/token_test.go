@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		names   []string
+		want    []Scope
+		wantErr error
+	}{
+		{names: []string{"account", "tasks:read", "account"}, want: []Scope{ScopeTasksRead, ScopeAccount}},
+		{names: []string{" tasks:write "}, want: []Scope{ScopeTasksWrite}},
+		{names: nil, wantErr: ErrRequired("scopes")},
+		{names: []string{"tasks:read", "admin"}, wantErr: ErrInvalid("scope admin")},
+	}
+
+	for _, tt := range tests {
+		t.Run(FormatScopes(tt.want), func(t *testing.T) {
+			got, err := ParseScopes(tt.names)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, []Scope{ScopeTasksRead, ScopeAccount}, SplitScopes(FormatScopes([]Scope{ScopeTasksRead, ScopeAccount})))
+	assert.Empty(t, SplitScopes(""))
+}
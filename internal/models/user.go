@@ -17,6 +17,15 @@ type UserData struct {
 	Name     string    `json:"name"`
 	Email    string    `json:"email"`
 	Password string    `json:"password"`
+	TimeZone string    `json:"timeZone"`
+}
+
+// UserResp is the user as shown to the user, without the password hash
+type UserResp struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	TimeZone string    `json:"timeZone"`
 }
 
 type LoginReq struct {
@@ -24,8 +33,11 @@ type LoginReq struct {
 	Password string `json:"password"`
 }
 
+// RegisterReq signs up a user, TimeZone is the IANA zone the due dates are shown in and
+// defaults to UTC
 type RegisterReq struct {
-	Name string `json:"name"`
+	Name     string `json:"name"`
+	TimeZone string `json:"timeZone"`
 	*LoginReq
 }
 
@@ -70,5 +82,13 @@ func (r *RegisterReq) Validate() error {
 		return ErrInvalid("name is too short")
 	}
 
+	if _, err := LoadTimeZone(r.TimeZone); err != nil {
+		return err
+	}
+
 	return r.LoginReq.Validate()
 }
+
+func (u *UserData) ToUserResp() *UserResp {
+	return &UserResp{ID: u.ID, Name: u.Name, Email: u.Email, TimeZone: u.TimeZone}
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag is the entity tag of the task, its version as a strong validator
+func (t *Task) ETag() string {
+	return strconv.Quote(strconv.FormatInt(t.Version, 10))
+}
+
+// ParseETag reads the task version of an entity tag, false when the tag is weak or no version.
+// A weak tag never matches an If-Match header.
+func ParseETag(tag string) (int64, bool) {
+	tag = strings.TrimSpace(tag)
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	v, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || v < 1 {
+		return 0, false
+	}
+
+	return v, true
+}
+
+// CheckVersion fails with ErrVersionMismatch unless the task is at version, a version of 0 matches any
+func (t *Task) CheckVersion(version int64) error {
+	if version != 0 && version != t.Version {
+		return ErrVersionMismatch
+	}
+
+	return nil
+}
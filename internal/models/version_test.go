@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseETag(t *testing.T) {
+	task := Task{Version: 7}
+
+	v, ok := ParseETag(task.ETag())
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), v)
+
+	for _, tag := range []string{`W/"7"`, "7", `"seven"`, `"0"`, `"`} {
+		_, ok := ParseETag(tag)
+		assert.False(t, ok, tag)
+	}
+
+	assert.NoError(t, task.CheckVersion(0))
+	assert.NoError(t, task.CheckVersion(7))
+	assert.Equal(t, ErrVersionMismatch, task.CheckVersion(6))
+}
@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"context"
+
+	"todoapp/internal/models"
+)
+
+type notificationAdder interface {
+	AddNotification(ctx context.Context, n *models.Notification) error
+}
+
+// InApp keeps notifications for the user to read in the app
+type InApp struct {
+	Store notificationAdder
+}
+
+func NewInApp(st notificationAdder) *InApp {
+	return &InApp{Store: st}
+}
+
+func (a *InApp) Notify(ctx context.Context, _ *models.UserData, n *models.Notification) error {
+	return a.Store.AddNotification(ctx, n)
+}
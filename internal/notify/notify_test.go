@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotification() (*models.UserData, *models.Notification) {
+	user := &models.UserData{ID: uuid.New(), Email: "alice@example.com"}
+	n := &models.Notification{ID: "ntf-1", UserID: user.ID, TaskID: "task-1", Title: "Reminder: pay\r\nBcc: x@y.io",
+		Body: "pay rent is due 2026-10-18", CreatedAt: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)}
+
+	return user, n
+}
+
+func TestWebhook(t *testing.T) {
+	user, n := testNotification()
+
+	var (
+		got WebhookPayload
+		sig string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sig = r.Header.Get(SignatureHeader)
+		assert.NoError(t, json.Unmarshal(body, &got))
+		assert.Equal(t, "sha256="+Sign("s3cret", body), sig)
+
+		if got.Notification.ID == "fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	hook := NewWebhook(srv.URL, "s3cret")
+	require.NoError(t, hook.Notify(context.Background(), user, n))
+	assert.Equal(t, "reminder", got.Event)
+	assert.Equal(t, user.Email, got.Email)
+	assert.Equal(t, n.Title, got.Notification.Title)
+	assert.NotEmpty(t, sig)
+
+	n.ID = "fail"
+	assert.Error(t, hook.Notify(context.Background(), user, n))
+}
+
+func TestSMTP(t *testing.T) {
+	user, n := testNotification()
+	mail := NewSMTP("mail.example.com:587", "todo@example.com", "todo", "pass")
+
+	var msg string
+
+	mail.send = func(addr string, a smtp.Auth, from string, to []string, body []byte) error {
+		assert.Equal(t, "mail.example.com:587", addr)
+		assert.NotNil(t, a)
+		assert.Equal(t, "todo@example.com", from)
+		assert.Equal(t, []string{user.Email}, to)
+
+		msg = string(body)
+
+		return nil
+	}
+
+	require.NoError(t, mail.Notify(context.Background(), user, n))
+	assert.Contains(t, msg, "Subject: =?utf-8?q?Reminder:_pay=0D=0ABcc:_x@y.io?=\r\n")
+	assert.NotContains(t, msg, "\r\nBcc:")
+	assert.Contains(t, msg, "\r\n\r\npay rent is due 2026-10-18\r\n")
+}
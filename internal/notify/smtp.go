@@ -0,0 +1,60 @@
+// Package notify delivers the notifications of a user through the channels the app is configured with
+package notify
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+
+	"todoapp/internal/models"
+)
+
+// SMTP mails notifications to the user's email address through an SMTP server, it
+// authenticates with PLAIN when Username is set
+type SMTP struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+
+	// send is smtp.SendMail, tests replace it
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTP(addr, from, username, password string) *SMTP {
+	return &SMTP{Addr: addr, From: from, Username: username, Password: password, send: smtp.SendMail}
+}
+
+func (m *SMTP) Notify(_ context.Context, to *models.UserData, n *models.Notification) error {
+	var auth smtp.Auth
+
+	if m.Username != "" {
+		host, _, err := net.SplitHostPort(m.Addr)
+		if err != nil {
+			return err
+		}
+
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+
+	return m.send(m.Addr, auth, m.From, []string{to.Email}, m.message(to, n))
+}
+
+// message writes n as a plain text mail, the subject is encoded so a title can't add headers
+func (m *SMTP) message(to *models.UserData, n *models.Notification) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("From: " + m.From + "\r\n")
+	b.WriteString("To: " + to.Email + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", n.Title) + "\r\n")
+	b.WriteString("Date: " + n.CreatedAt.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(n.Body + "\r\n")
+
+	return b.Bytes()
+}
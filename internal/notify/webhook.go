@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the webhook body keyed with the webhook secret
+const SignatureHeader = "X-Todoapp-Signature"
+
+// Webhook posts notifications as JSON to URL, the body is signed when Secret is set
+type Webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+// WebhookPayload is the body posted for every notification
+type WebhookPayload struct {
+	Event        string              `json:"event"`
+	UserID       uuid.UUID           `json:"userId"`
+	Email        string              `json:"email"`
+	Notification models.Notification `json:"notification"`
+}
+
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{URL: url, Secret: secret, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *Webhook) Notify(ctx context.Context, to *models.UserData, n *models.Notification) error {
+	body, err := json.Marshal(WebhookPayload{Event: "reminder", UserID: to.ID, Email: to.Email, Notification: *n})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if w.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(w.Secret, body))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Sign is the hex HMAC-SHA256 of body keyed with secret, as sent in SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
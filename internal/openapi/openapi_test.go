@@ -0,0 +1,305 @@
+package openapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"todoapp/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specPath = "../../openapi/todoApi.yaml"
+
+// TestSpecCoversRoutes keeps the spec in step with internal/server/routes.go
+func TestSpecCoversRoutes(t *testing.T) {
+	spec, err := Load(specPath)
+	require.NoError(t, err)
+
+	routes := []string{
+		"GET /", "GET /task", "GET /api", "GET /openapi/todoApi.yaml", "GET /public/htmx.min.js", "GET /healthz",
+		"POST /login", "POST /register", "POST /logout", "PUT /user/timezone",
+		"GET /tokens", "POST /tokens", "DELETE /tokens/tok-1",
+		"GET /tasks", "POST /tasks", "GET /tasks/search", "PUT /tasks/task-1", "DELETE /tasks/task-1/delete",
+		"PUT /tasks/task-1/restore", "GET /tasks/task-1/history", "PUT /tasks/task-1/revisions/2/restore",
+		"PUT /tasks/task-1/tags", "DELETE /tasks/task-1/tags/tag-1", "PUT /tasks/task-1/list",
+		"PUT /tasks/task-1/parent", "PUT /tasks/task-1/blockers", "GET /tasks/task-1/blockers/options",
+		"DELETE /tasks/task-1/blockers/task-2", "PUT /tasks/task-1/skip", "DELETE /tasks/task-1/recurrence",
+		"POST /tasks/task-1/reminders", "DELETE /tasks/task-1/reminders/rem-1",
+		"PUT /tasks/task-1/done", "PUT /tasks/task-1/status", "GET /trash",
+		"GET /tags", "POST /tags", "PUT /tags/tag-1", "DELETE /tags/tag-1",
+		"GET /lists", "POST /lists", "GET /lists/options", "PUT /lists/list-1", "DELETE /lists/list-1",
+		"PUT /lists/list-1/archive", "DELETE /lists/list-1/archive",
+		"GET /notifications", "PUT /notifications/ntf-1/read",
+		"POST /api/v1/auth/register", "POST /api/v1/auth/login", "POST /api/v1/auth/logout",
+		"GET /api/v1/me", "PUT /api/v1/me/timezone", "GET /api/v1/tokens", "POST /api/v1/tokens",
+		"DELETE /api/v1/tokens/tok-1", "GET /api/v1/tasks", "POST /api/v1/tasks", "GET /api/v1/tasks/search",
+		"GET /api/v1/tasks/task-1", "PUT /api/v1/tasks/task-1", "PATCH /api/v1/tasks/task-1",
+		"DELETE /api/v1/tasks/task-1",
+		"PUT /api/v1/tasks/task-1/status", "PUT /api/v1/tasks/task-1/restore", "PUT /api/v1/tasks/task-1/tags",
+		"DELETE /api/v1/tasks/task-1/tags/tag-1", "GET /api/v1/trash",
+	}
+
+	for _, route := range routes {
+		method, path, _ := strings.Cut(route, " ")
+
+		rt, ok := spec.FindRoute(httptest.NewRequest(method, path, http.NoBody))
+		if assert.True(t, ok, route) && strings.HasPrefix(path, "/tasks/search") {
+			assert.Equal(t, "/tasks/search", rt.Path)
+		}
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec, err := Load(specPath)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+		problems    []models.Problem
+	}{
+		{
+			name: "valid JSON task", method: http.MethodPost, target: "/api/v1/tasks", contentType: "application/json",
+			body: `{"title":"Pay rent","priority":"high","dueDate":"2026-10-20","dueTime":""}`,
+		},
+		{
+			name: "quick add without a title", method: http.MethodPost, target: "/api/v1/tasks",
+			contentType: "application/json", body: `{"quick":"Pay rent tomorrow"}`,
+		},
+		{
+			name: "bad JSON fields", method: http.MethodPost, target: "/api/v1/tasks", contentType: "application/json",
+			body: `{"title":5,"priority":"asap","dueDate":"20 oct","dueTime":"9am"}`, status: http.StatusBadRequest,
+			problems: []models.Problem{
+				{In: "body", Name: "dueDate", Msg: "must be a date"},
+				{In: "body", Name: "dueTime", Msg: `must match ^\d{2}:\d{2}$`},
+				{In: "body", Name: "priority", Msg: "must be one of none, low, medium, high, urgent"},
+				{In: "body", Name: "title", Msg: "must be a string"},
+			},
+		},
+		{
+			name: "not JSON", method: http.MethodPost, target: "/api/v1/tasks", contentType: "application/json",
+			body: `{"title":`, status: http.StatusBadRequest,
+			problems: []models.Problem{{In: "body", Msg: "is not valid JSON"}},
+		},
+		{
+			name: "missing body", method: http.MethodPut, target: "/api/v1/tasks/task-1/status",
+			contentType: "application/json", status: http.StatusBadRequest,
+			problems: []models.Problem{{In: "body", Msg: "is required"}},
+		},
+		{
+			name: "undocumented content type", method: http.MethodPost, target: "/api/v1/tasks",
+			contentType: "text/plain", body: "Pay rent", status: http.StatusUnsupportedMediaType,
+			problems: []models.Problem{{In: "header", Name: "Content-Type", Msg: "must be one of application/json"}},
+		},
+		{
+			name: "bad query", method: http.MethodGet, target: "/api/v1/tasks?limit=lots&done=maybe&tagMatch=",
+			status: http.StatusBadRequest,
+			problems: []models.Problem{
+				{In: "query", Name: "limit", Msg: "must be an integer"},
+				{In: "query", Name: "done", Msg: "must be a boolean"},
+			},
+		},
+		{
+			name: "negative limit", method: http.MethodGet, target: "/tasks/search?q=rent&limit=-1",
+			status: http.StatusBadRequest, problems: []models.Problem{{In: "query", Name: "limit", Msg: "must be at least 0"}},
+		},
+		{
+			name: "missing query", method: http.MethodGet, target: "/api/v1/tasks/search", status: http.StatusBadRequest,
+			problems: []models.Problem{{In: "query", Name: "q", Msg: "is required"}},
+		},
+		{
+			name: "bad path parameter", method: http.MethodPut, target: "/tasks/task-1/revisions/first/restore",
+			status: http.StatusBadRequest, problems: []models.Problem{{In: "path", Name: "number", Msg: "must be an integer"}},
+		},
+		{
+			name: "valid form", method: http.MethodPut, target: "/tasks/task-1/status",
+			contentType: "application/x-www-form-urlencoded", body: "status=done&force=true",
+		},
+		{
+			name: "bad form", method: http.MethodPost, target: "/tokens", contentType: "application/x-www-form-urlencoded",
+			body: "name=&scopes=tasks:read&scopes=admin", status: http.StatusBadRequest,
+			problems: []models.Problem{
+				{In: "body", Name: "name", Msg: "is required"},
+				{In: "body", Name: "scopes", Msg: "must be one of tasks:read, tasks:write, account"},
+			},
+		},
+		{
+			name: "merge patch", method: http.MethodPatch, target: "/api/v1/tasks/task-1",
+			contentType: "application/merge-patch+json", body: `{"dueTime":null,"priority":"low","title":null}`,
+			status: http.StatusBadRequest, problems: []models.Problem{{In: "body", Name: "title", Msg: "must not be null"}},
+		},
+		{
+			name: "undocumented route", method: http.MethodGet, target: "/api/v1/nowhere?limit=lots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			_, err := spec.ValidateRequest(r)
+			if tt.status == 0 {
+				require.NoError(t, err)
+
+				// the handler still reads the whole body
+				body := new(strings.Builder)
+				_, _ = io.Copy(body, r.Body)
+				assert.Equal(t, tt.body, body.String())
+
+				return
+			}
+
+			var verr *ValidationError
+			require.True(t, errors.As(err, &verr), err)
+			assert.Equal(t, tt.status, verr.Status)
+			assert.Equal(t, tt.problems, verr.Problems)
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	spec, err := Load(specPath)
+	require.NoError(t, err)
+
+	rt, ok := spec.FindRoute(httptest.NewRequest(http.MethodGet, "/api/v1/tasks/task-1", http.NoBody))
+	require.True(t, ok)
+
+	task := `{"id":"task-1","title":"Pay rent","status":"todo","priority":"none","dueDate":null,"tags":null,` +
+		`"addedAt":"2026-10-18T09:00:00Z","modifiedAt":null,"reminders":[{"id":"rem-1","label":"1d before","sent":false}]}`
+	require.NoError(t, spec.ValidateResponse(rt, http.StatusOK, "application/json", []byte(task)))
+
+	err = spec.ValidateResponse(rt, http.StatusOK, "application/json", []byte(`{"id":"task-1","status":"later","addedAt":"today"}`))
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, []models.Problem{
+		{In: "body", Name: "title", Msg: "is required"},
+		{In: "body", Name: "addedAt", Msg: "must be a date-time"},
+		{In: "body", Name: "status", Msg: "must be one of todo, in_progress, blocked, done, cancelled"},
+	}, verr.Problems)
+
+	err = spec.ValidateResponse(rt, http.StatusTeapot, "text/plain", []byte("teapot"))
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, []models.Problem{{In: "status", Name: "418", Msg: "is not documented"}}, verr.Problems)
+}
+
+func TestLoadUnsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{name: "schema keyword", schema: "{type: integer, multipleOf: 5}", want: "schema keyword multipleOf is not supported"},
+		{name: "nested keyword", schema: "{type: object, properties: {a: {not: {type: string}}}}",
+			want: "schema keyword not is not supported"},
+		{name: "format", schema: "{type: string, format: email}", want: "format email is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeSpec(t, tt.schema))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestValidateKeywords(t *testing.T) {
+	spec, err := Load(writeSpec(t, `
+        type: object
+        additionalProperties: false
+        properties:
+          limit: {type: integer, minimum: 0, exclusiveMinimum: true, maximum: 10, exclusiveMaximum: true}
+          when:
+            oneOf:
+              - {type: string, format: date}
+              - {type: integer}
+          labels:
+            type: object
+            additionalProperties: {type: string}
+          ref:
+            anyOf:
+              - {type: string, pattern: "^task-"}
+              - {type: string, pattern: "^list-"}`))
+	require.NoError(t, err)
+
+	validate := func(body string) []models.Problem {
+		r := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, err := spec.ValidateRequest(r)
+		if err == nil {
+			return nil
+		}
+
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr), err)
+
+		return verr.Problems
+	}
+
+	assert.Nil(t, validate(`{"limit":5,"when":"2026-10-18","labels":{"a":"b"},"ref":"list-1"}`))
+	assert.Nil(t, validate(`{"when":3}`))
+	assert.Equal(t, []models.Problem{
+		{In: "body", Name: "labels.a", Msg: "must be a string"},
+		{In: "body", Name: "limit", Msg: "must be less than 10"},
+		{In: "body", Name: "ref", Msg: "must match one of its schemas"},
+		{In: "body", Name: "when", Msg: "must match exactly one of its schemas"},
+		{In: "body", Name: "extra", Msg: "is not allowed"},
+	}, validate(`{"limit":10,"when":true,"labels":{"a":1},"ref":"tag-1","extra":1}`))
+	assert.Equal(t, []models.Problem{{In: "body", Name: "limit", Msg: "must be more than 0"}}, validate(`{"limit":0}`))
+}
+
+// writeSpec writes a spec with a single POST /things operation taking a JSON body of the schema
+func writeSpec(t *testing.T, schema string) string {
+	t.Helper()
+
+	spec := `
+paths:
+  /things:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+` + indent(schema) + `
+      responses:
+        "204":
+          description: Done
+`
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(spec), 0o600))
+
+	return path
+}
+
+func indent(schema string) string {
+	lines := strings.Split(strings.Trim(schema, "\n"), "\n")
+	prefix := strings.Repeat(" ", 14)
+
+	if len(lines) == 1 {
+		return prefix + strings.TrimSpace(lines[0])
+	}
+
+	for i, line := range lines {
+		lines[i] = prefix + strings.TrimPrefix(line, "        ")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,504 @@
+// Package openapi checks the requests and responses of the app against its OpenAPI specification,
+// it reads the subset of OpenAPI 3.0 openapi/todoApi.yaml is written in.
+//
+// The schemas are checked for type, nullable, enum, required, properties, additionalProperties,
+// items, allOf, oneOf, anyOf, pattern, the length, item count and number bounds, and the date,
+// date-time and uuid formats. Parameters are read from the path, the query and the headers in
+// their default style, cookies and security schemes are left to the auth middleware, and the
+// response headers aren't checked. Load refuses a spec using any other schema or parameter
+// keyword, or another format, so nothing in it is skipped silently.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	refSchemas       = "#/components/schemas/"
+	refParameters    = "#/components/parameters/"
+	refRequestBodies = "#/components/requestBodies/"
+	refResponses     = "#/components/responses/"
+)
+
+// Spec is a loaded specification, its references are checked to resolve and its patterns to compile
+type Spec struct {
+	Paths      map[string]*PathItem `yaml:"paths"`
+	Components Components           `yaml:"components"`
+
+	routes   []route
+	patterns map[string]*regexp.Regexp
+}
+
+type Components struct {
+	Schemas       map[string]*Schema      `yaml:"schemas"`
+	Parameters    map[string]*Parameter   `yaml:"parameters"`
+	RequestBodies map[string]*RequestBody `yaml:"requestBodies"`
+	Responses     map[string]*Response    `yaml:"responses"`
+}
+
+type PathItem struct {
+	Parameters []*Parameter `yaml:"parameters"`
+	Get        *Operation   `yaml:"get"`
+	Put        *Operation   `yaml:"put"`
+	Post       *Operation   `yaml:"post"`
+	Delete     *Operation   `yaml:"delete"`
+	Patch      *Operation   `yaml:"patch"`
+}
+
+type Operation struct {
+	Parameters  []*Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody         `yaml:"requestBody"`
+	Responses   map[string]*Response `yaml:"responses"`
+}
+
+// Parameter is read without style, explode or content, the default style is the only one checked
+type Parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+type RequestBody struct {
+	Ref      string                `yaml:"$ref"`
+	Required bool                  `yaml:"required"`
+	Content  map[string]*MediaType `yaml:"content"`
+}
+
+type Response struct {
+	Ref     string                `yaml:"$ref"`
+	Content map[string]*MediaType `yaml:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Enum                 []any              `yaml:"enum"`
+	Nullable             bool               `yaml:"nullable"`
+	Required             []string           `yaml:"required"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	AdditionalProperties *Additional        `yaml:"additionalProperties"`
+	Items                *Schema            `yaml:"items"`
+	AllOf                []*Schema          `yaml:"allOf"`
+	OneOf                []*Schema          `yaml:"oneOf"`
+	AnyOf                []*Schema          `yaml:"anyOf"`
+	Pattern              string             `yaml:"pattern"`
+	MinLength            *int               `yaml:"minLength"`
+	MaxLength            *int               `yaml:"maxLength"`
+	MinItems             *int               `yaml:"minItems"`
+	MaxItems             *int               `yaml:"maxItems"`
+	Minimum              *float64           `yaml:"minimum"`
+	Maximum              *float64           `yaml:"maximum"`
+	ExclusiveMinimum     bool               `yaml:"exclusiveMinimum"`
+	ExclusiveMaximum     bool               `yaml:"exclusiveMaximum"`
+}
+
+// Additional is the additionalProperties of an object schema, either false or the schema of the
+// properties not listed
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+// schemaKeywords are the keywords a schema may use, the annotations are read but have no effect
+// nolint:gochecknoglobals // read only lookup table
+var schemaKeywords = map[string]bool{
+	"$ref": true, "type": true, "format": true, "enum": true, "nullable": true, "required": true, "properties": true,
+	"additionalProperties": true, "items": true, "allOf": true, "oneOf": true, "anyOf": true, "pattern": true,
+	"minLength": true, "maxLength": true, "minItems": true, "maxItems": true, "minimum": true, "maximum": true,
+	"exclusiveMinimum": true, "exclusiveMaximum": true,
+	"description": true, "title": true, "example": true, "default": true, "deprecated": true, "externalDocs": true,
+}
+
+// parameterKeywords are the keywords a parameter may use
+// nolint:gochecknoglobals // read only lookup table
+var parameterKeywords = map[string]bool{
+	"$ref": true, "name": true, "in": true, "required": true, "schema": true,
+	"description": true, "example": true, "deprecated": true,
+}
+
+// formats are the formats a schema may use, the ones mapping to false only describe the value
+// nolint:gochecknoglobals // read only lookup table
+var formats = map[string]bool{
+	"date": true, "date-time": true, "uuid": true,
+	"int32": false, "int64": false, "float": false, "double": false, "password": false,
+}
+
+// Route is the documented operation a request is for, with the values of its path parameters
+type Route struct {
+	Path   string
+	item   *PathItem
+	op     *Operation
+	params map[string]string
+}
+
+type route struct {
+	path  string
+	segs  []string
+	item  *PathItem
+	wilds int
+}
+
+// UnmarshalYAML refuses a schema using a keyword the checks don't know
+func (sc *Schema) UnmarshalYAML(node *yaml.Node) error {
+	if err := knownKeys(node, "schema", schemaKeywords); err != nil {
+		return err
+	}
+
+	type plain Schema
+
+	return node.Decode((*plain)(sc))
+}
+
+// UnmarshalYAML refuses a parameter using a keyword the checks don't know
+func (p *Parameter) UnmarshalYAML(node *yaml.Node) error {
+	if err := knownKeys(node, "parameter", parameterKeywords); err != nil {
+		return err
+	}
+
+	type plain Parameter
+
+	return node.Decode((*plain)(p))
+}
+
+// UnmarshalYAML reads additionalProperties as a boolean or a schema
+func (a *Additional) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.Allowed)
+	}
+
+	a.Allowed = true
+
+	return node.Decode(&a.Schema)
+}
+
+// knownKeys checks the keys of a mapping node, the x- extensions are always allowed
+func knownKeys(node *yaml.Node, kind string, keywords map[string]bool) error {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if !keywords[key.Value] && !strings.HasPrefix(key.Value, "x-") {
+			return fmt.Errorf("line %d: %s keyword %s is not supported", key.Line, kind, key.Value)
+		}
+	}
+
+	return nil
+}
+
+// Load reads the specification at path, it fails on a keyword or a format the checks don't support
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Spec
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if err := s.prepare(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return &s, nil
+}
+
+// prepare orders the paths for matching, compiles the patterns and checks every reference resolves
+func (s *Spec) prepare() error {
+	s.patterns = make(map[string]*regexp.Regexp)
+
+	for path, item := range s.Paths {
+		r := route{path: path, segs: strings.Split(strings.TrimPrefix(path, "/"), "/"), item: item}
+		for _, seg := range r.segs {
+			if isWild(seg) {
+				r.wilds++
+			}
+		}
+
+		s.routes = append(s.routes, r)
+	}
+
+	// the paths with the fewest parameters, and then the latest first one, are the most specific
+	sort.Slice(s.routes, func(i, j int) bool {
+		a, b := s.routes[i], s.routes[j]
+		if a.wilds != b.wilds {
+			return a.wilds < b.wilds
+		}
+
+		if fa, fb := firstWild(a.segs), firstWild(b.segs); fa != fb {
+			return fa > fb
+		}
+
+		return a.path < b.path
+	})
+
+	for path, item := range s.Paths {
+		if err := s.checkItem(item); err != nil {
+			return fmt.Errorf("path %s: %w", path, err)
+		}
+	}
+
+	for name, schema := range s.Components.Schemas {
+		if err := s.checkSchema(schema); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// FindRoute is the operation documented for the method and path of r, false when there is none
+func (s *Spec) FindRoute(r *http.Request) (*Route, bool) {
+	segs := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	for _, rt := range s.routes {
+		params, ok := rt.match(segs)
+		if !ok {
+			continue
+		}
+
+		op := rt.item.operation(r.Method)
+		if op == nil {
+			return nil, false
+		}
+
+		return &Route{Path: rt.path, item: rt.item, op: op, params: params}, true
+	}
+
+	return nil, false
+}
+
+func (rt *route) match(segs []string) (map[string]string, bool) {
+	if len(segs) != len(rt.segs) {
+		return nil, false
+	}
+
+	params := make(map[string]string, rt.wilds)
+
+	for i, seg := range rt.segs {
+		switch {
+		case isWild(seg):
+			if segs[i] == "" {
+				return nil, false
+			}
+
+			params[seg[1:len(seg)-1]] = segs[i]
+		case seg != segs[i]:
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func (item *PathItem) operation(method string) *Operation {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return item.Get
+	case http.MethodPut:
+		return item.Put
+	case http.MethodPost:
+		return item.Post
+	case http.MethodDelete:
+		return item.Delete
+	case http.MethodPatch:
+		return item.Patch
+	default:
+		return nil
+	}
+}
+
+// parameters are the parameters of the path item overridden by the ones of the operation
+func (s *Spec) parameters(rt *Route) []*Parameter {
+	params := make([]*Parameter, 0, len(rt.item.Parameters)+len(rt.op.Parameters))
+	seen := make(map[string]int)
+
+	for _, p := range append(append([]*Parameter{}, rt.item.Parameters...), rt.op.Parameters...) {
+		p = s.parameter(p)
+
+		key := p.In + " " + p.Name
+		if i, ok := seen[key]; ok {
+			params[i] = p
+			continue
+		}
+
+		seen[key] = len(params)
+		params = append(params, p)
+	}
+
+	return params
+}
+
+func (s *Spec) schema(sc *Schema) *Schema {
+	for sc != nil && sc.Ref != "" {
+		sc = s.Components.Schemas[strings.TrimPrefix(sc.Ref, refSchemas)]
+	}
+
+	return sc
+}
+
+func (s *Spec) parameter(p *Parameter) *Parameter {
+	for p != nil && p.Ref != "" {
+		p = s.Components.Parameters[strings.TrimPrefix(p.Ref, refParameters)]
+	}
+
+	return p
+}
+
+func (s *Spec) requestBody(rb *RequestBody) *RequestBody {
+	for rb != nil && rb.Ref != "" {
+		rb = s.Components.RequestBodies[strings.TrimPrefix(rb.Ref, refRequestBodies)]
+	}
+
+	return rb
+}
+
+func (s *Spec) response(resp *Response) *Response {
+	for resp != nil && resp.Ref != "" {
+		resp = s.Components.Responses[strings.TrimPrefix(resp.Ref, refResponses)]
+	}
+
+	return resp
+}
+
+func (s *Spec) checkItem(item *PathItem) error {
+	for _, p := range item.Parameters {
+		if err := s.checkParameter(p); err != nil {
+			return err
+		}
+	}
+
+	for _, op := range []*Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch} {
+		if op == nil {
+			continue
+		}
+
+		for _, p := range op.Parameters {
+			if err := s.checkParameter(p); err != nil {
+				return err
+			}
+		}
+
+		if op.RequestBody != nil {
+			rb := s.requestBody(op.RequestBody)
+			if rb == nil {
+				return fmt.Errorf("unknown request body %s", op.RequestBody.Ref)
+			}
+
+			if err := s.checkContent(rb.Content); err != nil {
+				return err
+			}
+		}
+
+		for code, resp := range op.Responses {
+			if s.response(resp) == nil {
+				return fmt.Errorf("response %s: unknown response %s", code, resp.Ref)
+			}
+
+			if err := s.checkContent(s.response(resp).Content); err != nil {
+				return fmt.Errorf("response %s: %w", code, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Spec) checkParameter(p *Parameter) error {
+	resolved := s.parameter(p)
+	if resolved == nil {
+		return fmt.Errorf("unknown parameter %s", p.Ref)
+	}
+
+	return s.checkSchema(resolved.Schema)
+}
+
+func (s *Spec) checkContent(content map[string]*MediaType) error {
+	for _, media := range content {
+		if media == nil {
+			continue
+		}
+
+		if err := s.checkSchema(media.Schema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSchema follows the schema but not its references, the components are checked on their own
+func (s *Spec) checkSchema(sc *Schema) error {
+	if sc == nil {
+		return nil
+	}
+
+	if sc.Ref != "" {
+		if s.schema(sc) == nil {
+			return fmt.Errorf("unknown schema %s", sc.Ref)
+		}
+
+		return nil
+	}
+
+	if _, ok := formats[sc.Format]; sc.Format != "" && !ok {
+		return fmt.Errorf("format %s is not supported", sc.Format)
+	}
+
+	if sc.Pattern != "" {
+		re, err := regexp.Compile(sc.Pattern)
+		if err != nil {
+			return err
+		}
+
+		s.patterns[sc.Pattern] = re
+	}
+
+	children := append(append(append([]*Schema{sc.Items}, sc.AllOf...), sc.OneOf...), sc.AnyOf...)
+	if sc.AdditionalProperties != nil {
+		children = append(children, sc.AdditionalProperties.Schema)
+	}
+
+	for _, prop := range sc.Properties {
+		children = append(children, prop)
+	}
+
+	for _, child := range children {
+		if err := s.checkSchema(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isWild(seg string) bool {
+	return strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")
+}
+
+func firstWild(segs []string) int {
+	for i, seg := range segs {
+		if isWild(seg) {
+			return i
+		}
+	}
+
+	return len(segs)
+}
@@ -0,0 +1,566 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	appJSON = "application/json"
+	appForm = "application/x-www-form-urlencoded"
+	// maxBody is the most of a body read to check it, the API reads no more either
+	maxBody = 1 << 20
+)
+
+// ValidationError lists what doesn't match the specification, Status is the one to answer with
+type ValidationError struct {
+	Status   int
+	Problems []models.Problem
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Problems))
+
+	for _, p := range e.Problems {
+		msgs = append(msgs, strings.TrimSpace(p.In+" "+p.Name)+": "+p.Msg)
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateRequest checks the parameters and the body of r against the operation documented for it,
+// the route is nil when the request is for none. The body is put back for the handler to read.
+// Every keyword of the spec is enforced, Load refuses the ones that aren't.
+func (s *Spec) ValidateRequest(r *http.Request) (*Route, error) {
+	rt, ok := s.FindRoute(r)
+	if !ok {
+		return nil, nil
+	}
+
+	c := checker{spec: s}
+
+	for _, p := range s.parameters(rt) {
+		var values []string
+
+		switch p.In {
+		case "path":
+			values = []string{rt.params[p.Name]}
+		case "query":
+			values = r.URL.Query()[p.Name]
+		case "header":
+			values = r.Header.Values(p.Name)
+		default:
+			// the cookies are the auth middleware's
+			continue
+		}
+
+		c.in = p.In
+		c.text(p.Name, values, p.Schema, p.Required)
+	}
+
+	status, err := c.body(r, s.requestBody(rt.op.RequestBody))
+	if err != nil {
+		return rt, err
+	}
+
+	if len(c.problems) > 0 {
+		return rt, &ValidationError{Status: status, Problems: c.problems}
+	}
+
+	return rt, nil
+}
+
+// ValidateResponse checks the status, content type and JSON body of a response to a request for rt,
+// contentType is the one the handler set
+func (s *Spec) ValidateResponse(rt *Route, status int, contentType string, body []byte) error {
+	code := strconv.Itoa(status)
+
+	resp, ok := rt.op.Responses[code]
+	if !ok {
+		resp, ok = rt.op.Responses[code[:1]+"XX"]
+	}
+
+	if !ok {
+		resp, ok = rt.op.Responses["default"]
+	}
+
+	if !ok {
+		return &ValidationError{Problems: []models.Problem{{In: "status", Name: code, Msg: "is not documented"}}}
+	}
+
+	resp = s.response(resp)
+	if len(resp.Content) == 0 || len(body) == 0 {
+		return nil
+	}
+
+	// the HTML handlers leave the content type to be sniffed, their body is taken to be the one documented
+	if contentType == "" && len(resp.Content) == 1 {
+		for mt := range resp.Content {
+			contentType = mt
+		}
+	}
+
+	c := checker{spec: s, in: "body"}
+	c.content(resp.Content, contentType, body)
+
+	if len(c.problems) > 0 {
+		return &ValidationError{Problems: c.problems}
+	}
+
+	return nil
+}
+
+// checker collects the problems of a request or a response, in is where the value checked is
+type checker struct {
+	spec     *Spec
+	in       string
+	problems []models.Problem
+}
+
+func (c *checker) add(name, msg string) {
+	c.problems = append(c.problems, models.Problem{In: c.in, Name: name, Msg: msg})
+}
+
+// body checks the request body, the status is 415 when its content type isn't documented
+func (c *checker) body(r *http.Request, rb *RequestBody) (int, error) {
+	if rb == nil {
+		return http.StatusBadRequest, nil
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	c.in = "body"
+
+	if len(body) == 0 {
+		if rb.Required {
+			c.add("", "is required")
+		}
+
+		return http.StatusBadRequest, nil
+	}
+
+	if !c.content(rb.Content, r.Header.Get("Content-Type"), body) {
+		return http.StatusUnsupportedMediaType, nil
+	}
+
+	return http.StatusBadRequest, nil
+}
+
+// content checks a JSON or form body against the schema of its content type, false when the
+// content type isn't one of the documented ones
+func (c *checker) content(content map[string]*MediaType, contentType string, body []byte) bool {
+	mt, _, _ := mime.ParseMediaType(contentType)
+
+	media, ok := content[mt]
+	if !ok {
+		types := make([]string, 0, len(content))
+		for t := range content {
+			types = append(types, t)
+		}
+
+		sort.Strings(types)
+
+		c.in = "header"
+		c.add("Content-Type", "must be one of "+strings.Join(types, ", "))
+
+		return false
+	}
+
+	if media == nil || media.Schema == nil {
+		return true
+	}
+
+	// the structured syntax suffix marks JSON based types like application/merge-patch+json
+	switch {
+	case mt == appJSON || strings.HasSuffix(mt, "+json"):
+		var v any
+
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+
+		if err := dec.Decode(&v); err != nil {
+			c.add("", "is not valid JSON")
+			return true
+		}
+
+		c.value("", v, media.Schema)
+	case mt == appForm:
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			c.add("", "is not a valid form")
+			return true
+		}
+
+		c.form(values, media.Schema)
+	}
+
+	return true
+}
+
+// form checks the fields of a form, like query parameters an empty field counts as a missing one
+func (c *checker) form(values url.Values, sc *Schema) {
+	sc = c.spec.schema(sc)
+	if sc == nil {
+		return
+	}
+
+	required := make(map[string]bool, len(sc.Required))
+
+	for _, name := range sc.Required {
+		required[name] = true
+
+		if _, ok := sc.Properties[name]; !ok {
+			c.text(name, values[name], nil, true)
+		}
+	}
+
+	for _, name := range sortedKeys(sc.Properties) {
+		c.text(name, values[name], sc.Properties[name], required[name])
+	}
+
+	for _, name := range sortedKeys(values) {
+		if _, ok := sc.Properties[name]; !ok && sc.AdditionalProperties != nil && !sc.AdditionalProperties.Allowed {
+			c.add(name, "is not allowed")
+		}
+	}
+}
+
+// text checks the string values of a parameter or a form field, read as the type of the schema.
+// Empty values are left out, the handlers take them as missing.
+func (c *checker) text(name string, raw []string, sc *Schema, required bool) {
+	values := make([]string, 0, len(raw))
+
+	for _, v := range raw {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == 0 {
+		if required {
+			c.add(name, "is required")
+		}
+
+		return
+	}
+
+	sc = c.spec.schema(sc)
+	if sc == nil {
+		return
+	}
+
+	item := sc
+	if sc.Type == "array" {
+		item = c.spec.schema(sc.Items)
+		c.count(name, len(values), sc)
+	}
+
+	for _, v := range values {
+		c.value(name, coerce(v, item), item)
+	}
+}
+
+// value checks a JSON value, name is its dotted path in the body
+func (c *checker) value(name string, v any, sc *Schema) {
+	sc = c.spec.schema(sc)
+	if sc == nil {
+		return
+	}
+
+	for _, sub := range sc.AllOf {
+		c.value(name, v, sub)
+	}
+
+	if len(sc.AnyOf) > 0 && c.matching(name, v, sc.AnyOf) == 0 {
+		c.add(name, "must match one of its schemas")
+	}
+
+	if len(sc.OneOf) > 0 && c.matching(name, v, sc.OneOf) != 1 {
+		c.add(name, "must match exactly one of its schemas")
+	}
+
+	if v == nil {
+		if sc.Type != "" && !sc.Nullable {
+			c.add(name, "must not be null")
+		}
+
+		return
+	}
+
+	switch sc.Type {
+	case "object":
+		c.object(name, v, sc)
+	case "array":
+		c.array(name, v, sc)
+	case "string":
+		c.str(name, v, sc)
+	case "integer", "number":
+		c.number(name, v, sc)
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			c.add(name, "must be a boolean")
+		}
+	}
+}
+
+// matching counts the schemas v passes
+func (c *checker) matching(name string, v any, schemas []*Schema) int {
+	n := 0
+
+	for _, sub := range schemas {
+		alt := checker{spec: c.spec, in: c.in}
+		if alt.value(name, v, sub); len(alt.problems) == 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (c *checker) object(name string, v any, sc *Schema) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		c.add(name, "must be an object")
+		return
+	}
+
+	for _, field := range sc.Required {
+		if _, ok := m[field]; !ok {
+			c.add(join(name, field), "is required")
+		}
+	}
+
+	for _, field := range sortedKeys(sc.Properties) {
+		if fv, ok := m[field]; ok {
+			c.value(join(name, field), fv, sc.Properties[field])
+		}
+	}
+
+	for _, field := range sortedKeys(m) {
+		if _, ok := sc.Properties[field]; !ok {
+			c.additional(join(name, field), m[field], sc)
+		}
+	}
+}
+
+// additional checks a property the schema doesn't list
+func (c *checker) additional(name string, v any, sc *Schema) {
+	switch ap := sc.AdditionalProperties; {
+	case ap == nil:
+	case !ap.Allowed:
+		c.add(name, "is not allowed")
+	case ap.Schema != nil:
+		c.value(name, v, ap.Schema)
+	}
+}
+
+func (c *checker) array(name string, v any, sc *Schema) {
+	items, ok := v.([]any)
+	if !ok {
+		c.add(name, "must be an array")
+		return
+	}
+
+	c.count(name, len(items), sc)
+
+	for i, item := range items {
+		c.value(fmt.Sprintf("%s[%d]", name, i), item, sc.Items)
+	}
+}
+
+func (c *checker) count(name string, n int, sc *Schema) {
+	if sc.MinItems != nil && n < *sc.MinItems {
+		c.add(name, fmt.Sprintf("must have at least %d items", *sc.MinItems))
+	}
+
+	if sc.MaxItems != nil && n > *sc.MaxItems {
+		c.add(name, fmt.Sprintf("must have at most %d items", *sc.MaxItems))
+	}
+}
+
+// str checks a string, an empty one stands for no value so only its length is checked
+func (c *checker) str(name string, v any, sc *Schema) {
+	s, ok := v.(string)
+	if !ok {
+		c.add(name, "must be a string")
+		return
+	}
+
+	n := len([]rune(s))
+	if sc.MinLength != nil && n < *sc.MinLength {
+		c.add(name, fmt.Sprintf("must be at least %d characters", *sc.MinLength))
+	}
+
+	if sc.MaxLength != nil && n > *sc.MaxLength {
+		c.add(name, fmt.Sprintf("must be at most %d characters", *sc.MaxLength))
+	}
+
+	if s == "" {
+		return
+	}
+
+	c.enum(name, s, sc)
+
+	if re := c.spec.patterns[sc.Pattern]; re != nil && !re.MatchString(s) {
+		c.add(name, "must match "+sc.Pattern)
+	}
+
+	if msg := checkFormat(sc.Format, s); msg != "" {
+		c.add(name, msg)
+	}
+}
+
+func (c *checker) number(name string, v any, sc *Schema) {
+	msg := "must be a number"
+	if sc.Type == "integer" {
+		msg = "must be an integer"
+	}
+
+	n, ok := v.(json.Number)
+	if !ok {
+		c.add(name, msg)
+		return
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		c.add(name, msg)
+		return
+	}
+
+	if _, err := n.Int64(); sc.Type == "integer" && err != nil {
+		c.add(name, msg)
+		return
+	}
+
+	switch {
+	case sc.Minimum == nil:
+	case sc.ExclusiveMinimum && f <= *sc.Minimum:
+		c.add(name, "must be more than "+strconv.FormatFloat(*sc.Minimum, 'f', -1, 64))
+	case f < *sc.Minimum:
+		c.add(name, "must be at least "+strconv.FormatFloat(*sc.Minimum, 'f', -1, 64))
+	}
+
+	switch {
+	case sc.Maximum == nil:
+	case sc.ExclusiveMaximum && f >= *sc.Maximum:
+		c.add(name, "must be less than "+strconv.FormatFloat(*sc.Maximum, 'f', -1, 64))
+	case f > *sc.Maximum:
+		c.add(name, "must be at most "+strconv.FormatFloat(*sc.Maximum, 'f', -1, 64))
+	}
+
+	c.enum(name, n.String(), sc)
+}
+
+func (c *checker) enum(name, v string, sc *Schema) {
+	if len(sc.Enum) == 0 {
+		return
+	}
+
+	allowed := make([]string, 0, len(sc.Enum))
+
+	for _, e := range sc.Enum {
+		if fmt.Sprint(e) == v {
+			return
+		}
+
+		allowed = append(allowed, fmt.Sprint(e))
+	}
+
+	c.add(name, "must be one of "+strings.Join(allowed, ", "))
+}
+
+// coerce reads a query or form value as the type of its schema, a value that can't be read is
+// left a string for the check to reject
+func coerce(v string, sc *Schema) any {
+	if sc == nil {
+		return v
+	}
+
+	switch sc.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return json.Number(v)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+
+	return v
+}
+
+// readBody reads the request body and puts it back for the handler
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody))
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+
+	return body, err
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func join(name, field string) string {
+	if name == "" {
+		return field
+	}
+
+	return name + "." + field
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// checkFormat is what is wrong with s in the format, empty when nothing is or the format isn't checked
+func checkFormat(format, s string) string {
+	var err error
+
+	switch format {
+	case "date":
+		_, err = time.Parse(time.DateOnly, s)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, s)
+	case "uuid":
+		_, err = uuid.Parse(s)
+	default:
+		return ""
+	}
+
+	if err != nil {
+		return "must be a " + format
+	}
+
+	return ""
+}
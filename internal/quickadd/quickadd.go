@@ -0,0 +1,371 @@
+// Package quickadd reads a task written as a single line, like "Pay rent tomorrow 9am #home !high every month".
+//
+// The line is read word by word, the first due date, time of day, priority and repeat phrase found
+// are taken out of it along with every #tag, the words left over are the title:
+//
+//   - dates: today, tomorrow, a weekday ("friday" is the coming one, today included, "next friday"
+//     is after today), next week, next month, next year, "in 3 days", "in a week", 2026-10-20, oct 20
+//     or 20 oct, optionally after on, by or due
+//   - times: 9am, 9:30pm, 9 pm, 17:30 or noon, optionally after at. "in 2 hours" sets both the date and the time
+//   - priority: a word starting with !, like !high
+//   - tags: words starting with #, like #home
+//   - repeats: daily, weekly, monthly, yearly, every day, every other week, every 2 months, every weekday,
+//     every monday or every mon,thu
+//
+// Only the shape of the line is read here, the values are checked by whoever adds the task.
+package quickadd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout = time.DateOnly
+	timeLayout = "15:04"
+)
+
+// Task is what a line asks for, the fields that weren't given are empty. DueDate and DueTime are
+// in the time zone of the now the line was parsed at, Recurrence is an RFC 5545 RRULE.
+type Task struct {
+	Title      string
+	DueDate    string
+	DueTime    string
+	Priority   string
+	Recurrence string
+	Tags       []string
+}
+
+type parser struct {
+	now   time.Time
+	today time.Time
+	task  Task
+	// day is the due date once one is found, clock its time of day
+	day   *time.Time
+	clock string
+}
+
+// Parse reads line as a task to add at now, a time without a date is due today, or tomorrow when
+// it has already passed. A weekly repeat without a date starts on its first day.
+func Parse(line string, now time.Time) Task {
+	y, m, d := now.Date()
+	p := parser{now: now, today: time.Date(y, m, d, 0, 0, 0, 0, now.Location())}
+
+	words := strings.Fields(line)
+	lower := strings.Fields(strings.ToLower(line))
+	title := make([]string, 0, len(words))
+
+	for i := 0; i < len(words); {
+		if n := p.match(words[i:], lower[i:]); n > 0 {
+			i += n
+
+			continue
+		}
+
+		title = append(title, words[i])
+		i++
+	}
+
+	p.task.Title = strings.Join(title, " ")
+	p.finish()
+
+	return p.task
+}
+
+// match takes the phrase words starts with and returns how many words it is made of, 0 when it
+// is part of the title
+func (p *parser) match(words, lower []string) int {
+	w := lower[0]
+
+	switch {
+	case len(w) > 1 && w[0] == '#':
+		p.task.Tags = append(p.task.Tags, words[0][1:])
+
+		return 1
+	case len(w) > 1 && w[0] == '!' && p.task.Priority == "":
+		p.task.Priority = w[1:]
+
+		return 1
+	}
+
+	if p.task.Recurrence == "" {
+		if n := p.repeat(lower); n > 0 {
+			return n
+		}
+	}
+
+	if p.day == nil {
+		if n := p.prefixed(lower, p.date, "on", "by", "due"); n > 0 {
+			return n
+		}
+	}
+
+	if p.clock == "" {
+		return p.prefixed(lower, p.timeOfDay, "at")
+	}
+
+	return 0
+}
+
+// prefixed matches words with match, the phrase may follow one of the given prefixes
+func (*parser) prefixed(words []string, match func([]string) int, prefixes ...string) int {
+	if n := match(words); n > 0 {
+		return n
+	}
+
+	for _, prefix := range prefixes {
+		if words[0] == prefix && len(words) > 1 {
+			if n := match(words[1:]); n > 0 {
+				return n + 1
+			}
+		}
+	}
+
+	return 0
+}
+
+func (p *parser) date(words []string) int {
+	w := words[0]
+
+	if day, ok := p.relativeDay(w); ok {
+		return p.setDay(day, 1)
+	}
+
+	if day, err := time.ParseInLocation(dateLayout, w, p.now.Location()); err == nil {
+		return p.setDay(day, 1)
+	}
+
+	if len(words) < 2 {
+		return 0
+	}
+
+	switch w {
+	case "next":
+		return p.next(words[1])
+	case "in":
+		return p.in(words[1:])
+	}
+
+	if day, ok := p.monthDay(words[0], words[1]); ok {
+		return p.setDay(day, 2)
+	}
+
+	return 0
+}
+
+// relativeDay reads a day named after today, a weekday is the coming one, today included
+func (p *parser) relativeDay(w string) (time.Time, bool) {
+	switch w {
+	case "today":
+		return p.today, true
+	case "tomorrow", "tmr", "tmrw":
+		return p.today.AddDate(0, 0, 1), true
+	}
+
+	if wd, ok := weekday(w); ok {
+		return p.today.AddDate(0, 0, (int(wd)-int(p.today.Weekday())+7)%7), true
+	}
+
+	return time.Time{}, false
+}
+
+// next reads "next" followed by w, a weekday after today or the same day a week, month or year later
+func (p *parser) next(w string) int {
+	switch w {
+	case "week":
+		return p.setDay(p.today.AddDate(0, 0, 7), 2)
+	case "month":
+		return p.setDay(p.today.AddDate(0, 1, 0), 2)
+	case "year":
+		return p.setDay(p.today.AddDate(1, 0, 0), 2)
+	}
+
+	wd, ok := weekday(w)
+	if !ok {
+		return 0
+	}
+
+	days := (int(wd) - int(p.today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+
+	return p.setDay(p.today.AddDate(0, 0, days), 2)
+}
+
+// in reads "in" followed by an amount of time like "3 days" or "an hour", hours and minutes set
+// the time of day too
+func (p *parser) in(words []string) int {
+	if len(words) < 2 {
+		return 0
+	}
+
+	n, ok := count(words[0])
+	if !ok {
+		return 0
+	}
+
+	switch strings.TrimSuffix(words[1], "s") {
+	case "minute", "min":
+		return p.setMoment(p.now.Add(time.Duration(n)*time.Minute), 3)
+	case "hour":
+		return p.setMoment(p.now.Add(time.Duration(n)*time.Hour), 3)
+	case "day":
+		return p.setDay(p.today.AddDate(0, 0, n), 3)
+	case "week":
+		return p.setDay(p.today.AddDate(0, 0, 7*n), 3)
+	case "month":
+		return p.setDay(p.today.AddDate(0, n, 0), 3)
+	case "year":
+		return p.setDay(p.today.AddDate(n, 0, 0), 3)
+	}
+
+	return 0
+}
+
+// monthDay reads a day of a month like "oct 20" or "20th october", the coming one
+func (p *parser) monthDay(a, b string) (time.Time, bool) {
+	m, ok := month(a)
+	d := b
+
+	if !ok {
+		m, ok = month(b)
+		d = a
+	}
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		d = strings.TrimSuffix(d, suffix)
+	}
+
+	n, err := strconv.Atoi(d)
+	if err != nil || n < 1 || n > 31 {
+		return time.Time{}, false
+	}
+
+	day := time.Date(p.today.Year(), m, n, 0, 0, 0, 0, p.today.Location())
+	if day.Month() != m {
+		// like feb 30
+		return time.Time{}, false
+	}
+
+	if day.Before(p.today) {
+		day = day.AddDate(1, 0, 0)
+	}
+
+	return day, true
+}
+
+func (p *parser) timeOfDay(words []string) int {
+	w := words[0]
+
+	if w == "noon" {
+		p.clock = "12:00"
+
+		return 1
+	}
+
+	if clock, ok := parseClock(w); ok {
+		p.clock = clock
+
+		return 1
+	}
+
+	// "9 am"
+	if len(words) > 1 && (words[1] == "am" || words[1] == "pm") {
+		if clock, ok := parseClock(w + words[1]); ok {
+			p.clock = clock
+
+			return 2
+		}
+	}
+
+	return 0
+}
+
+func (p *parser) setDay(day time.Time, n int) int {
+	p.day = &day
+
+	return n
+}
+
+// setMoment sets both the due date and its time of day, the clock unless one was already found
+func (p *parser) setMoment(at time.Time, n int) int {
+	y, m, d := at.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, at.Location())
+	p.day = &day
+
+	if p.clock == "" {
+		p.clock = at.Format(timeLayout)
+	}
+
+	return n
+}
+
+// finish fills the due date of a time or a repeat found without one
+func (p *parser) finish() {
+	p.task.DueTime = p.clock
+
+	if p.day == nil {
+		// a time that has passed today is due on the next day that fits
+		from := 0
+		if at, err := time.Parse(timeLayout, p.clock); err == nil && clockBefore(at, p.now) {
+			from = 1
+		}
+
+		switch byDay, weekly := strings.CutPrefix(p.task.Recurrence, "FREQ=WEEKLY;BYDAY="); {
+		case weekly:
+			p.day = p.firstWeekday(byDay, from)
+		case p.clock != "":
+			day := p.today.AddDate(0, 0, from)
+			p.day = &day
+		}
+	}
+
+	if p.day != nil {
+		p.task.DueDate = p.day.Format(dateLayout)
+	}
+}
+
+// firstWeekday is the first day from days after today on that falls on one of the RRULE weekdays in byDay
+func (p *parser) firstWeekday(byDay string, from int) *time.Time {
+	for i := from; i < from+7; i++ {
+		day := p.today.AddDate(0, 0, i)
+		if strings.Contains(byDay, rruleDay(day.Weekday())) {
+			return &day
+		}
+	}
+
+	return nil
+}
+
+// parseClock reads a time of day like 9am, 9:30pm or 17:30 as 15:04
+func parseClock(w string) (string, bool) {
+	for _, layout := range []string{"3pm", "3:04pm", "15:04"} {
+		if t, err := time.Parse(layout, w); err == nil {
+			return t.Format(timeLayout), true
+		}
+	}
+
+	return "", false
+}
+
+// clockBefore tells whether the time of day of at comes before that of now
+func clockBefore(at, now time.Time) bool {
+	return at.Hour()*60+at.Minute() < now.Hour()*60+now.Minute()
+}
+
+// count reads a positive number, "a" and "an" are one
+func count(w string) (int, bool) {
+	if w == "a" || w == "an" {
+		return 1, true
+	}
+
+	n, err := strconv.Atoi(w)
+
+	return n, err == nil && n > 0
+}
@@ -0,0 +1,63 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a Wednesday
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, paris)
+
+	tests := []struct {
+		line string
+		want Task
+	}{
+		{line: "Pay rent tomorrow 9am #home !high every month", want: Task{Title: "Pay rent", DueDate: "2026-10-15",
+			DueTime: "09:00", Priority: "high", Recurrence: "FREQ=MONTHLY", Tags: []string{"home"}}},
+		{line: "Buy milk", want: Task{Title: "Buy milk"}},
+		{line: "Call mom friday", want: Task{Title: "Call mom", DueDate: "2026-10-16"}},
+		{line: "Call mom next Friday", want: Task{Title: "Call mom", DueDate: "2026-10-16"}},
+		{line: "Review wednesday", want: Task{Title: "Review", DueDate: "2026-10-14"}},
+		{line: "Review next wed", want: Task{Title: "Review", DueDate: "2026-10-21"}},
+		{line: "Renew passport in 3 days", want: Task{Title: "Renew passport", DueDate: "2026-10-17"}},
+		{line: "Renew passport in a week", want: Task{Title: "Renew passport", DueDate: "2026-10-21"}},
+		{line: "Check oven in 2 hours", want: Task{Title: "Check oven", DueDate: "2026-10-14", DueTime: "12:00"}},
+		{line: "Dentist in 2 months at 8:15am", want: Task{Title: "Dentist", DueDate: "2026-12-14", DueTime: "08:15"}},
+		{line: "Report next month", want: Task{Title: "Report", DueDate: "2026-11-14"}},
+		{line: "Standup at 9:30", want: Task{Title: "Standup", DueDate: "2026-10-15", DueTime: "09:30"}},
+		{line: "Call bank at 5pm", want: Task{Title: "Call bank", DueDate: "2026-10-14", DueTime: "17:00"}},
+		{line: "Lunch at noon", want: Task{Title: "Lunch", DueDate: "2026-10-14", DueTime: "12:00"}},
+		{line: "9 pm call", want: Task{Title: "call", DueDate: "2026-10-14", DueTime: "21:00"}},
+		{line: "Taxes due apr 15", want: Task{Title: "Taxes", DueDate: "2027-04-15"}},
+		{line: "Party on 20th Dec", want: Task{Title: "Party", DueDate: "2026-12-20"}},
+		{line: "2026-11-01 quarterly report", want: Task{Title: "quarterly report", DueDate: "2026-11-01"}},
+		{line: "Gym every mon,thu 7am", want: Task{Title: "Gym", DueDate: "2026-10-15", DueTime: "07:00",
+			Recurrence: "FREQ=WEEKLY;BYDAY=MO,TH"}},
+		{line: "Gym every wednesday 7am", want: Task{Title: "Gym", DueDate: "2026-10-21", DueTime: "07:00",
+			Recurrence: "FREQ=WEEKLY;BYDAY=WE"}},
+		{line: "Backup every other week", want: Task{Title: "Backup", Recurrence: "FREQ=WEEKLY;INTERVAL=2"}},
+		{line: "Water plants every 3 days", want: Task{Title: "Water plants", Recurrence: "FREQ=DAILY;INTERVAL=3"}},
+		{line: "Timesheet every weekday", want: Task{Title: "Timesheet", Recurrence: "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR"}},
+		{line: "Review daily #work #Team", want: Task{Title: "Review", Recurrence: "FREQ=DAILY", Tags: []string{"work", "Team"}}},
+		// only the first date is taken out
+		{line: "Call mom tomorrow about sunday plans", want: Task{Title: "Call mom about sunday plans", DueDate: "2026-10-15"}},
+		{line: "Meeting at the office in the morning", want: Task{Title: "Meeting at the office in the morning"}},
+		{line: "Book on feb 30", want: Task{Title: "Book on feb 30"}},
+		{line: "  # !  ", want: Task{Title: "# !"}},
+		{line: "", want: Task{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.line, now))
+		})
+	}
+}
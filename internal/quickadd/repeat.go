@@ -0,0 +1,133 @@
+package quickadd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nolint:gochecknoglobals // read only lookup table
+var frequencies = map[string]string{
+	"day": "DAILY", "week": "WEEKLY", "month": "MONTHLY", "year": "YEARLY",
+}
+
+// repeat reads how often the task comes back as an RRULE
+func (p *parser) repeat(words []string) int {
+	switch words[0] {
+	case "daily":
+		return p.setRepeat("FREQ=DAILY", 1)
+	case "weekly":
+		return p.setRepeat("FREQ=WEEKLY", 1)
+	case "monthly":
+		return p.setRepeat("FREQ=MONTHLY", 1)
+	case "yearly", "annually":
+		return p.setRepeat("FREQ=YEARLY", 1)
+	case "every":
+		if len(words) > 1 {
+			return p.every(words[1:])
+		}
+	}
+
+	return 0
+}
+
+// every reads what follows "every": a period, an interval and a period, or weekdays
+func (p *parser) every(words []string) int {
+	w := words[0]
+
+	if freq, ok := frequencies[w]; ok {
+		return p.setRepeat("FREQ="+freq, 2)
+	}
+
+	if w == "weekday" {
+		return p.setRepeat("FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", 2)
+	}
+
+	if byDay, ok := weekdays(w); ok {
+		return p.setRepeat("FREQ=WEEKLY;BYDAY="+byDay, 2)
+	}
+
+	if len(words) < 2 {
+		return 0
+	}
+
+	interval := 2
+	if w != "other" {
+		n, err := strconv.Atoi(w)
+		if err != nil || n < 1 {
+			return 0
+		}
+
+		interval = n
+	}
+
+	freq, ok := frequencies[strings.TrimSuffix(words[1], "s")]
+	if !ok {
+		return 0
+	}
+
+	if interval == 1 {
+		return p.setRepeat("FREQ="+freq, 3)
+	}
+
+	return p.setRepeat("FREQ="+freq+";INTERVAL="+strconv.Itoa(interval), 3)
+}
+
+func (p *parser) setRepeat(rule string, n int) int {
+	p.task.Recurrence = rule
+
+	return n
+}
+
+// weekdays reads a comma separated list of weekdays like "mon,thu" as the days of a BYDAY
+func weekdays(w string) (string, bool) {
+	days := make([]string, 0)
+
+	for _, name := range strings.Split(w, ",") {
+		wd, ok := weekday(name)
+		if !ok {
+			return "", false
+		}
+
+		days = append(days, rruleDay(wd))
+	}
+
+	return strings.Join(days, ","), true
+}
+
+// weekday reads a weekday by its name or a short form of it like "thu" or "thurs"
+func weekday(w string) (time.Weekday, bool) {
+	if len(w) < 3 {
+		return 0, false
+	}
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if strings.HasPrefix(name, w) && (len(w) == 3 || w == name || w == "tues" || w == "thur" || w == "thurs") {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
+
+// month reads a month by its name or its first three letters
+func month(w string) (time.Month, bool) {
+	if len(w) < 3 {
+		return 0, false
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if w == name || w == name[:3] || (m == time.September && w == "sept") {
+			return m, true
+		}
+	}
+
+	return 0, false
+}
+
+// rruleDay is the two letter RRULE name of a weekday
+func rruleDay(d time.Weekday) string {
+	return strings.ToUpper(d.String()[:2])
+}
@@ -5,44 +5,45 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
-	"todoapp/internal/models"
 
-	"github.com/sqlitecloud/sqlitecloud-go"
+	"todoapp/internal/database"
 )
 
-func newDB(logger *slog.Logger) (*sqlitecloud.SQCloud, error) {
+func newDB(logger *slog.Logger, driver string) (database.DB, error) {
 	ctx := context.Background()
 
-	config := sqlitecloud.SQCloudConfig{
+	config := database.Config{
+		Driver:   driver,
 		Host:     os.Getenv("DB_HOST"),
 		Port:     getEnvAsInt("DB_PORT", 8860),
 		Database: os.Getenv("DB_NAME"),
-		ApiKey:   os.Getenv("DB_API_KEY"),
+		APIKey:   os.Getenv("DB_API_KEY"),
 		MaxRows:  getEnvAsInt("DB_MAX_ROWS", 20),
+		Path:     getEnvOrDefault("DB_PATH", "todo.db"),
+		URL:      os.Getenv("DB_URL"),
+		MaxConns: getEnvAsInt("DB_MAX_CONNS", 10),
 	}
 
-	isSecure, err := strconv.ParseBool(os.Getenv("DB_SECURE_FLAG"))
-	if err != nil {
-		return nil, err
-	}
-
-	config.Secure = isSecure
+	if config.Driver == database.DriverSQLiteCloud {
+		isSecure, err := strconv.ParseBool(os.Getenv("DB_SECURE_FLAG"))
+		if err != nil {
+			return nil, err
+		}
 
-	sqcl := sqlitecloud.New(config)
+		config.Secure = isSecure
+	}
 
-	if err := sqcl.Connect(); err != nil {
+	db, err := database.Open(&config)
+	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while connecting to Database",
+			slog.String("driver", config.Driver),
 			slog.String("error", err.Error()),
 		)
 
 		return nil, err
 	}
 
-	if !sqcl.IsConnected() {
-		return nil, models.NewConstError("database is not connected after conn success")
-	}
-
-	logger.LogAttrs(ctx, slog.LevelInfo, "DB connected successfully")
+	logger.LogAttrs(ctx, slog.LevelInfo, "DB connected successfully", slog.String("driver", config.Driver))
 
-	return sqcl, nil
+	return db, nil
 }
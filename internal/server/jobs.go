@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/notify"
+	"todoapp/internal/service/notifysvc"
+	"todoapp/internal/service/todosvc"
+)
+
+// RunTrashPurge permanently deletes the tasks trashed for longer than the retention period,
+// once at start and then every purge interval until ctx is done
+func (s *Server) RunTrashPurge(ctx context.Context) {
+	if s.TrashPurgeInterval <= 0 {
+		s.Logger.LogAttrs(ctx, slog.LevelInfo, "trash purge disabled")
+
+		return
+	}
+
+	svc := todosvc.New(s.stores.todo, s.stores.tx)
+
+	ticker := time.NewTicker(s.TrashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		// the error is logged by the service, the next tick tries again
+		_, _ = svc.PurgeTrash(ctx, s.TrashRetention)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunReminders sends the due reminders through every configured channel every reminder interval
+// until ctx is done
+func (s *Server) RunReminders(ctx context.Context) {
+	if s.ReminderInterval <= 0 {
+		s.Logger.LogAttrs(ctx, slog.LevelInfo, "reminders disabled")
+
+		return
+	}
+
+	svc := notifysvc.New(s.stores.notify, s.stores.user, s.notifiers()...)
+
+	ticker := time.NewTicker(s.ReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		// the error is logged by the service, the next tick tries again
+		_, _ = svc.SendReminders(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// notifiers are the channels reminders go out through, the in-app one is always on
+func (s *Server) notifiers() []notifysvc.Notifier {
+	res := []notifysvc.Notifier{notify.NewInApp(s.stores.notify)}
+
+	if s.SMTPAddr != "" {
+		res = append(res, notify.NewSMTP(s.SMTPAddr, s.SMTPFrom, s.SMTPUsername, s.SMTPPassword))
+	}
+
+	if s.WebhookURL != "" {
+		res = append(res, notify.NewWebhook(s.WebhookURL, s.WebhookSecret))
+	}
+
+	return res
+}
@@ -1,26 +1,34 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"html/template"
+	"io"
 	"log/slog"
+	"mime"
 	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	apihttp "todoapp/internal/handler/api"
 	"todoapp/internal/models"
+	"todoapp/internal/openapi"
 
 	"github.com/google/uuid"
-	"github.com/sqlitecloud/sqlitecloud-go"
 )
 
 const (
 	invalidCookieMsg = "user not logged in, please login again!!"
 	cookieName       = "token"
+	// maxLoginBody is the most of a JSON login body read for its email, in bytes
+	maxLoginBody = 1 << 12
+	// maxRecorded is the most of a response body kept to check it against the spec, in bytes
+	maxRecorded = 1 << 20
 )
 
 type middleware func(http.HandlerFunc) http.HandlerFunc
@@ -37,7 +45,8 @@ func method(m string) middleware {
 	return func(f http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
 			if r.Method != m {
-				http.Error(w,
+				w.Header().Set("Allow", m)
+				httpError(w, r,
 					http.StatusText(http.StatusMethodNotAllowed),
 					http.StatusMethodNotAllowed,
 				)
@@ -63,10 +72,23 @@ func isHTMX() middleware {
 	}
 }
 
+// authMiddleware signs in the request with the session of the token cookie, or with the personal
+// access token of an Authorization: Bearer header
 func (s *Server) authMiddleware(ctx context.Context) middleware {
 	return func(f http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
-			var temp = template.Must(template.ParseGlob("views/*"))
+			if userCtx, ok, err := s.bearerContext(ctx, r); ok {
+				if err != nil {
+					http.Error(w, err.Error(), bearerStatus(err))
+					return
+				}
+
+				f(w, r.WithContext(userCtx))
+
+				return
+			}
+
+			temp := models.NewTemplate()
 
 			cookieVal, err := validateCookie(ctx, s.Logger, r)
 			if err != nil {
@@ -88,7 +110,7 @@ func (s *Server) authMiddleware(ctx context.Context) middleware {
 				return
 			}
 
-			uid, err := getSessionID(ctx, s.DB, s.Logger, cookieVal)
+			uid, err := s.stores.session.GetUserIDByToken(ctx, cookieVal)
 			if err != nil {
 				s.Logger.LogAttrs(ctx, slog.LevelError, "error while validating session", slog.String("error", err.Error()))
 
@@ -102,11 +124,200 @@ func (s *Server) authMiddleware(ctx context.Context) middleware {
 				return
 			}
 
-			f(w, r.WithContext(context.WithValue(ctx, models.CtxKeyUserID, *uid)))
+			userCtx := context.WithValue(ctx, models.CtxKeyUserID, *uid)
+			f(w, r.WithContext(context.WithValue(userCtx, models.CtxKeyLocation, s.userLocation(userCtx, uid))))
+		}
+	}
+}
+
+// apiAuthMiddleware signs in the API requests like authMiddleware, answering with a JSON error
+func (s *Server) apiAuthMiddleware(ctx context.Context) middleware {
+	return func(f http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if userCtx, ok, err := s.bearerContext(ctx, r); ok {
+				if err != nil {
+					apihttp.WriteError(w, bearerStatus(err), err.Error())
+					return
+				}
+
+				f(w, r.WithContext(userCtx))
+
+				return
+			}
+
+			cookieVal, err := validateCookie(ctx, s.Logger, r)
+			if err != nil {
+				apihttp.WriteError(w, http.StatusUnauthorized, invalidCookieMsg)
+				return
+			}
+
+			uid, err := s.stores.session.GetUserIDByToken(ctx, cookieVal)
+			if err != nil {
+				s.Logger.LogAttrs(ctx, slog.LevelError, "error while validating session", slog.String("error", err.Error()))
+				apihttp.WriteError(w, http.StatusUnauthorized, invalidCookieMsg)
+
+				return
+			}
+
+			userCtx := context.WithValue(ctx, models.CtxKeyUserID, *uid)
+			f(w, r.WithContext(context.WithValue(userCtx, models.CtxKeyLocation, s.userLocation(userCtx, uid))))
 		}
 	}
 }
 
+// bearerContext signs in a request carrying a personal access token as the token's user, ok is
+// false when it carries none. The token must have the scope the request needs.
+func (s *Server) bearerContext(ctx context.Context, r *http.Request) (context.Context, bool, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil, false, nil
+	}
+
+	t, err := s.users.AuthenticateToken(ctx, strings.TrimSpace(token))
+	if err != nil {
+		s.Logger.LogAttrs(ctx, slog.LevelError, "error while validating access token", slog.String("error", err.Error()))
+
+		return nil, true, models.ErrInvalidToken
+	}
+
+	scope := requiredScope(r)
+	if !t.Allows(scope) {
+		return nil, true, fmt.Errorf("%w %s", models.ErrScopeMissing, scope)
+	}
+
+	userCtx := context.WithValue(ctx, models.CtxKeyUserID, t.UserID)
+	userCtx = context.WithValue(userCtx, models.CtxKeyScopes, t.Scopes)
+
+	return context.WithValue(userCtx, models.CtxKeyLocation, s.userLocation(userCtx, &t.UserID)), true, nil
+}
+
+// requiredScope is the scope an access token needs for the request: account for the routes of
+// the user and their tokens, tasks:read to read everything else and tasks:write to change it
+func requiredScope(r *http.Request) models.Scope {
+	path := r.URL.Path
+
+	for _, prefix := range []string{"/user/", "/tokens", apihttp.Prefix + "/me", apihttp.Prefix + "/tokens", apihttp.Prefix + "/auth/"} {
+		if strings.HasPrefix(path, prefix) {
+			return models.ScopeAccount
+		}
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return models.ScopeTasksRead
+	}
+
+	return models.ScopeTasksWrite
+}
+
+// bearerStatus is the status a rejected access token is answered with
+func bearerStatus(err error) int {
+	if errors.Is(err, models.ErrScopeMissing) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusUnauthorized
+}
+
+// httpError answers with a plain text error, or a JSON one on the API routes
+func httpError(w http.ResponseWriter, r *http.Request, msg string, code int) {
+	if strings.HasPrefix(r.URL.Path, apihttp.Prefix+"/") {
+		apihttp.WriteError(w, code, msg)
+		return
+	}
+
+	http.Error(w, msg, code)
+}
+
+// userLocation is the time zone of the user, UTC when it can't be read
+func (s *Server) userLocation(ctx context.Context, uid *uuid.UUID) *time.Location {
+	user, err := s.stores.user.GetUserByID(ctx, uid)
+	if err != nil {
+		s.Logger.LogAttrs(ctx, slog.LevelError, "error while reading the user's time zone", slog.String("error", err.Error()))
+
+		return time.UTC
+	}
+
+	loc, err := models.LoadTimeZone(user.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// ValidateOpenAPI answers the requests that don't match the OpenAPI spec with the list of their
+// problems, in dev mode the responses are checked too and the ones that don't match are logged
+func (s *Server) ValidateOpenAPI(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		route, err := s.spec.ValidateRequest(r)
+		if err != nil {
+			s.Logger.LogAttrs(ctx, slog.LevelDebug, "request does not match the API spec",
+				slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.String("error", err.Error()))
+
+			var verr *openapi.ValidationError
+			if !errors.As(err, &verr) {
+				apihttp.WriteError(w, http.StatusBadRequest, models.ErrInvalid("request body").Error())
+				return
+			}
+
+			apihttp.WriteProblems(w, verr.Status, "request does not match the API spec", verr.Problems)
+
+			return
+		}
+
+		if route == nil || !s.IsDev() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		if err := s.spec.ValidateResponse(route, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes()); err != nil {
+			s.Logger.LogAttrs(ctx, slog.LevelWarn, "response does not match the API spec",
+				slog.String("method", r.Method), slog.String("path", r.URL.Path),
+				slog.Int("status", rec.status), slog.String("error", err.Error()))
+		}
+	})
+}
+
+// recorder keeps a copy of the response it writes for it to be checked against the spec
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(code int) {
+	if rec.status == 0 {
+		rec.status = code
+	}
+
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	if rec.body.Len() < maxRecorded {
+		rec.body.Write(b)
+	}
+
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
 func (s *Server) GlobalRateLimiter(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ip := clientIP(r)
@@ -139,7 +350,7 @@ func (s *Server) GlobalRateLimiter(next http.Handler) http.Handler {
 
 		if info.count > s.globalLimiter.maxAttempts {
 			w.Header().Set("Retry-After", strconv.Itoa(int(s.globalLimiter.timeWindow.Seconds())))
-			http.Error(w, "Too many requests. Please try again later!!", http.StatusTooManyRequests)
+			httpError(w, r, "Too many requests. Please try again later!!", http.StatusTooManyRequests)
 			s.globalLimiter.mu.Unlock()
 
 			return
@@ -156,9 +367,9 @@ func (s *Server) rateLimiterLogin() middleware {
 		return func(w http.ResponseWriter, r *http.Request) {
 			s.Logger.LogAttrs(r.Context(), slog.LevelDebug, "started login rate limiter")
 
-			email := r.FormValue("email")
+			email := loginEmail(r)
 			if strings.TrimSpace(email) == "" {
-				http.Error(w, "invalid email provided", http.StatusBadRequest)
+				httpError(w, r, "invalid email provided", http.StatusBadRequest)
 				s.Logger.LogAttrs(r.Context(), slog.LevelDebug, "invalid email in rate limiter login")
 
 				return
@@ -188,7 +399,7 @@ func (s *Server) rateLimiterLogin() middleware {
 				s.Logger.LogAttrs(r.Context(), slog.LevelDebug, "attempt count exceeded",
 					slog.Int("count", attempt.count), slog.Int("max attempt", s.loginLimiter.maxAttempts))
 
-				http.Error(w, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
+				httpError(w, r, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
 
 				s.loginLimiter.mu.Unlock()
 
@@ -202,6 +413,28 @@ func (s *Server) rateLimiterLogin() middleware {
 	}
 }
 
+// loginEmail is the email a login is attempted for, posted as a form or in a JSON body which
+// is put back for the handler to read
+func loginEmail(r *http.Request) string {
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/json" {
+		return r.FormValue("email")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxLoginBody))
+	if err != nil {
+		return ""
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var req models.LoginReq
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+
+	return req.Email
+}
+
 func validateCookie(ctx context.Context, logger *slog.Logger, r *http.Request) (*uuid.UUID, error) {
 	cookie, err := r.Cookie(cookieName)
 	if err == nil {
@@ -228,46 +461,6 @@ func validateCookie(ctx context.Context, logger *slog.Logger, r *http.Request) (
 	return nil, err
 }
 
-func getSessionID(ctx context.Context, db *sqlitecloud.SQCloud, logger *slog.Logger, sessionToken *uuid.UUID) (*uuid.UUID, error) {
-	var (
-		uid uuid.UUID
-		err error
-	)
-
-	row, err := db.Select(
-		fmt.Sprintf("SELECT user_id FROM sessions WHERE token='%s';", *sessionToken),
-	)
-	if err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, err.Error())
-
-		return nil, err
-	}
-
-	if row.GetNumberOfRows() == uint64(0) {
-		logger.LogAttrs(ctx, slog.LevelError, "no valid session found, login again")
-
-		return nil, models.ErrInvalidCookie
-	}
-
-	for r := uint64(0); r < row.GetNumberOfRows(); r++ {
-		userID, err := row.GetStringValue(r, 0)
-		if err != nil {
-			logger.LogAttrs(ctx, slog.LevelError, err.Error())
-
-			return nil, err
-		}
-
-		uid, err = uuid.Parse(userID)
-		if err != nil {
-			logger.LogAttrs(ctx, slog.LevelError, err.Error())
-
-			return nil, err
-		}
-	}
-
-	return &uid, nil
-}
-
 // Extract client IP address from request (trusting RemoteAddr, no proxy handling)
 func clientIP(r *http.Request) string {
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
@@ -9,24 +9,29 @@ import (
 	"time"
 
 	"todoapp/internal/handler"
+	apihttp "todoapp/internal/handler/api"
+	listhttp "todoapp/internal/handler/list"
+	notifyhttp "todoapp/internal/handler/notify"
 	todohttp "todoapp/internal/handler/todo"
 	userhttp "todoapp/internal/handler/user"
+	"todoapp/internal/service/listsvc"
+	"todoapp/internal/service/notifysvc"
 	"todoapp/internal/service/todosvc"
 	usersvc "todoapp/internal/service/user"
-	sessionstore "todoapp/internal/store/session"
-	todostore "todoapp/internal/store/todo"
-	userstore "todoapp/internal/store/user"
 )
 
 func SetupRoutes(ctx context.Context, app *Server) {
 	setupPublicRoutes(app)
-	setupUserRoutes(app)
+	setupUserRoutes(ctx, app)
 	setupTasksRoutes(ctx, app)
+	setupListRoutes(ctx, app)
+	setupNotificationRoutes(ctx, app)
+	setupAPIRoutes(ctx, app)
 }
 
 func setupTasksRoutes(ctx context.Context, app *Server) {
-	todoStore := todostore.New(app.DB)
-	todoSvc := todosvc.New(todoStore)
+	todoSvc := todosvc.New(app.stores.todo, app.stores.tx)
+	todoSvc.MaxDepth = app.SubtaskMaxDepth
 	todoHTTP := todohttp.New(todoSvc)
 
 	app.Mux.HandleFunc("/task",
@@ -36,6 +41,10 @@ func setupTasksRoutes(ctx context.Context, app *Server) {
 	app.Mux.HandleFunc("/tasks",
 		chain(todoHTTP.HandleTasks, isHTMX(),
 			app.authMiddleware(ctx)))
+	app.Mux.HandleFunc("/tasks/search",
+		chain(todoHTTP.Search, isHTMX(), method(http.MethodGet),
+			app.authMiddleware(ctx),
+		))
 	app.Mux.HandleFunc("/tasks/{id}",
 		chain(todoHTTP.Update, isHTMX(), method(http.MethodPut),
 			app.authMiddleware(ctx),
@@ -44,21 +53,195 @@ func setupTasksRoutes(ctx context.Context, app *Server) {
 		chain(todoHTTP.DeleteTask, isHTMX(), method(http.MethodDelete),
 			app.authMiddleware(ctx),
 		))
+	app.Mux.HandleFunc("/tasks/{id}/restore",
+		chain(todoHTTP.Restore, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/history",
+		chain(todoHTTP.History, isHTMX(), method(http.MethodGet),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/revisions/{number}/restore",
+		chain(todoHTTP.RestoreRevision, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/tags",
+		chain(todoHTTP.TagTask, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/tags/{tagId}",
+		chain(todoHTTP.UntagTask, isHTMX(), method(http.MethodDelete),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/list",
+		chain(todoHTTP.MoveTask, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/parent",
+		chain(todoHTTP.SetParent, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/blockers",
+		chain(todoHTTP.AddBlocker, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/blockers/options",
+		chain(todoHTTP.BlockerOptions, isHTMX(), method(http.MethodGet),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/blockers/{blockerId}",
+		chain(todoHTTP.RemoveBlocker, isHTMX(), method(http.MethodDelete),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/skip",
+		chain(todoHTTP.SkipOccurrence, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/recurrence",
+		chain(todoHTTP.EndSeries, isHTMX(), method(http.MethodDelete),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/reminders",
+		chain(todoHTTP.AddReminder, isHTMX(), method(http.MethodPost),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tasks/{id}/reminders/{reminderId}",
+		chain(todoHTTP.RemoveReminder, isHTMX(), method(http.MethodDelete),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tags",
+		chain(todoHTTP.HandleTags, isHTMX(),
+			app.authMiddleware(ctx)))
+	app.Mux.HandleFunc("/tags/{id}",
+		chain(todoHTTP.HandleTag, isHTMX(),
+			app.authMiddleware(ctx)))
+	app.Mux.HandleFunc("/trash",
+		chain(todoHTTP.Trash, isHTMX(), method(http.MethodGet),
+			app.authMiddleware(ctx),
+		))
 	app.Mux.HandleFunc("/tasks/{id}/done",
 		chain(todoHTTP.Done, isHTMX(), method(http.MethodPut),
 			app.authMiddleware(context.Background()),
 		))
+	app.Mux.HandleFunc("/tasks/{id}/status",
+		chain(todoHTTP.Status, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
 }
 
-func setupUserRoutes(app *Server) {
-	usrSt := userstore.New(app.DB)
-	sessionSt := sessionstore.New(app.DB)
-	userSvc := usersvc.New(usrSt, sessionSt)
+func setupListRoutes(ctx context.Context, app *Server) {
+	listSvc := listsvc.New(app.stores.list, app.stores.tx)
+	listHTTP := listhttp.New(listSvc)
+
+	app.Mux.HandleFunc("/lists",
+		chain(listHTTP.HandleLists, isHTMX(),
+			app.authMiddleware(ctx)))
+	app.Mux.HandleFunc("/lists/options",
+		chain(listHTTP.Options, isHTMX(), method(http.MethodGet),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/lists/{id}",
+		chain(listHTTP.HandleList, isHTMX(),
+			app.authMiddleware(ctx)))
+	app.Mux.HandleFunc("/lists/{id}/archive",
+		chain(listHTTP.HandleArchive, isHTMX(),
+			app.authMiddleware(ctx)))
+}
+
+// setupNotificationRoutes serves the in-app notifications, they are sent by RunReminders
+func setupNotificationRoutes(ctx context.Context, app *Server) {
+	notifyHTTP := notifyhttp.New(notifysvc.New(app.stores.notify, app.stores.user))
+
+	app.Mux.HandleFunc("/notifications",
+		chain(notifyHTTP.List, isHTMX(), method(http.MethodGet),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/notifications/{id}/read",
+		chain(notifyHTTP.MarkRead, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+}
+
+// setupAPIRoutes serves the JSON API, it runs on the same services as the HTML routes
+func setupAPIRoutes(ctx context.Context, app *Server) {
+	todoSvc := todosvc.New(app.stores.todo, app.stores.tx)
+	todoSvc.MaxDepth = app.SubtaskMaxDepth
+	apiHTTP := apihttp.New(todoSvc, usersvc.New(app.stores.user, app.stores.session, app.stores.tx))
+
+	const v1 = apihttp.Prefix
+
+	app.Mux.HandleFunc(v1+"/", apiHTTP.NotFound)
+	app.Mux.HandleFunc(v1+"/auth/register", chain(apiHTTP.Register, method(http.MethodPost)))
+	app.Mux.HandleFunc(v1+"/auth/login", chain(apiHTTP.Login, method(http.MethodPost), app.rateLimiterLogin()))
+	app.Mux.HandleFunc(v1+"/auth/logout",
+		chain(apiHTTP.Logout, method(http.MethodPost),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/me",
+		chain(apiHTTP.Me, method(http.MethodGet),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/me/timezone",
+		chain(apiHTTP.SetTimeZone, method(http.MethodPut),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/tokens",
+		chain(apiHTTP.HandleTokens,
+			app.apiAuthMiddleware(ctx)))
+	app.Mux.HandleFunc(v1+"/tokens/{id}",
+		chain(apiHTTP.RevokeToken, method(http.MethodDelete),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/tasks",
+		chain(apiHTTP.HandleTasks,
+			app.apiAuthMiddleware(ctx)))
+	app.Mux.HandleFunc(v1+"/tasks/search",
+		chain(apiHTTP.Search, method(http.MethodGet),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/tasks/{id}",
+		chain(apiHTTP.HandleTask,
+			app.apiAuthMiddleware(ctx)))
+	app.Mux.HandleFunc(v1+"/tasks/{id}/status",
+		chain(apiHTTP.SetStatus, method(http.MethodPut),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/tasks/{id}/restore",
+		chain(apiHTTP.Restore, method(http.MethodPut),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/tasks/{id}/tags",
+		chain(apiHTTP.TagTask, method(http.MethodPut),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/tasks/{id}/tags/{tagId}",
+		chain(apiHTTP.UntagTask, method(http.MethodDelete),
+			app.apiAuthMiddleware(ctx),
+		))
+	app.Mux.HandleFunc(v1+"/trash",
+		chain(apiHTTP.Trash, method(http.MethodGet),
+			app.apiAuthMiddleware(ctx),
+		))
+}
+
+func setupUserRoutes(ctx context.Context, app *Server) {
+	userSvc := usersvc.New(app.stores.user, app.stores.session, app.stores.tx)
 	usrHTTP := userhttp.New(userSvc)
 
 	app.Mux.HandleFunc("/register", chain(usrHTTP.Register, method(http.MethodPost)))
 	app.Mux.HandleFunc("/login", chain(usrHTTP.Login, method(http.MethodPost), app.rateLimiterLogin()))
 	app.Mux.HandleFunc("/logout", chain(usrHTTP.Logout, method(http.MethodPost)))
+	app.Mux.HandleFunc("/user/timezone",
+		chain(usrHTTP.SetTimeZone, isHTMX(), method(http.MethodPut),
+			app.authMiddleware(ctx),
+		))
+	app.Mux.HandleFunc("/tokens",
+		chain(usrHTTP.HandleTokens, isHTMX(),
+			app.authMiddleware(ctx)))
+	app.Mux.HandleFunc("/tokens/{id}",
+		chain(usrHTTP.RevokeToken, isHTMX(), method(http.MethodDelete),
+			app.authMiddleware(ctx),
+		))
 }
 
 func setupPublicRoutes(app *Server) {
@@ -81,9 +264,11 @@ func setupPublicRoutes(app *Server) {
 			app.Health.Msg = "DB is not connected"
 		}
 
-		if err := app.DB.Ping(); err != nil {
-			app.Health.DBStatus = false
-			app.Health.Msg = err.Error()
+		if app.DB != nil {
+			if err := app.DB.Ping(); err != nil {
+				app.Health.DBStatus = false
+				app.Health.Msg = err.Error()
+			}
 		}
 
 		if isServiceHealthy(r.Context(), app.Port) {
@@ -10,8 +10,12 @@ import (
 	"sync"
 	"time"
 
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+	"todoapp/internal/openapi"
+	usersvc "todoapp/internal/service/user"
+
 	"github.com/joho/godotenv"
-	"github.com/sqlitecloud/sqlitecloud-go"
 )
 
 type Configs struct {
@@ -23,6 +27,28 @@ type Configs struct {
 	WriteTimeout    int
 	IdleTimeout     int
 	MigrationMethod string
+	DBDriver        string
+	// trashed tasks older than TrashRetention are purged every TrashPurgeInterval
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+	// SubtaskMaxDepth is how many levels of subtasks nest below a top level task
+	SubtaskMaxDepth int
+	// due reminders are sent every ReminderInterval, by mail when SMTPAddr is set and to
+	// WebhookURL when it is set on top of the in-app notifications
+	ReminderInterval time.Duration
+	SMTPAddr         string
+	SMTPFrom         string
+	SMTPUsername     string
+	SMTPPassword     string
+	WebhookURL       string
+	WebhookSecret    string
+	// OpenAPISpec is the specification the requests are validated against, and in dev mode the responses
+	OpenAPISpec string
+}
+
+// IsDev is true in the dev environment, where the responses are validated against the spec too
+func (c *Configs) IsDev() bool {
+	return c.Env == "dev" || c.Env == "development"
 }
 
 type Health struct {
@@ -44,13 +70,17 @@ type limiterAttempt struct {
 }
 
 type Server struct {
-	DB            *sqlitecloud.SQCloud
+	DB            database.DB
 	Logger        *slog.Logger
 	ShutDownFxn   func(context.Context) error
 	Mux           *http.ServeMux
 	Health        *Health
 	loginLimiter  *rateLimiter
 	globalLimiter *rateLimiter
+	stores        *stores
+	// users signs in the requests made with personal access tokens
+	users *usersvc.Service
+	spec  *openapi.Spec
 	*Configs
 }
 
@@ -82,14 +112,39 @@ func configureServer() (*Server, error) {
 	s.IdleTimeout = getEnvAsInt("IDLE_TIMEOUT", 5)
 	s.MigrationMethod = getEnvOrDefault("MIGRATION_METHOD", "UP")
 
+	s.DBDriver = getEnvOrDefault("DB_DRIVER", database.DriverSQLiteCloud)
+	s.TrashRetention = time.Duration(getEnvAsInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+	s.TrashPurgeInterval = time.Duration(getEnvAsInt("TRASH_PURGE_INTERVAL_MINUTES", 60)) * time.Minute
+	s.SubtaskMaxDepth = getEnvAsInt("SUBTASK_MAX_DEPTH", models.DefaultMaxDepth)
+	s.ReminderInterval = time.Duration(getEnvAsInt("REMINDER_INTERVAL_SECONDS", 60)) * time.Second
+	s.SMTPAddr = os.Getenv("SMTP_ADDR")
+	s.SMTPFrom = getEnvOrDefault("SMTP_FROM", "todoapp@localhost")
+	s.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	s.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	s.WebhookURL = os.Getenv("WEBHOOK_URL")
+	s.WebhookSecret = os.Getenv("WEBHOOK_SECRET")
+	s.OpenAPISpec = getEnvOrDefault("OPENAPI_SPEC", "openapi/todoApi.yaml")
+
 	s.Logger = newLogger()
 
-	db, err := newDB(s.Logger)
+	spec, err := openapi.Load(s.OpenAPISpec)
 	if err != nil {
 		return nil, err
 	}
 
-	s.DB = db
+	s.spec = spec
+
+	if s.DBDriver != database.DriverMemory {
+		db, err := newDB(s.Logger, s.DBDriver)
+		if err != nil {
+			return nil, err
+		}
+
+		s.DB = db
+	}
+
+	s.stores = newStores(s.DBDriver, s.DB)
+	s.users = usersvc.New(s.stores.user, s.stores.session, s.stores.tx)
 
 	return s, nil
 }
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+	"todoapp/internal/service/listsvc"
+	"todoapp/internal/service/notifysvc"
+	"todoapp/internal/service/todosvc"
+	usersvc "todoapp/internal/service/user"
+	memstore "todoapp/internal/store/memory"
+	sessionstore "todoapp/internal/store/session"
+	todostore "todoapp/internal/store/todo"
+	userstore "todoapp/internal/store/user"
+
+	"github.com/google/uuid"
+)
+
+type sessionStorer interface {
+	usersvc.SessionStorer
+	GetUserIDByToken(ctx context.Context, token *uuid.UUID) (*uuid.UUID, error)
+}
+
+// notifyStorer keeps the reminders of the scheduler and the notifications of the in-app channel
+type notifyStorer interface {
+	notifysvc.NotifyStorer
+	AddNotification(ctx context.Context, n *models.Notification) error
+}
+
+// stores are shared by the routes and the auth middleware, so both see the same sessions
+type stores struct {
+	todo    todosvc.TodoStorer
+	list    listsvc.ListStorer
+	notify  notifyStorer
+	user    usersvc.UserStorer
+	session sessionStorer
+	tx      transactor
+}
+
+// transactor is satisfied by both database.Transactor and memstore.Transactor
+type transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+func newStores(driver string, db database.DB) *stores {
+	// the lists and reminders live with the tasks, deleting a list takes its tasks out of it
+	if driver == database.DriverMemory {
+		todo := memstore.NewTodoStore()
+
+		return &stores{
+			todo:    todo,
+			list:    todo,
+			notify:  todo,
+			user:    memstore.NewUserStore(),
+			session: memstore.NewSessionStore(),
+			tx:      memstore.NewTransactor(),
+		}
+	}
+
+	todo := todostore.New(db)
+
+	return &stores{
+		todo:    todo,
+		list:    todo,
+		notify:  todo,
+		user:    userstore.New(db),
+		session: sessionstore.New(db),
+		tx:      database.NewTransactor(db),
+	}
+}
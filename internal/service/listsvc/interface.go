@@ -0,0 +1,26 @@
+package listsvc
+
+import (
+	"context"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen --source=interface.go --destination=mock_interface.go --package=listsvc
+
+// Transactor runs fn as one unit of work, the store calls made with the ctx given to fn
+// are committed together when fn returns nil and rolled back otherwise
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type ListStorer interface {
+	CreateList(ctx context.Context, list *models.List) error
+	GetLists(ctx context.Context, archived bool, userID *uuid.UUID) ([]models.List, error)
+	GetList(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error)
+	GetListByName(ctx context.Context, name string, userID *uuid.UUID) (*models.List, error)
+	UpdateList(ctx context.Context, list *models.List) error
+	DeleteList(ctx context.Context, id string, userID *uuid.UUID) error
+}
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen --source=interface.go --destination=mock_interface.go --package=listsvc
+//
+
+// Package listsvc is a generated GoMock package.
+package listsvc
+
+import (
+	context "context"
+	reflect "reflect"
+	models "todoapp/internal/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTransactorMockRecorder) WithinTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockListStorer is a mock of ListStorer interface.
+type MockListStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockListStorerMockRecorder
+	isgomock struct{}
+}
+
+// MockListStorerMockRecorder is the mock recorder for MockListStorer.
+type MockListStorerMockRecorder struct {
+	mock *MockListStorer
+}
+
+// NewMockListStorer creates a new mock instance.
+func NewMockListStorer(ctrl *gomock.Controller) *MockListStorer {
+	mock := &MockListStorer{ctrl: ctrl}
+	mock.recorder = &MockListStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListStorer) EXPECT() *MockListStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateList mocks base method.
+func (m *MockListStorer) CreateList(ctx context.Context, list *models.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateList", ctx, list)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateList indicates an expected call of CreateList.
+func (mr *MockListStorerMockRecorder) CreateList(ctx, list any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateList", reflect.TypeOf((*MockListStorer)(nil).CreateList), ctx, list)
+}
+
+// DeleteList mocks base method.
+func (m *MockListStorer) DeleteList(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockListStorerMockRecorder) DeleteList(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockListStorer)(nil).DeleteList), ctx, id, userID)
+}
+
+// GetList mocks base method.
+func (m *MockListStorer) GetList(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, id, userID)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockListStorerMockRecorder) GetList(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockListStorer)(nil).GetList), ctx, id, userID)
+}
+
+// GetListByName mocks base method.
+func (m *MockListStorer) GetListByName(ctx context.Context, name string, userID *uuid.UUID) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListByName", ctx, name, userID)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListByName indicates an expected call of GetListByName.
+func (mr *MockListStorerMockRecorder) GetListByName(ctx, name, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByName", reflect.TypeOf((*MockListStorer)(nil).GetListByName), ctx, name, userID)
+}
+
+// GetLists mocks base method.
+func (m *MockListStorer) GetLists(ctx context.Context, archived bool, userID *uuid.UUID) ([]models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", ctx, archived, userID)
+	ret0, _ := ret[0].([]models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockListStorerMockRecorder) GetLists(ctx, archived, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockListStorer)(nil).GetLists), ctx, archived, userID)
+}
+
+// UpdateList mocks base method.
+func (m *MockListStorer) UpdateList(ctx context.Context, list *models.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", ctx, list)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockListStorerMockRecorder) UpdateList(ctx, list any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockListStorer)(nil).UpdateList), ctx, list)
+}
@@ -0,0 +1,167 @@
+package listsvc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	Store ListStorer
+	Tx    Transactor
+}
+
+func New(st ListStorer, tx Transactor) *Service {
+	return &Service{Store: st, Tx: tx}
+}
+
+// GetAll returns either the active or the archived lists of the user
+func (s *Service) GetAll(ctx context.Context, archived bool, userID *uuid.UUID) ([]models.List, error) {
+	lists, err := s.Store.GetLists(ctx, archived, userID)
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while fetching lists",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error) {
+	if err := validateListID(id); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetList(ctx, id, userID)
+}
+
+func (s *Service) Create(ctx context.Context, req *models.ListReq, userID *uuid.UUID) (*models.List, error) {
+	if err := validateList(req); err != nil {
+		return nil, err
+	}
+
+	list := models.List{
+		ID:        prefixList + uuid.NewString(),
+		UserID:    *userID,
+		Name:      req.Name,
+		Sort:      req.Sort,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.Store.GetListByName(ctx, list.Name, userID); err == nil {
+			return models.ErrListAlreadyExists
+		}
+
+		return s.Store.CreateList(ctx, &list)
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while creating list",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// Update renames a list of the user and sets the task order of its view
+func (s *Service) Update(ctx context.Context, id string, req *models.ListReq, userID *uuid.UUID) (*models.List, error) {
+	if err := validateListID(id); err != nil {
+		return nil, err
+	}
+
+	if err := validateList(req); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, id, userID, func(ctx context.Context, list *models.List) error {
+		if other, err := s.Store.GetListByName(ctx, req.Name, userID); err == nil && other.ID != id {
+			return models.ErrListAlreadyExists
+		}
+
+		list.Name, list.Sort = req.Name, req.Sort
+
+		return nil
+	})
+}
+
+// SetArchived archives a list of the user or brings it back, its tasks are left as they are
+func (s *Service) SetArchived(ctx context.Context, id string, archived bool, userID *uuid.UUID) (*models.List, error) {
+	if err := validateListID(id); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, id, userID, func(_ context.Context, list *models.List) error {
+		switch {
+		case archived && !list.IsArchived():
+			at := time.Now().UTC()
+			list.ArchivedAt = &at
+		case !archived:
+			list.ArchivedAt = nil
+		}
+
+		return nil
+	})
+}
+
+// Delete deletes a list of the user, its tasks are kept in no list
+func (s *Service) Delete(ctx context.Context, id string, userID *uuid.UUID) error {
+	if err := validateListID(id); err != nil {
+		return err
+	}
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.Store.GetList(ctx, id, userID); err != nil {
+			return err
+		}
+
+		return s.Store.DeleteList(ctx, id, userID)
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while deleting list",
+			slog.String("error", err.Error()), slog.String("list", id))
+
+		return err
+	}
+
+	return nil
+}
+
+// update reads the list, lets change modify it and writes it back in one transaction, change
+// gets the transaction's ctx
+func (s *Service) update(ctx context.Context, id string, userID *uuid.UUID,
+	change func(ctx context.Context, list *models.List) error,
+) (*models.List, error) {
+	var list *models.List
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		if list, err = s.Store.GetList(ctx, id, userID); err != nil {
+			return err
+		}
+
+		if err := change(ctx, list); err != nil {
+			return err
+		}
+
+		mt := time.Now().UTC()
+		list.ModifiedAt = &mt
+
+		return s.Store.UpdateList(ctx, list)
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while updating list",
+			slog.String("error", err.Error()), slog.String("list", id))
+
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,48 @@
+package listsvc
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const prefixList = "list-"
+
+func validateListID(id string) error {
+	uid, err := uuid.Parse(strings.TrimPrefix(id, prefixList))
+	if !strings.HasPrefix(id, prefixList) || err != nil || uid == uuid.Nil {
+		return models.ErrInvalid("list id")
+	}
+
+	return nil
+}
+
+// validateList trims the name and normalizes the sort, an empty sort keeps the default task order
+func validateList(req *models.ListReq) error {
+	req.Name = strings.TrimSpace(req.Name)
+
+	switch {
+	case req.Name == "":
+		return models.ErrRequired("list name")
+	case utf8.RuneCountInString(req.Name) > models.MaxListName:
+		return models.ErrInvalid("list name")
+	}
+
+	if strings.TrimSpace(req.Sort) == "" {
+		req.Sort = ""
+
+		return nil
+	}
+
+	keys, err := models.ParseSort(req.Sort)
+	if err != nil {
+		return err
+	}
+
+	req.Sort = models.FormatSort(keys)
+
+	return nil
+}
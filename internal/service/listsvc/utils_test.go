@@ -0,0 +1,45 @@
+package listsvc
+
+import (
+	"strings"
+	"testing"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateList(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.ListReq
+		want    models.ListReq
+		wantErr error
+	}{
+		{name: "default order", req: models.ListReq{Name: "  work ", Sort: " "}, want: models.ListReq{Name: "work"}},
+		{name: "sort", req: models.ListReq{Name: "work", Sort: "-priority, due"},
+			want: models.ListReq{Name: "work", Sort: "-priority,due"}},
+		{name: "missing name", req: models.ListReq{Name: " "}, wantErr: models.ErrRequired("list name")},
+		{name: "long name", req: models.ListReq{Name: strings.Repeat("é", models.MaxListName+1)},
+			wantErr: models.ErrInvalid("list name")},
+		{name: "invalid sort", req: models.ListReq{Name: "work", Sort: "color"}, wantErr: models.ErrInvalid("sort")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateList(&tt.req)
+			assert.Equal(t, tt.wantErr, err)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, tt.req)
+			}
+		})
+	}
+}
+
+func TestValidateListID(t *testing.T) {
+	assert.NoError(t, validateListID(prefixList+uuid.NewString()))
+	assert.Equal(t, models.ErrInvalid("list id"), validateListID(uuid.NewString()))
+	assert.Equal(t, models.ErrInvalid("list id"), validateListID(prefixList+uuid.Nil.String()))
+}
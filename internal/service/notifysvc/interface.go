@@ -0,0 +1,28 @@
+package notifysvc
+
+import (
+	"context"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen --source=interface.go --destination=mock_interface.go --package=notifysvc
+
+type NotifyStorer interface {
+	DueReminders(ctx context.Context, now time.Time, limit int) ([]models.DueReminder, error)
+	MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error)
+	ListNotifications(ctx context.Context, limit int, userID *uuid.UUID) ([]models.Notification, error)
+	ReadNotification(ctx context.Context, id string, readAt time.Time, userID *uuid.UUID) error
+}
+
+type UserGetter interface {
+	GetUserByID(ctx context.Context, id *uuid.UUID) (*models.UserData, error)
+}
+
+// Notifier delivers a notification to a user through one channel, like mail or a webhook
+type Notifier interface {
+	Notify(ctx context.Context, to *models.UserData, n *models.Notification) error
+}
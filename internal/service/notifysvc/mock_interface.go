@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen --source=interface.go --destination=mock_interface.go --package=notifysvc
+//
+
+// Package notifysvc is a generated GoMock package.
+package notifysvc
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "todoapp/internal/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotifyStorer is a mock of NotifyStorer interface.
+type MockNotifyStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifyStorerMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifyStorerMockRecorder is the mock recorder for MockNotifyStorer.
+type MockNotifyStorerMockRecorder struct {
+	mock *MockNotifyStorer
+}
+
+// NewMockNotifyStorer creates a new mock instance.
+func NewMockNotifyStorer(ctrl *gomock.Controller) *MockNotifyStorer {
+	mock := &MockNotifyStorer{ctrl: ctrl}
+	mock.recorder = &MockNotifyStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifyStorer) EXPECT() *MockNotifyStorerMockRecorder {
+	return m.recorder
+}
+
+// DueReminders mocks base method.
+func (m *MockNotifyStorer) DueReminders(ctx context.Context, now time.Time, limit int) ([]models.DueReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueReminders", ctx, now, limit)
+	ret0, _ := ret[0].([]models.DueReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueReminders indicates an expected call of DueReminders.
+func (mr *MockNotifyStorerMockRecorder) DueReminders(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueReminders", reflect.TypeOf((*MockNotifyStorer)(nil).DueReminders), ctx, now, limit)
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotifyStorer) ListNotifications(ctx context.Context, limit int, userID *uuid.UUID) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, limit, userID)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotifyStorerMockRecorder) ListNotifications(ctx, limit, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotifyStorer)(nil).ListNotifications), ctx, limit, userID)
+}
+
+// MarkReminderSent mocks base method.
+func (m *MockNotifyStorer) MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", ctx, id, sentAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockNotifyStorerMockRecorder) MarkReminderSent(ctx, id, sentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockNotifyStorer)(nil).MarkReminderSent), ctx, id, sentAt)
+}
+
+// ReadNotification mocks base method.
+func (m *MockNotifyStorer) ReadNotification(ctx context.Context, id string, readAt time.Time, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadNotification", ctx, id, readAt, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadNotification indicates an expected call of ReadNotification.
+func (mr *MockNotifyStorerMockRecorder) ReadNotification(ctx, id, readAt, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNotification", reflect.TypeOf((*MockNotifyStorer)(nil).ReadNotification), ctx, id, readAt, userID)
+}
+
+// MockUserGetter is a mock of UserGetter interface.
+type MockUserGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockUserGetterMockRecorder is the mock recorder for MockUserGetter.
+type MockUserGetterMockRecorder struct {
+	mock *MockUserGetter
+}
+
+// NewMockUserGetter creates a new mock instance.
+func NewMockUserGetter(ctrl *gomock.Controller) *MockUserGetter {
+	mock := &MockUserGetter{ctrl: ctrl}
+	mock.recorder = &MockUserGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserGetter) EXPECT() *MockUserGetterMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserGetter) GetUserByID(ctx context.Context, id *uuid.UUID) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserGetterMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserGetter)(nil).GetUserByID), ctx, id)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(ctx context.Context, to *models.UserData, n *models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, to, n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(ctx, to, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), ctx, to, n)
+}
@@ -0,0 +1,170 @@
+package notifysvc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	prefixNotification = "ntf-"
+	// batchSize is how many due reminders are fetched at once
+	batchSize = 100
+	// listSize is how many of the latest notifications are listed
+	listSize = 50
+)
+
+// Service fires the due reminders through every notifier and serves the in-app notifications
+type Service struct {
+	Store     NotifyStorer
+	Users     UserGetter
+	Notifiers []Notifier
+}
+
+func New(st NotifyStorer, users UserGetter, notifiers ...Notifier) *Service {
+	return &Service{Store: st, Users: users, Notifiers: notifiers}
+}
+
+// SendReminders notifies the users of every reminder due at now and returns how many were sent.
+// A reminder is marked sent before it is delivered, a failed delivery is logged and not tried again.
+func (s *Service) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+	users := make(map[uuid.UUID]*models.UserData)
+	sent := 0
+
+	for {
+		due, err := s.Store.DueReminders(ctx, now, batchSize)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "error while fetching due reminders", slog.String("error", err.Error()))
+
+			return sent, err
+		}
+
+		for i := range due {
+			ok, err := s.send(ctx, &due[i], users, now)
+			if err != nil {
+				return sent, err
+			}
+
+			if ok {
+				sent++
+			}
+		}
+
+		if len(due) < batchSize {
+			break
+		}
+	}
+
+	if sent > 0 {
+		logger.LogAttrs(ctx, slog.LevelInfo, "reminders sent", slog.Int("reminders", sent))
+	}
+
+	return sent, nil
+}
+
+// send delivers one due reminder, it is false when another run sent it first
+func (s *Service) send(ctx context.Context, due *models.DueReminder, users map[uuid.UUID]*models.UserData, now time.Time,
+) (bool, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	marked, err := s.Store.MarkReminderSent(ctx, due.ID, now)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while marking reminder sent",
+			slog.String("error", err.Error()), slog.String("reminder", due.ID))
+
+		return false, err
+	}
+
+	if !marked {
+		return false, nil
+	}
+
+	user, ok := users[due.UserID]
+	if !ok {
+		if user, err = s.Users.GetUserByID(ctx, &due.UserID); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "error while fetching reminded user",
+				slog.String("error", err.Error()), slog.String("reminder", due.ID))
+
+			return true, nil
+		}
+
+		users[due.UserID] = user
+	}
+
+	n := newNotification(due, user, now)
+	errs := make([]error, 0)
+
+	for _, notifier := range s.Notifiers {
+		if err := notifier.Notify(ctx, user, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while delivering reminder",
+			slog.String("error", err.Error()), slog.String("reminder", due.ID))
+	}
+
+	return true, nil
+}
+
+// List returns the user's latest notifications, the newest first
+func (s *Service) List(ctx context.Context, userID *uuid.UUID) ([]models.Notification, error) {
+	res, err := s.Store.ListNotifications(ctx, listSize, userID)
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while listing notifications",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// MarkRead marks a notification of the user as read
+func (s *Service) MarkRead(ctx context.Context, id string, userID *uuid.UUID) error {
+	rest, ok := strings.CutPrefix(id, prefixNotification)
+	if _, err := uuid.Parse(rest); !ok || err != nil {
+		return models.ErrInvalid("notification id")
+	}
+
+	return s.Store.ReadNotification(ctx, id, time.Now().UTC(), userID)
+}
+
+// newNotification tells user about the task of a due reminder, its due date is given in the user's time zone
+func newNotification(due *models.DueReminder, user *models.UserData, now time.Time) *models.Notification {
+	loc, err := models.LoadTimeZone(user.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	body := due.Task.Title
+
+	if tr := due.Task.ToTaskResp(loc); tr.DueDate != nil {
+		body += " is due on " + *tr.DueDate
+
+		if tr.DueTime != "" {
+			body += " at " + tr.DueTime
+		}
+	}
+
+	if due.Task.Description != "" {
+		body += "\n\n" + due.Task.Description
+	}
+
+	return &models.Notification{
+		ID:        prefixNotification + uuid.NewString(),
+		UserID:    user.ID,
+		TaskID:    due.TaskID,
+		Title:     "Reminder: " + due.Task.Title,
+		Body:      body,
+		CreatedAt: now,
+	}
+}
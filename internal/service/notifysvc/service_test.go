@@ -0,0 +1,73 @@
+package notifysvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSendReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock, usersMock := NewMockNotifyStorer(ctrl), NewMockUserGetter(ctrl)
+	mailMock, hookMock := NewMockNotifier(ctrl), NewMockNotifier(ctrl)
+	s := New(storeMock, usersMock, mailMock, hookMock)
+
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	user := &models.UserData{ID: uuid.New(), Email: "alice@example.com", TimeZone: "Europe/Paris"}
+	task := models.Task{ID: "task-a", UserID: user.ID, Title: "Standup", DueDate: &due, HasDueTime: true}
+
+	storeMock.EXPECT().DueReminders(gomock.Any(), now, batchSize).Return([]models.DueReminder{
+		{Reminder: models.Reminder{ID: "rem-a", TaskID: task.ID, UserID: user.ID, Before: 2 * time.Hour}, Task: task},
+		{Reminder: models.Reminder{ID: "rem-b", TaskID: task.ID, UserID: user.ID, Before: time.Hour}, Task: task},
+		{Reminder: models.Reminder{ID: "rem-c", TaskID: task.ID, UserID: user.ID, Before: 90 * time.Minute}, Task: task},
+	}, nil)
+	storeMock.EXPECT().MarkReminderSent(gomock.Any(), "rem-a", now).Return(true, nil)
+	// sent by another run in the meantime
+	storeMock.EXPECT().MarkReminderSent(gomock.Any(), "rem-b", now).Return(false, nil)
+	storeMock.EXPECT().MarkReminderSent(gomock.Any(), "rem-c", now).Return(true, nil)
+	usersMock.EXPECT().GetUserByID(gomock.Any(), &user.ID).Return(user, nil)
+
+	var got []*models.Notification
+
+	mailMock.EXPECT().Notify(gomock.Any(), user, gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, _ *models.UserData, n *models.Notification) error {
+			got = append(got, n)
+
+			return nil
+		})
+	// a failing channel doesn't stop the others
+	hookMock.EXPECT().Notify(gomock.Any(), user, gomock.Any()).Times(2).Return(errors.New("webhook down"))
+
+	sent, err := s.SendReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Reminder: Standup", got[0].Title)
+	assert.Equal(t, "Standup is due on 2026-10-18 at 11:30", got[0].Body)
+	assert.Equal(t, task.ID, got[0].TaskID)
+}
+
+func TestMarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockNotifyStorer(ctrl)
+	s := New(storeMock, NewMockUserGetter(ctrl))
+	userID, id := uuid.New(), prefixNotification+uuid.NewString()
+
+	storeMock.EXPECT().ReadNotification(gomock.Any(), id, gomock.Any(), &userID).Return(nil)
+
+	require.NoError(t, s.MarkRead(context.Background(), id, &userID))
+	assert.Equal(t, models.ErrInvalid("notification id"), s.MarkRead(context.Background(), "rem-"+uuid.NewString(), &userID))
+}
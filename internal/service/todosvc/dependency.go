@@ -0,0 +1,144 @@
+package todosvc
+
+import (
+	"context"
+	"log/slog"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AddBlocker makes a task of the user wait for the task blockerID, it is refused when the blocker
+// already waits for the task, directly or through other tasks
+func (s *Service) AddBlocker(ctx context.Context, id, blockerID string, userID *uuid.UUID) (*models.Task, error) {
+	dep, err := validateDependency(id, blockerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changeDependency(ctx, dep, userID, func(ctx context.Context) error {
+		// two requests adding the opposite dependencies must not both pass the check
+		if err := s.Store.LockDependencies(ctx, userID); err != nil {
+			return err
+		}
+
+		deps, err := s.Store.ListDependencies(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if waitsFor(deps, blockerID, id) {
+			return models.ErrDependencyCycle
+		}
+
+		return s.Store.AddDependency(ctx, dep)
+	})
+}
+
+// RemoveBlocker stops a task of the user from waiting for the task blockerID
+func (s *Service) RemoveBlocker(ctx context.Context, id, blockerID string, userID *uuid.UUID) (*models.Task, error) {
+	dep, err := validateDependency(id, blockerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changeDependency(ctx, dep, userID, func(ctx context.Context) error {
+		return s.Store.RemoveDependency(ctx, dep)
+	})
+}
+
+// changeDependency runs change once both tasks of dep are known to belong to the user and
+// returns the waiting task as changed
+func (s *Service) changeDependency(ctx context.Context, dep *models.Dependency, userID *uuid.UUID,
+	change func(ctx context.Context) error,
+) (*models.Task, error) {
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, id := range []string{dep.TaskID, dep.BlockerID} {
+			if _, err := s.Store.Get(ctx, id, userID); err != nil {
+				return err
+			}
+		}
+
+		if err := change(ctx); err != nil {
+			return err
+		}
+
+		var err error
+
+		task, err = s.Store.Get(ctx, dep.TaskID, userID)
+
+		return err
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while changing task dependency",
+			slog.String("error", err.Error()),
+			slog.String("task", dep.TaskID),
+			slog.String("blocker", dep.BlockerID),
+		)
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// canMove tells why task can't be moved to next, a task with open blockers is only done when forced
+func canMove(task *models.Task, next models.TaskStatus, force bool) error {
+	if !task.Status.CanMoveTo(next) {
+		return models.ErrStatusTransition
+	}
+
+	if next == models.StatusDone && !force && len(task.BlockedBy.Open()) > 0 {
+		return models.ErrTaskBlocked
+	}
+
+	return nil
+}
+
+func validateDependency(id, blockerID string) (*models.Dependency, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	if err := validatePrefixedID(blockerID, prefixTask, "blocker id"); err != nil {
+		return nil, err
+	}
+
+	if id == blockerID {
+		return nil, models.ErrDependencyCycle
+	}
+
+	return &models.Dependency{TaskID: id, BlockerID: blockerID}, nil
+}
+
+// waitsFor reports whether the task from waits for target, directly or through other tasks
+func waitsFor(deps []models.Dependency, from, target string) bool {
+	blockers := make(map[string][]string)
+	for _, dep := range deps {
+		blockers[dep.TaskID] = append(blockers[dep.TaskID], dep.BlockerID)
+	}
+
+	seen := map[string]bool{from: true}
+	next := []string{from}
+
+	for len(next) > 0 {
+		id := next[len(next)-1]
+		next = next[:len(next)-1]
+
+		for _, blockerID := range blockers[id] {
+			if blockerID == target {
+				return true
+			}
+
+			if !seen[blockerID] {
+				seen[blockerID] = true
+				next = append(next, blockerID)
+			}
+		}
+	}
+
+	return false
+}
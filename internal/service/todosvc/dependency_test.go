@@ -0,0 +1,38 @@
+package todosvc
+
+import (
+	"testing"
+
+	"todoapp/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitsFor(t *testing.T) {
+	// release waits for test, test for build and docs
+	deps := []models.Dependency{
+		{TaskID: "task-release", BlockerID: "task-test"},
+		{TaskID: "task-test", BlockerID: "task-build"},
+		{TaskID: "task-test", BlockerID: "task-docs"},
+		{TaskID: "task-docs", BlockerID: "task-build"},
+	}
+
+	tests := []struct {
+		name   string
+		from   string
+		target string
+		want   bool
+	}{
+		{name: "direct blocker", from: "task-release", target: "task-test", want: true},
+		{name: "through other tasks", from: "task-release", target: "task-build", want: true},
+		{name: "waiting task", from: "task-build", target: "task-release"},
+		{name: "unrelated tasks", from: "task-docs", target: "task-test"},
+		{name: "no dependencies", from: "task-other", target: "task-build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, waitsFor(deps, tt.from, tt.target))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package todosvc
 
 import (
 	"context"
+	"time"
 
 	"todoapp/internal/models"
 
@@ -9,10 +10,41 @@ import (
 )
 
 //go:generate mockgen --source=interface.go --destination=mock_interface.go --package=todosvc
+
+// Transactor runs fn as one unit of work, the store calls made with the ctx given to fn
+// are committed together when fn returns nil and rolled back otherwise
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type TodoStorer interface {
-	GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, error)
+	GetAll(ctx context.Context, q *models.TaskQuery, userID *uuid.UUID) ([]models.Task, error)
 	Create(ctx context.Context, task *models.Task) error
 	Update(ctx context.Context, task *models.Task) error
-	Delete(ctx context.Context, id string, userID *uuid.UUID) error
-	MarkDone(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error
+	Delete(ctx context.Context, id string, version int64, userID *uuid.UUID) error
+	Search(ctx context.Context, terms []string, limit int, userID *uuid.UUID) ([]models.SearchResult, error)
+	ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error)
+	Restore(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	Get(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	AddRevision(ctx context.Context, rev *models.Revision) error
+	ListRevisions(ctx context.Context, taskID string) ([]models.Revision, error)
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	ListTags(ctx context.Context, userID *uuid.UUID) ([]models.Tag, error)
+	GetTag(ctx context.Context, id string, userID *uuid.UUID) (*models.Tag, error)
+	GetTagByName(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error)
+	RenameTag(ctx context.Context, tag *models.Tag) error
+	DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error
+	TagTask(ctx context.Context, taskID, tagID string) error
+	UntagTask(ctx context.Context, taskID, tagID string) error
+	GetList(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error)
+	GetChildren(ctx context.Context, parentIDs []string, userID *uuid.UUID) ([]models.Task, error)
+	AddDependency(ctx context.Context, dep *models.Dependency) error
+	RemoveDependency(ctx context.Context, dep *models.Dependency) error
+	ListDependencies(ctx context.Context, userID *uuid.UUID) ([]models.Dependency, error)
+	LockDependencies(ctx context.Context, userID *uuid.UUID) error
+	AddReminder(ctx context.Context, rem *models.Reminder) error
+	RemoveReminder(ctx context.Context, rem *models.Reminder) error
+	ResetReminders(ctx context.Context, taskID string, now time.Time) error
 }
@@ -0,0 +1,95 @@
+package todosvc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MoveTask moves a task of the user and its subtasks to the list listID, an empty listID takes them out
+// of their list. A subtask is kept in the list of its parent, it only moves along with it.
+func (s *Service) MoveTask(ctx context.Context, id, listID string, userID *uuid.UUID) (*models.Task, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	if listID != "" {
+		if err := validateListID(listID); err != nil {
+			return nil, err
+		}
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if current.ParentID != "" {
+			return models.ErrSubtaskList
+		}
+
+		if listID != "" {
+			if _, err := s.openList(ctx, listID, userID); err != nil {
+				return err
+			}
+		}
+
+		changed := *current
+		mt := time.Now().UTC()
+		changed.ListID = listID
+		changed.ModifiedAt = &mt
+
+		if err := s.moveSubtasks(ctx, &changed, userID); err != nil {
+			return err
+		}
+
+		task, err = s.save(ctx, current, &changed, userID)
+
+		return err
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while moving task",
+			slog.String("error", err.Error()),
+			slog.String("task", id),
+			slog.String("list", listID),
+		)
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// openList returns a list of the user that can take new tasks
+func (s *Service) openList(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error) {
+	list, err := s.Store.GetList(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.IsArchived() {
+		return nil, models.ErrListArchived
+	}
+
+	return list, nil
+}
+
+// viewedList returns the list whose tasks req lists, nil when it lists the tasks of every list or of none
+func (s *Service) viewedList(ctx context.Context, req *models.TaskListReq, userID *uuid.UUID) (*models.List, error) {
+	if req == nil || req.List == "" || req.List == models.InboxList {
+		return nil, nil
+	}
+
+	if err := validateListID(req.List); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetList(ctx, req.List, userID)
+}
@@ -0,0 +1,71 @@
+package todosvc
+
+import (
+	"context"
+	"testing"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMoveTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	txMock := NewMockTransactor(ctrl)
+	s := New(storeMock, txMock)
+	userID := uuid.New()
+	a, b, c, home := generateID(), generateID(), generateID(), prefixList+uuid.New().String()
+
+	// a > b > c, c is already in the list
+	tasks := map[string]models.Task{
+		a: {ID: a, UserID: userID, Title: "Move"},
+		b: {ID: b, UserID: userID, Title: "Pack", ParentID: a},
+		c: {ID: c, UserID: userID, Title: "Boxes", ParentID: b, ListID: home},
+	}
+
+	txMock.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	storeMock.EXPECT().Get(gomock.Any(), gomock.Any(), &userID).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string, _ *uuid.UUID) (*models.Task, error) {
+			task := tasks[id]
+
+			return &task, nil
+		})
+	storeMock.EXPECT().GetList(gomock.Any(), home, &userID).AnyTimes().
+		Return(&models.List{ID: home, UserID: userID, Name: "home"}, nil)
+	storeMock.EXPECT().GetChildren(gomock.Any(), gomock.Any(), &userID).AnyTimes().
+		DoAndReturn(func(_ context.Context, ids []string, _ *uuid.UUID) ([]models.Task, error) {
+			var children []models.Task
+
+			for _, task := range tasks {
+				if len(ids) == 1 && task.ParentID == ids[0] {
+					children = append(children, task)
+				}
+			}
+
+			return children, nil
+		})
+	// b is moved along, then a itself
+	storeMock.EXPECT().Update(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, task *models.Task) error {
+			tasks[task.ID] = *task
+
+			return nil
+		})
+
+	// a subtask only moves along with its parent
+	_, err := s.MoveTask(context.Background(), b, home, &userID)
+	assert.Equal(t, models.ErrSubtaskList, err)
+
+	moved, err := s.MoveTask(context.Background(), a, home, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, home, moved.ListID)
+	assert.Equal(t, home, tasks[b].ListID)
+	assert.Equal(t, home, tasks[c].ListID)
+}
@@ -12,12 +12,51 @@ package todosvc
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	models "todoapp/internal/models"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTransactorMockRecorder) WithinTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTransactor)(nil).WithinTx), ctx, fn)
+}
+
 // MockTodoStorer is a mock of TodoStorer interface.
 type MockTodoStorer struct {
 	ctrl     *gomock.Controller
@@ -42,6 +81,48 @@ func (m *MockTodoStorer) EXPECT() *MockTodoStorerMockRecorder {
 	return m.recorder
 }
 
+// AddDependency mocks base method.
+func (m *MockTodoStorer) AddDependency(ctx context.Context, dep *models.Dependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, dep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockTodoStorerMockRecorder) AddDependency(ctx, dep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockTodoStorer)(nil).AddDependency), ctx, dep)
+}
+
+// AddReminder mocks base method.
+func (m *MockTodoStorer) AddReminder(ctx context.Context, rem *models.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReminder", ctx, rem)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReminder indicates an expected call of AddReminder.
+func (mr *MockTodoStorerMockRecorder) AddReminder(ctx, rem any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminder", reflect.TypeOf((*MockTodoStorer)(nil).AddReminder), ctx, rem)
+}
+
+// AddRevision mocks base method.
+func (m *MockTodoStorer) AddRevision(ctx context.Context, rev *models.Revision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRevision", ctx, rev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRevision indicates an expected call of AddRevision.
+func (mr *MockTodoStorerMockRecorder) AddRevision(ctx, rev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRevision", reflect.TypeOf((*MockTodoStorer)(nil).AddRevision), ctx, rev)
+}
+
 // Create mocks base method.
 func (m *MockTodoStorer) Create(ctx context.Context, task *models.Task) error {
 	m.ctrl.T.Helper()
@@ -56,48 +137,353 @@ func (mr *MockTodoStorerMockRecorder) Create(ctx, task any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoStorer)(nil).Create), ctx, task)
 }
 
+// CreateTag mocks base method.
+func (m *MockTodoStorer) CreateTag(ctx context.Context, tag *models.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTodoStorerMockRecorder) CreateTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTodoStorer)(nil).CreateTag), ctx, tag)
+}
+
 // Delete mocks base method.
-func (m *MockTodoStorer) Delete(ctx context.Context, id string, userID *uuid.UUID) error {
+func (m *MockTodoStorer) Delete(ctx context.Context, id string, version int64, userID *uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoStorerMockRecorder) Delete(ctx, id, userID any) *gomock.Call {
+func (mr *MockTodoStorerMockRecorder) Delete(ctx, id, version, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoStorer)(nil).Delete), ctx, id, version, userID)
+}
+
+// DeleteTag mocks base method.
+func (m *MockTodoStorer) DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTodoStorerMockRecorder) DeleteTag(ctx, id, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoStorer)(nil).Delete), ctx, id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTodoStorer)(nil).DeleteTag), ctx, id, userID)
+}
+
+// Get mocks base method.
+func (m *MockTodoStorer) Get(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTodoStorerMockRecorder) Get(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTodoStorer)(nil).Get), ctx, id, userID)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoStorer) GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+func (m *MockTodoStorer) GetAll(ctx context.Context, q *models.TaskQuery, userID *uuid.UUID) ([]models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret := m.ctrl.Call(m, "GetAll", ctx, q, userID)
 	ret0, _ := ret[0].([]models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoStorerMockRecorder) GetAll(ctx, userID any) *gomock.Call {
+func (mr *MockTodoStorerMockRecorder) GetAll(ctx, q, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoStorer)(nil).GetAll), ctx, q, userID)
+}
+
+// GetChildren mocks base method.
+func (m *MockTodoStorer) GetChildren(ctx context.Context, parentIDs []string, userID *uuid.UUID) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", ctx, parentIDs, userID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockTodoStorerMockRecorder) GetChildren(ctx, parentIDs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockTodoStorer)(nil).GetChildren), ctx, parentIDs, userID)
+}
+
+// GetList mocks base method.
+func (m *MockTodoStorer) GetList(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, id, userID)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockTodoStorerMockRecorder) GetList(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockTodoStorer)(nil).GetList), ctx, id, userID)
+}
+
+// GetTag mocks base method.
+func (m *MockTodoStorer) GetTag(ctx context.Context, id string, userID *uuid.UUID) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTag", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTag indicates an expected call of GetTag.
+func (mr *MockTodoStorerMockRecorder) GetTag(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*MockTodoStorer)(nil).GetTag), ctx, id, userID)
+}
+
+// GetTagByName mocks base method.
+func (m *MockTodoStorer) GetTagByName(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagByName", ctx, name, userID)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagByName indicates an expected call of GetTagByName.
+func (mr *MockTodoStorerMockRecorder) GetTagByName(ctx, name, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagByName", reflect.TypeOf((*MockTodoStorer)(nil).GetTagByName), ctx, name, userID)
+}
+
+// ListDependencies mocks base method.
+func (m *MockTodoStorer) ListDependencies(ctx context.Context, userID *uuid.UUID) ([]models.Dependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependencies", ctx, userID)
+	ret0, _ := ret[0].([]models.Dependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependencies indicates an expected call of ListDependencies.
+func (mr *MockTodoStorerMockRecorder) ListDependencies(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependencies", reflect.TypeOf((*MockTodoStorer)(nil).ListDependencies), ctx, userID)
+}
+
+// ListRevisions mocks base method.
+func (m *MockTodoStorer) ListRevisions(ctx context.Context, taskID string) ([]models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, taskID)
+	ret0, _ := ret[0].([]models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockTodoStorerMockRecorder) ListRevisions(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockTodoStorer)(nil).ListRevisions), ctx, taskID)
+}
+
+// ListTags mocks base method.
+func (m *MockTodoStorer) ListTags(ctx context.Context, userID *uuid.UUID) ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, userID)
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockTodoStorerMockRecorder) ListTags(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockTodoStorer)(nil).ListTags), ctx, userID)
+}
+
+// ListTrash mocks base method.
+func (m *MockTodoStorer) ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, userID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockTodoStorerMockRecorder) ListTrash(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockTodoStorer)(nil).ListTrash), ctx, userID)
+}
+
+// LockDependencies mocks base method.
+func (m *MockTodoStorer) LockDependencies(ctx context.Context, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDependencies", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockDependencies indicates an expected call of LockDependencies.
+func (mr *MockTodoStorerMockRecorder) LockDependencies(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDependencies", reflect.TypeOf((*MockTodoStorer)(nil).LockDependencies), ctx, userID)
+}
+
+// Patch mocks base method.
+func (m *MockTodoStorer) Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, task, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockTodoStorerMockRecorder) Patch(ctx, task, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockTodoStorer)(nil).Patch), ctx, task, fields)
+}
+
+// Purge mocks base method.
+func (m *MockTodoStorer) Purge(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTodoStorerMockRecorder) Purge(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTodoStorer)(nil).Purge), ctx, before)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockTodoStorer) RemoveDependency(ctx context.Context, dep *models.Dependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, dep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockTodoStorerMockRecorder) RemoveDependency(ctx, dep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockTodoStorer)(nil).RemoveDependency), ctx, dep)
+}
+
+// RemoveReminder mocks base method.
+func (m *MockTodoStorer) RemoveReminder(ctx context.Context, rem *models.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReminder", ctx, rem)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReminder indicates an expected call of RemoveReminder.
+func (mr *MockTodoStorerMockRecorder) RemoveReminder(ctx, rem any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReminder", reflect.TypeOf((*MockTodoStorer)(nil).RemoveReminder), ctx, rem)
+}
+
+// RenameTag mocks base method.
+func (m *MockTodoStorer) RenameTag(ctx context.Context, tag *models.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockTodoStorerMockRecorder) RenameTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockTodoStorer)(nil).RenameTag), ctx, tag)
+}
+
+// ResetReminders mocks base method.
+func (m *MockTodoStorer) ResetReminders(ctx context.Context, taskID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetReminders", ctx, taskID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetReminders indicates an expected call of ResetReminders.
+func (mr *MockTodoStorerMockRecorder) ResetReminders(ctx, taskID, now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoStorer)(nil).GetAll), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetReminders", reflect.TypeOf((*MockTodoStorer)(nil).ResetReminders), ctx, taskID, now)
 }
 
-// MarkDone mocks base method.
-func (m *MockTodoStorer) MarkDone(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+// Restore mocks base method.
+func (m *MockTodoStorer) Restore(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkDone", ctx, id, userID)
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userID)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MarkDone indicates an expected call of MarkDone.
-func (mr *MockTodoStorerMockRecorder) MarkDone(ctx, id, userID any) *gomock.Call {
+// Restore indicates an expected call of Restore.
+func (mr *MockTodoStorerMockRecorder) Restore(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoStorer)(nil).Restore), ctx, id, userID)
+}
+
+// Search mocks base method.
+func (m *MockTodoStorer) Search(ctx context.Context, terms []string, limit int, userID *uuid.UUID) ([]models.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, terms, limit, userID)
+	ret0, _ := ret[0].([]models.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoStorerMockRecorder) Search(ctx, terms, limit, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoStorer)(nil).Search), ctx, terms, limit, userID)
+}
+
+// TagTask mocks base method.
+func (m *MockTodoStorer) TagTask(ctx context.Context, taskID, tagID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagTask", ctx, taskID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagTask indicates an expected call of TagTask.
+func (mr *MockTodoStorerMockRecorder) TagTask(ctx, taskID, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagTask", reflect.TypeOf((*MockTodoStorer)(nil).TagTask), ctx, taskID, tagID)
+}
+
+// UntagTask mocks base method.
+func (m *MockTodoStorer) UntagTask(ctx context.Context, taskID, tagID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagTask", ctx, taskID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagTask indicates an expected call of UntagTask.
+func (mr *MockTodoStorerMockRecorder) UntagTask(ctx, taskID, tagID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDone", reflect.TypeOf((*MockTodoStorer)(nil).MarkDone), ctx, id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagTask", reflect.TypeOf((*MockTodoStorer)(nil).UntagTask), ctx, taskID, tagID)
 }
 
 // Update mocks base method.
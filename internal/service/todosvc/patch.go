@@ -0,0 +1,168 @@
+package todosvc
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// PatchTask applies an RFC 7396 merge patch to a task of the user. The patched task is checked like
+// a full update and only the fields the patch changed are written, the task is returned as is when
+// it changes none. A version other than 0 has to be the one of the task.
+func (s *Service) PatchTask(ctx context.Context, id string, patch []byte, version int64, userID *uuid.UUID,
+) (*models.Task, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := current.CheckVersion(version); err != nil {
+			return err
+		}
+
+		req, err := models.PatchTask(current, patch, models.GetLocationFromCtx(ctx))
+		if err != nil {
+			return err
+		}
+
+		if err := validateTask(id, req); err != nil {
+			return err
+		}
+
+		changed, fields := patched(ctx, current, req)
+		if len(fields) == 0 {
+			task = current
+			return nil
+		}
+
+		if fields, err = s.checkMove(ctx, current, changed, fields, userID); err != nil {
+			return err
+		}
+
+		if err := s.Store.Patch(ctx, changed, fields); err != nil {
+			return err
+		}
+
+		task, err = s.saved(ctx, current, changed, userID)
+
+		return err
+	})
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while patching task",
+			slog.String("error", err.Error()),
+			slog.String("task", id),
+		)
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// patched is the task current with the checked fields of req and the fields that differ from current
+func patched(ctx context.Context, current *models.Task, req *models.TaskReq) (*models.Task, []models.TaskField) {
+	loc := models.GetLocationFromCtx(ctx)
+	dd, _ := models.ParseDue(req.DueDate, req.DueTime, loc)
+	priority, _ := models.ParsePriority(req.Priority)
+	recurrence, _ := parseRecurrence(req.Recurrence)
+
+	changed := *current
+	fields := make([]models.TaskField, 0)
+
+	if req.Title != current.Title {
+		changed.Title = req.Title
+		fields = append(fields, models.FieldTitle)
+	}
+
+	if req.Description != current.Description {
+		changed.Description = req.Description
+		fields = append(fields, models.FieldDescription)
+	}
+
+	// the due date is compared as the client sees it, a day and maybe a time in its time zone
+	if resp := current.ToTaskResp(loc); resp.DueDate == nil || req.DueDate != *resp.DueDate || req.DueTime != resp.DueTime {
+		changed.DueDate, changed.HasDueTime = &dd, req.DueTime != ""
+		fields = append(fields, models.FieldDue)
+	}
+
+	if priority != current.Priority {
+		changed.Priority = priority
+		fields = append(fields, models.FieldPriority)
+	}
+
+	if req.ListID != current.ListID {
+		changed.ListID = req.ListID
+		fields = append(fields, models.FieldList)
+	}
+
+	if req.ParentID != current.ParentID {
+		changed.ParentID = req.ParentID
+		fields = append(fields, models.FieldParent)
+	}
+
+	if setRecurrence(&changed, recurrence); changed.Recurrence != current.Recurrence {
+		fields = append(fields, models.FieldRecurrence)
+	}
+
+	if len(fields) > 0 {
+		mt := time.Now().UTC()
+		changed.ModifiedAt = &mt
+	}
+
+	return &changed, fields
+}
+
+// checkMove checks the list and the parent a patch moves a task to like MoveTask and SetParent do, a
+// subtask is kept in the list of its parent and the subtasks of the task move along with it. It returns
+// fields along with the list when the task takes the list of a new parent.
+func (s *Service) checkMove(ctx context.Context, current, changed *models.Task, fields []models.TaskField,
+	userID *uuid.UUID,
+) ([]models.TaskField, error) {
+	if changed.ParentID != current.ParentID && changed.ParentID != "" {
+		listID := changed.ListID
+
+		if err := s.takeParentList(ctx, current, changed, userID); err != nil {
+			return nil, err
+		}
+
+		if listID != current.ListID && listID != changed.ListID {
+			return nil, models.ErrSubtaskList
+		}
+
+		if changed.ListID != current.ListID && !slices.Contains(fields, models.FieldList) {
+			fields = append(fields, models.FieldList)
+		}
+
+		return fields, nil
+	}
+
+	if changed.ListID == current.ListID {
+		return fields, nil
+	}
+
+	if changed.ParentID != "" {
+		return nil, models.ErrSubtaskList
+	}
+
+	if changed.ListID != "" {
+		if _, err := s.openList(ctx, changed.ListID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, s.moveSubtasks(ctx, changed, userID)
+}
@@ -0,0 +1,110 @@
+package todosvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPatchTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	txMock := NewMockTransactor(ctrl)
+	s := New(storeMock, txMock)
+	userID := uuid.New()
+	due := time.Date(2026, 10, 20, 23, 59, 59, 0, time.UTC)
+	task := models.Task{ID: generateID(), UserID: userID, Title: "Pay rent", DueDate: &due, Priority: models.PriorityHigh,
+		Version: 1}
+
+	txMock.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	storeMock.EXPECT().Get(gomock.Any(), task.ID, &userID).AnyTimes().
+		DoAndReturn(func(context.Context, string, *uuid.UUID) (*models.Task, error) {
+			stored := task
+
+			return &stored, nil
+		})
+
+	// a patch leaving the task as it is writes nothing
+	got, err := s.PatchTask(context.Background(), task.ID, []byte(`{"title":" Pay rent ","listId":null}`), 0, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, &task, got)
+
+	_, err = s.PatchTask(context.Background(), task.ID, []byte(`{"dueDate":null}`), 0, &userID)
+	assert.Equal(t, models.ErrRequired("due date"), err)
+
+	storeMock.EXPECT().Patch(gomock.Any(), gomock.Any(), []models.TaskField{models.FieldDue, models.FieldPriority}).
+		DoAndReturn(func(_ context.Context, changed *models.Task, _ []models.TaskField) error {
+			assert.Equal(t, time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC), *changed.DueDate)
+			assert.True(t, changed.HasDueTime)
+			assert.Equal(t, models.PriorityNone, changed.Priority)
+			assert.NotNil(t, changed.ModifiedAt)
+			task = *changed
+
+			return nil
+		})
+	storeMock.EXPECT().ResetReminders(gomock.Any(), task.ID, gomock.Any()).Return(nil)
+	storeMock.EXPECT().AddRevision(gomock.Any(), gomock.Any()).Return(nil)
+
+	patch := []byte(`{"dueDate":"2026-10-21","dueTime":"09:00","priority":null}`)
+	// the patch is made from the version read
+	_, err = s.PatchTask(context.Background(), task.ID, patch, 2, &userID)
+	assert.Equal(t, models.ErrVersionMismatch, err)
+
+	_, err = s.PatchTask(context.Background(), task.ID, patch, 1, &userID)
+	require.NoError(t, err)
+}
+
+// TestPatchTaskMove keeps a patched subtask in the list of its parent
+func TestPatchTaskMove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	txMock := NewMockTransactor(ctrl)
+	s := New(storeMock, txMock)
+	userID := uuid.New()
+	due := time.Date(2026, 10, 20, 23, 59, 59, 0, time.UTC)
+	a, b, x, home := generateID(), generateID(), generateID(), prefixList+uuid.New().String()
+
+	// b is a subtask of a in no list, x is in home
+	tasks := map[string]models.Task{
+		a: {ID: a, UserID: userID, Title: "Move", DueDate: &due},
+		b: {ID: b, UserID: userID, Title: "Pack", DueDate: &due, ParentID: a},
+		x: {ID: x, UserID: userID, Title: "House", DueDate: &due, ListID: home},
+	}
+
+	txMock.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	storeMock.EXPECT().Get(gomock.Any(), gomock.Any(), &userID).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string, _ *uuid.UUID) (*models.Task, error) {
+			task := tasks[id]
+
+			return &task, nil
+		})
+	storeMock.EXPECT().GetList(gomock.Any(), home, &userID).Return(&models.List{ID: home, UserID: userID, Name: "home"}, nil)
+	storeMock.EXPECT().GetChildren(gomock.Any(), gomock.Any(), &userID).AnyTimes().Return(nil, nil)
+
+	_, err := s.PatchTask(context.Background(), b, []byte(`{"listId":"`+home+`"}`), 0, &userID)
+	assert.Equal(t, models.ErrSubtaskList, err)
+
+	storeMock.EXPECT().Patch(gomock.Any(), gomock.Any(), []models.TaskField{models.FieldParent, models.FieldList}).
+		DoAndReturn(func(_ context.Context, changed *models.Task, _ []models.TaskField) error {
+			tasks[changed.ID] = *changed
+
+			return nil
+		})
+
+	got, err := s.PatchTask(context.Background(), b, []byte(`{"parentId":"`+x+`"}`), 0, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, home, got.ListID, "a new parent brings its list")
+}
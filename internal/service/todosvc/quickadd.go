@@ -0,0 +1,55 @@
+package todosvc
+
+import (
+	"context"
+	"time"
+
+	"todoapp/internal/models"
+	"todoapp/internal/quickadd"
+
+	"github.com/google/uuid"
+)
+
+// QuickAdd adds the task written on a single line like "Pay rent tomorrow 9am #home !high every month"
+// to the list listID, a line without a date is due today. The tags it names are created when missing.
+func (s *Service) QuickAdd(ctx context.Context, line, listID string, userID *uuid.UUID) (*models.Task, error) {
+	now := time.Now().In(models.GetLocationFromCtx(ctx))
+	parsed := quickadd.Parse(line, now)
+
+	req := models.TaskReq{
+		Title:      parsed.Title,
+		DueDate:    parsed.DueDate,
+		DueTime:    parsed.DueTime,
+		Priority:   parsed.Priority,
+		Recurrence: parsed.Recurrence,
+		ListID:     listID,
+	}
+
+	if req.DueDate == "" {
+		req.DueDate = now.Format(time.DateOnly)
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		task, err = s.AddTask(ctx, &req, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range parsed.Tags {
+			if task, err = s.TagTask(ctx, task.ID, tag, userID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
@@ -0,0 +1,53 @@
+package todosvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuickAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	txMock := NewMockTransactor(ctrl)
+	s := New(storeMock, txMock)
+	userID := uuid.New()
+
+	txMock.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	var added models.Task
+
+	storeMock.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, task *models.Task) error {
+			added = *task
+
+			return nil
+		})
+	storeMock.EXPECT().Get(gomock.Any(), gomock.Any(), &userID).AnyTimes().
+		DoAndReturn(func(context.Context, string, *uuid.UUID) (*models.Task, error) { return &added, nil })
+	storeMock.EXPECT().GetTagByName(gomock.Any(), "home", &userID).Times(2).Return(nil, models.ErrNotFound("tag"))
+	storeMock.EXPECT().CreateTag(gomock.Any(), gomock.Any()).Return(nil)
+	storeMock.EXPECT().TagTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	task, err := s.QuickAdd(context.Background(), "Pay rent #home !high every month", "", &userID)
+	require.NoError(t, err)
+
+	// without a date the task is due today
+	assert.Equal(t, "Pay rent", task.Title)
+	assert.Equal(t, models.EndOfDay(time.Now(), time.UTC), *added.DueDate)
+	assert.Equal(t, models.PriorityHigh, added.Priority)
+	assert.Equal(t, "FREQ=MONTHLY", added.Recurrence)
+
+	_, err = s.QuickAdd(context.Background(), "Pay rent !whenever", "", &userID)
+	assert.ErrorIs(t, err, models.ErrInvalid("priority"))
+}
@@ -0,0 +1,168 @@
+package todosvc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SkipOccurrence moves a recurring task of the user on to its next occurrence without completing it,
+// skipping the last occurrence of the series cancels the task
+func (s *Service) SkipOccurrence(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	loc := models.GetLocationFromCtx(ctx)
+
+	return s.changeSeries(ctx, id, userID, func(task *models.Task, r *models.Recurrence) {
+		if next, ok := r.Next(occurrenceDay(task, loc), task.Occurrence); ok {
+			task.DueDate = &next
+			task.Occurrence++
+
+			return
+		}
+
+		setRecurrence(task, "")
+
+		if task.Status.CanMoveTo(models.StatusCancelled) {
+			task.SetStatus(models.StatusCancelled, *task.ModifiedAt)
+		}
+	})
+}
+
+// EndSeries stops a recurring task of the user from coming back, the task itself is kept
+func (s *Service) EndSeries(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	return s.changeSeries(ctx, id, userID, func(task *models.Task, _ *models.Recurrence) {
+		setRecurrence(task, "")
+	})
+}
+
+// changeSeries applies change to a recurring task of the user and saves it
+func (s *Service) changeSeries(ctx context.Context, id string, userID *uuid.UUID,
+	change func(task *models.Task, r *models.Recurrence),
+) (*models.Task, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if current.Recurrence == "" {
+			return models.ErrNotRecurring
+		}
+
+		r, err := models.ParseRecurrence(current.Recurrence)
+		if err != nil {
+			return err
+		}
+
+		changed := *current
+		mt := time.Now().UTC()
+		changed.ModifiedAt = &mt
+		change(&changed, r)
+
+		task, err = s.save(ctx, current, &changed, userID)
+
+		return err
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while changing task series",
+			slog.String("error", err.Error()), slog.String("task", id))
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// completed runs what follows from task being done: with cascade its subtasks are done too and a
+// recurring task gets its next occurrence
+func (s *Service) completed(ctx context.Context, task *models.Task, req *models.StatusReq, userID *uuid.UUID) error {
+	if req.Cascade {
+		if err := s.completeSubtasks(ctx, task.ID, *task.CompletedAt, req.Force, userID); err != nil {
+			return err
+		}
+	}
+
+	if task.Recurrence == "" {
+		return nil
+	}
+
+	return s.recur(ctx, task)
+}
+
+// recur adds the occurrence following the completed task, the series moves on to it so reopening
+// the completed task doesn't repeat it twice
+func (s *Service) recur(ctx context.Context, done *models.Task) error {
+	r, err := models.ParseRecurrence(done.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	due, ok := r.Next(occurrenceDay(done, models.GetLocationFromCtx(ctx)), done.Occurrence)
+	if ok {
+		next := models.Task{
+			ID:          generateID(),
+			UserID:      done.UserID,
+			Title:       done.Title,
+			Description: done.Description,
+			Status:      models.StatusTodo,
+			Priority:    done.Priority,
+			ListID:      done.ListID,
+			ParentID:    done.ParentID,
+			DueDate:     &due,
+			HasDueTime:  done.HasDueTime,
+			AddedAt:     *done.CompletedAt,
+			Recurrence:  done.Recurrence,
+			Occurrence:  done.Occurrence + 1,
+		}
+
+		if err := s.Store.Create(ctx, &next); err != nil {
+			return err
+		}
+
+		for _, tag := range done.Tags {
+			if err := s.Store.TagTask(ctx, next.ID, tag.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := s.copyReminders(ctx, done, &next); err != nil {
+			return err
+		}
+	}
+
+	setRecurrence(done, "")
+
+	return nil
+}
+
+// setRecurrence sets the rule a task repeats by, a new rule starts a new series
+func setRecurrence(task *models.Task, rule string) {
+	switch {
+	case rule == "":
+		task.Recurrence, task.Occurrence = "", 0
+	case rule != task.Recurrence:
+		task.Recurrence, task.Occurrence = rule, 1
+	}
+}
+
+// occurrenceDay is when the current occurrence of task falls due in loc, the following ones are
+// counted in the days of loc. A task without a due date is taken as due the day it was completed.
+func occurrenceDay(task *models.Task, loc *time.Location) time.Time {
+	switch {
+	case task.DueDate != nil && !task.DueDate.IsZero():
+		return task.DueDate.In(loc)
+	case task.CompletedAt != nil:
+		return models.EndOfDay(*task.CompletedAt, loc)
+	default:
+		return models.EndOfDay(time.Now(), loc)
+	}
+}
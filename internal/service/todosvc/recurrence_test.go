@@ -0,0 +1,66 @@
+package todosvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecur(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	s := New(storeMock, NewMockTransactor(ctrl))
+	due := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	done := time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC)
+
+	task := models.Task{
+		ID: "task-a", UserID: uuid.New(), Title: "Standup", Status: models.StatusDone, DueDate: &due, CompletedAt: &done,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3", Occurrence: 1, Tags: []models.Tag{{ID: "tag-a"}},
+		Reminders: []models.Reminder{{ID: "rem-a", Before: time.Hour}, {ID: "rem-b", At: &due}},
+	}
+
+	var next models.Task
+
+	storeMock.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, task *models.Task) error {
+			next = *task
+
+			return nil
+		})
+	storeMock.EXPECT().TagTask(gomock.Any(), gomock.Any(), "tag-a").Return(nil)
+
+	var copied models.Reminder
+
+	// only the reminder relative to the due date moves on to the next occurrence
+	storeMock.EXPECT().AddReminder(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, rem *models.Reminder) error {
+			copied = *rem
+
+			return nil
+		})
+
+	require.NoError(t, s.recur(context.Background(), &task))
+
+	assert.Equal(t, time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC), *next.DueDate)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3", next.Recurrence)
+	assert.Equal(t, 2, next.Occurrence)
+	assert.Equal(t, models.StatusTodo, next.Status)
+	assert.Empty(t, task.Recurrence)
+	assert.Equal(t, next.ID, copied.TaskID)
+	assert.Equal(t, time.Hour, copied.Before)
+	assert.Nil(t, copied.At)
+
+	// the last occurrence of the series adds no task
+	task.Recurrence, task.Occurrence = next.Recurrence, 3
+	require.NoError(t, s.recur(context.Background(), &task))
+	assert.Empty(t, task.Recurrence)
+}
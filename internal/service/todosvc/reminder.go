@@ -0,0 +1,149 @@
+package todosvc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const prefixReminder = "rem-"
+
+// AddReminder reminds the user of a task of theirs, either some time before its due date or at a
+// given time, a task carries at most models.MaxReminders
+func (s *Service) AddReminder(ctx context.Context, id string, req *models.ReminderReq, userID *uuid.UUID,
+) (*models.Task, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	rem, err := newReminder(req, now, models.GetLocationFromCtx(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	rem.TaskID, rem.UserID = id, *userID
+
+	return s.changeReminders(ctx, id, userID, func(ctx context.Context, task *models.Task) error {
+		if len(task.Reminders) >= models.MaxReminders {
+			return models.ErrTooManyReminders
+		}
+
+		return s.Store.AddReminder(ctx, rem)
+	})
+}
+
+// RemoveReminder drops a reminder of a task of the user
+func (s *Service) RemoveReminder(ctx context.Context, id, reminderID string, userID *uuid.UUID) (*models.Task, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	if err := validatePrefixedID(reminderID, prefixReminder, "reminder id"); err != nil {
+		return nil, err
+	}
+
+	return s.changeReminders(ctx, id, userID, func(ctx context.Context, task *models.Task) error {
+		for _, rem := range task.Reminders {
+			if rem.ID == reminderID {
+				return s.Store.RemoveReminder(ctx, &rem)
+			}
+		}
+
+		return models.ErrNotFound("reminder")
+	})
+}
+
+// changeReminders runs change on a task of the user and returns it as changed
+func (s *Service) changeReminders(ctx context.Context, id string, userID *uuid.UUID,
+	change func(ctx context.Context, task *models.Task) error,
+) (*models.Task, error) {
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := change(ctx, current); err != nil {
+			return err
+		}
+
+		task, err = s.Store.Get(ctx, id, userID)
+
+		return err
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while changing task reminders",
+			slog.String("error", err.Error()),
+			slog.String("task", id),
+		)
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// copyReminders gives the next occurrence of a recurring task the reminders relative to its due
+// date, reminders at a given time have already had their turn
+func (s *Service) copyReminders(ctx context.Context, from, to *models.Task) error {
+	for _, rem := range from.Reminders {
+		if rem.At != nil {
+			continue
+		}
+
+		next := models.Reminder{
+			ID:        prefixReminder + uuid.NewString(),
+			TaskID:    to.ID,
+			UserID:    to.UserID,
+			Before:    rem.Before,
+			CreatedAt: to.AddedAt,
+		}
+
+		if err := s.Store.AddReminder(ctx, &next); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newReminder checks that req asks for a single reminder in the future, a local time is read in loc
+func newReminder(req *models.ReminderReq, now time.Time, loc *time.Location) (*models.Reminder, error) {
+	rem := models.Reminder{ID: prefixReminder + uuid.NewString(), CreatedAt: now}
+
+	switch {
+	case req.Before != "" && req.At != "":
+		return nil, models.ErrInvalid("reminder, either before or at")
+	case req.Before != "":
+		before, err := models.ParseBefore(req.Before)
+		if err != nil {
+			return nil, err
+		}
+
+		rem.Before = before
+	case req.At != "":
+		at, err := models.ParseRemindAt(req.At, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		if !at.After(now) {
+			return nil, models.ErrInvalid("reminder at, it is in the past")
+		}
+
+		at = at.UTC()
+		rem.At = &at
+	default:
+		return nil, models.ErrRequired("reminder before or at")
+	}
+
+	return &rem, nil
+}
@@ -0,0 +1,178 @@
+package todosvc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// save writes the changed task and stores the fields it changed from before as a new revision,
+// it has to run inside a transaction so that the write and its revision are kept together
+func (s *Service) save(ctx context.Context, before, changed *models.Task, userID *uuid.UUID) (*models.Task, error) {
+	if err := s.Store.Update(ctx, changed); err != nil {
+		return nil, err
+	}
+
+	return s.saved(ctx, before, changed, userID)
+}
+
+// saved follows up a change to a task written to the store, it returns the task as stored
+func (s *Service) saved(ctx context.Context, before, changed *models.Task, userID *uuid.UUID) (*models.Task, error) {
+	// a reminder that already fired before the due date moved out goes off again before the new one
+	if !sameTime(before.DueDate, changed.DueDate) {
+		if err := s.Store.ResetReminders(ctx, changed.ID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	after, err := s.Store.Get(ctx, changed.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := models.DiffTasks(before, after, models.GetLocationFromCtx(ctx))
+	if len(changes) == 0 {
+		return after, nil
+	}
+
+	err = s.Store.AddRevision(ctx, &models.Revision{
+		TaskID:    changed.ID,
+		UserID:    *userID,
+		ChangedAt: time.Now().UTC(),
+		Changes:   changes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return after, nil
+}
+
+// History returns the revisions of a task of the user, newest first
+func (s *Service) History(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, []models.Revision, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	if err := validateID(id); err != nil {
+		return nil, nil, err
+	}
+
+	task, err := s.Store.Get(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revs, err := s.Store.ListRevisions(ctx, id)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while listing revisions",
+			slog.String("error", err.Error()), slog.String("task", id))
+
+		return nil, nil, err
+	}
+
+	return task, revs, nil
+}
+
+// RestoreRevision brings the task back to how it was right after revision number, the restore
+// itself is recorded as a new revision so it can be undone as well. A status it brings back is
+// checked and followed up like one set through SetStatus. The task is returned as is when it already
+// reads like that revision. A version other than 0 has to be the one of the task.
+func (s *Service) RestoreRevision(ctx context.Context, id string, number, version int64, userID *uuid.UUID,
+) (*models.Task, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := current.CheckVersion(version); err != nil {
+			return err
+		}
+
+		revs, err := s.Store.ListRevisions(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		loc := models.GetLocationFromCtx(ctx)
+
+		changed := *current
+		if err := revert(&changed, revs, number, loc); err != nil {
+			return err
+		}
+
+		if len(models.DiffTasks(current, &changed, loc)) == 0 {
+			task = current
+			return nil
+		}
+
+		if err := s.restoredStatus(ctx, current, &changed, userID); err != nil {
+			return err
+		}
+
+		mt := time.Now().UTC()
+		changed.ModifiedAt = &mt
+
+		task, err = s.save(ctx, current, &changed, userID)
+
+		return err
+	})
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while restoring revision",
+			slog.String("error", err.Error()), slog.String("task", id))
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// restoredStatus checks the move to the status a restore brings back, without forcing it past open
+// blockers, and completes a task restored to done
+func (s *Service) restoredStatus(ctx context.Context, current, changed *models.Task, userID *uuid.UUID) error {
+	if changed.Status == current.Status {
+		return nil
+	}
+
+	if err := canMove(current, changed.Status, false); err != nil {
+		return err
+	}
+
+	if changed.Status != models.StatusDone {
+		return nil
+	}
+
+	return s.completed(ctx, changed, &models.StatusReq{Status: changed.Status.String()}, userID)
+}
+
+// revert undoes on task the changes of the revisions newer than number, revs are newest first
+func revert(task *models.Task, revs []models.Revision, number int64, loc *time.Location) error {
+	if number < 1 || len(revs) == 0 || number > revs[0].Number {
+		return models.ErrNotFound("revision")
+	}
+
+	for _, rev := range revs {
+		if rev.Number <= number {
+			break
+		}
+
+		for _, change := range rev.Changes {
+			if err := task.SetField(change.Field, change.Old, loc); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package todosvc
+
+import (
+	"context"
+	"testing"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRestoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	txMock := NewMockTransactor(ctrl)
+	s := New(storeMock, txMock)
+	userID := uuid.New()
+	task := models.Task{ID: generateID(), UserID: userID, Title: "Ship it", Status: models.StatusCancelled, Version: 1}
+	revs := []models.Revision{
+		{Number: 3, Changes: []models.FieldChange{{Field: "status", Old: "in_progress", New: "cancelled"}}},
+		{Number: 2, Changes: []models.FieldChange{{Field: "status", Old: "done", New: "in_progress"}}},
+		{Number: 1, Changes: []models.FieldChange{{Field: "title", Old: "Ship", New: "Ship it"}}},
+	}
+
+	txMock.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	storeMock.EXPECT().Get(gomock.Any(), task.ID, &userID).AnyTimes().
+		DoAndReturn(func(context.Context, string, *uuid.UUID) (*models.Task, error) {
+			stored := task
+
+			return &stored, nil
+		})
+	storeMock.EXPECT().ListRevisions(gomock.Any(), task.ID).AnyTimes().Return(revs, nil)
+
+	// a cancelled task has to be reopened before it is worked on again
+	_, err := s.RestoreRevision(context.Background(), task.ID, 2, 0, &userID)
+	assert.Equal(t, models.ErrStatusTransition, err)
+
+	// a task waiting for an open blocker isn't done by a restore
+	task.Status = models.StatusInProgress
+	task.BlockedBy = models.TaskRefs{{ID: generateID(), Title: "Review", Status: models.StatusTodo}}
+	_, err = s.RestoreRevision(context.Background(), task.ID, 1, 0, &userID)
+	assert.Equal(t, models.ErrTaskBlocked, err)
+
+	// the latest revision is how the task reads already, nothing is written
+	got, err := s.RestoreRevision(context.Background(), task.ID, 3, 0, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, &task, got)
+
+	// a history shown before the task changed restores nothing
+	_, err = s.RestoreRevision(context.Background(), task.ID, 1, 2, &userID)
+	assert.Equal(t, models.ErrVersionMismatch, err)
+
+	// a recurring task restored to done gets its next occurrence
+	task.BlockedBy = nil
+	task.Recurrence = "FREQ=DAILY;COUNT=3"
+	task.Occurrence = 1
+
+	storeMock.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, next *models.Task) error {
+			assert.Equal(t, models.StatusTodo, next.Status)
+			assert.Equal(t, 2, next.Occurrence)
+
+			return nil
+		})
+	storeMock.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, changed *models.Task) error {
+			assert.Equal(t, models.StatusDone, changed.Status)
+			assert.NotNil(t, changed.CompletedAt)
+			assert.Empty(t, changed.Recurrence)
+			task = *changed
+
+			return nil
+		})
+	storeMock.EXPECT().AddRevision(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err = s.RestoreRevision(context.Background(), task.ID, 1, 1, &userID)
+	require.NoError(t, err)
+}
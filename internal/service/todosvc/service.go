@@ -2,6 +2,7 @@ package todosvc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 	"todoapp/internal/models"
@@ -9,18 +10,43 @@ import (
 	"github.com/google/uuid"
 )
 
+// Service runs the task use cases, MaxDepth is how deep subtasks nest below a top level task
 type Service struct {
-	Store TodoStorer
+	Store    TodoStorer
+	Tx       Transactor
+	MaxDepth int
 }
 
-func New(st TodoStorer) *Service {
-	return &Service{Store: st}
+func New(st TodoStorer, tx Transactor) *Service {
+	return &Service{Store: st, Tx: tx, MaxDepth: models.DefaultMaxDepth}
 }
 
-func (s *Service) GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+// GetAll returns one page of the user's tasks, the page's NextCursor fetches the one after it
+func (s *Service) GetAll(ctx context.Context, req *models.TaskListReq, userID *uuid.UUID) (*models.TaskPage, error) {
 	logger := models.GetLoggerFromCtx(ctx)
 
-	tasks, err := s.Store.GetAll(ctx, userID)
+	list, err := s.viewedList(ctx, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a list view keeps the order of its list unless asked otherwise
+	if list != nil && req.Sort == "" {
+		withSort := *req
+		withSort.Sort = list.Sort
+		req = &withSort
+	}
+
+	q, err := newTaskQuery(req, models.GetLocationFromCtx(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	limit := q.Limit
+	// one more task than asked tells whether there is a next page
+	q.Limit++
+
+	tasks, err := s.Store.GetAll(ctx, q, userID)
 	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while fetcing all tasks",
 			slog.String("error", err.Error()), slog.String("user", userID.String()))
@@ -28,7 +54,30 @@ func (s *Service) GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task,
 		return nil, err
 	}
 
-	return tasks, nil
+	page := models.TaskPage{Tasks: tasks, Sort: models.FormatSort(q.Sort), Limit: limit, List: list}
+
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.NextCursor = models.NewCursor(q.Sort, &tasks[limit-1]).Encode()
+	}
+
+	// as a tree the page holds the top level tasks only, their subtasks hang below them
+	if q.Filter.TopLevel {
+		if err := s.attachSubtasks(ctx, q, page.Tasks, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &page, nil
+}
+
+// GetTask returns a task of the user that is not in the trash
+func (s *Service) GetTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	return s.Store.Get(ctx, id, userID)
 }
 
 func (s *Service) AddTask(ctx context.Context, taskInp *models.TaskReq, userID *uuid.UUID) (*models.Task, error) {
@@ -39,19 +88,48 @@ func (s *Service) AddTask(ctx context.Context, taskInp *models.TaskReq, userID *
 		return nil, err
 	}
 
-	dd, _ := time.Parse(time.DateOnly, taskInp.DueDate)
+	dd, _ := models.ParseDue(taskInp.DueDate, taskInp.DueTime, models.GetLocationFromCtx(ctx))
+	priority, _ := models.ParsePriority(taskInp.Priority)
+	recurrence, _ := parseRecurrence(taskInp.Recurrence)
 
 	task := models.Task{
 		ID:          id,
 		UserID:      *userID,
 		Title:       taskInp.Title,
 		Description: taskInp.Description,
-		IsDone:      false,
+		Status:      models.StatusTodo,
+		Priority:    priority,
+		ListID:      taskInp.ListID,
+		ParentID:    taskInp.ParentID,
 		DueDate:     &dd,
+		HasDueTime:  taskInp.DueTime != "",
 		AddedAt:     time.Now().UTC(),
 	}
 
-	if err := s.Store.Create(ctx, &task); err != nil {
+	if recurrence != "" {
+		task.Recurrence, task.Occurrence = recurrence, 1
+	}
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if task.ParentID != "" {
+			// a subtask is kept in the list of its parent
+			parent, err := s.checkParent(ctx, task.ID, task.ParentID, 0, userID)
+			if err != nil {
+				return err
+			}
+
+			task.ListID = parent.ListID
+		}
+
+		if task.ListID != "" {
+			if _, err := s.openList(ctx, task.ListID, userID); err != nil {
+				return err
+			}
+		}
+
+		return s.Store.Create(ctx, &task)
+	})
+	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while creating task - store.Create",
 			slog.String("error", err.Error()),
 			slog.String("task", task.ID),
@@ -63,14 +141,32 @@ func (s *Service) AddTask(ctx context.Context, taskInp *models.TaskReq, userID *
 	return &task, nil
 }
 
-func (s *Service) DeleteTask(ctx context.Context, id string, userID *uuid.UUID) error {
+// DeleteTask moves a task of the user to the trash, with cascade its subtasks go along with it,
+// otherwise they take its place. A version other than 0 has to be the one of the task.
+func (s *Service) DeleteTask(ctx context.Context, id string, cascade bool, version int64, userID *uuid.UUID) error {
 	logger := models.GetLoggerFromCtx(ctx)
 
 	if err := validateID(id); err != nil {
 		return models.ErrInvalid("task id")
 	}
 
-	if err := s.Store.Delete(ctx, id, userID); err != nil {
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		task, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := task.CheckVersion(version); err != nil {
+			return err
+		}
+
+		if err := s.trashSubtasks(ctx, task, cascade, userID); err != nil {
+			return err
+		}
+
+		return s.Store.Delete(ctx, id, task.Version, userID)
+	})
+	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while deleting task",
 			slog.String("error", err.Error()),
 			slog.String("task", id),
@@ -82,18 +178,58 @@ func (s *Service) DeleteTask(ctx context.Context, id string, userID *uuid.UUID)
 	return nil
 }
 
-func (s *Service) MarkDone(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+// SetStatus moves a task of the user to req.Status if its workflow allows it. A task with open
+// blockers is only done when forced, with cascade its subtasks that can be done are done along with it.
+// Completing a recurring task adds its next occurrence. A req.Version other than 0 has to be the one of the task.
+func (s *Service) SetStatus(ctx context.Context, id string, req *models.StatusReq, userID *uuid.UUID,
+) (*models.Task, error) {
 	logger := models.GetLoggerFromCtx(ctx)
 
 	if err := validateID(id); err != nil {
 		return nil, err
 	}
 
-	task, err := s.Store.MarkDone(ctx, id, userID)
+	next, err := models.ParseStatus(req.Status)
 	if err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, "error while marking task done",
+		return nil, err
+	}
+
+	var task *models.Task
+
+	err = s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := current.CheckVersion(req.Version); err != nil {
+			return err
+		}
+
+		if err := canMove(current, next, req.Force); err != nil {
+			return err
+		}
+
+		mt := time.Now().UTC()
+		changed := *current
+		changed.SetStatus(next, mt)
+		changed.ModifiedAt = &mt
+
+		if next == models.StatusDone {
+			if err := s.completed(ctx, &changed, req, userID); err != nil {
+				return err
+			}
+		}
+
+		task, err = s.save(ctx, current, &changed, userID)
+
+		return err
+	})
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while changing task status",
 			slog.String("error", err.Error()),
 			slog.String("task", id),
+			slog.String("status", req.Status),
 		)
 
 		return nil, err
@@ -102,7 +238,9 @@ func (s *Service) MarkDone(ctx context.Context, id string, userID *uuid.UUID) (*
 	return task, nil
 }
 
-func (s *Service) UpdateTask(ctx context.Context, id string, taskInp *models.TaskReq, isDone bool, userID *uuid.UUID,
+// UpdateTask changes the title, description, due date and time and priority of a task, its status is left as is.
+// A taskInp.Version other than 0 has to be the one of the task.
+func (s *Service) UpdateTask(ctx context.Context, id string, taskInp *models.TaskReq, userID *uuid.UUID,
 ) (*models.Task, error) {
 	logger := models.GetLoggerFromCtx(ctx)
 
@@ -110,20 +248,35 @@ func (s *Service) UpdateTask(ctx context.Context, id string, taskInp *models.Tas
 		return nil, err
 	}
 
-	dd, _ := time.Parse(time.DateOnly, taskInp.DueDate)
+	dd, _ := models.ParseDue(taskInp.DueDate, taskInp.DueTime, models.GetLocationFromCtx(ctx))
+	priority, _ := models.ParsePriority(taskInp.Priority)
+	recurrence, _ := parseRecurrence(taskInp.Recurrence)
 	mt := time.Now().UTC()
 
-	task := models.Task{
-		ID:          id,
-		UserID:      *userID,
-		Title:       taskInp.Title,
-		Description: taskInp.Description,
-		DueDate:     &dd,
-		IsDone:      isDone,
-		ModifiedAt:  &mt,
-	}
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
 
-	err := s.Store.Update(ctx, &task)
+		if err := current.CheckVersion(taskInp.Version); err != nil {
+			return err
+		}
+
+		changed := *current
+		changed.Title = taskInp.Title
+		changed.Description = taskInp.Description
+		changed.DueDate, changed.HasDueTime = &dd, taskInp.DueTime != ""
+		changed.Priority = priority
+		changed.ModifiedAt = &mt
+		setRecurrence(&changed, recurrence)
+
+		task, err = s.save(ctx, current, &changed, userID)
+
+		return err
+	})
 	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while updating task",
 			slog.String("error", err.Error()),
@@ -133,5 +286,101 @@ func (s *Service) UpdateTask(ctx context.Context, id string, taskInp *models.Tas
 		return nil, err
 	}
 
-	return &task, nil
+	return task, nil
+}
+
+// Search finds the user's tasks whose title or description contain words starting with every
+// word of query, best match first
+func (s *Service) Search(ctx context.Context, query string, limit int, userID *uuid.UUID) ([]models.SearchResult, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	terms := models.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, models.ErrRequired("search query")
+	}
+
+	limit, err := pageSize(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.Store.Search(ctx, terms, limit, userID)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while searching tasks",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *Service) ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	tasks, err := s.Store.ListTrash(ctx, userID)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while listing trash",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// RestoreTask takes a task of the user out of the trash, it becomes a top level task when its
+// parent is gone
+func (s *Service) RestoreTask(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		if task, err = s.Store.Restore(ctx, id, userID); err != nil || task.ParentID == "" {
+			return err
+		}
+
+		if _, err = s.Store.Get(ctx, task.ParentID, userID); !errors.Is(err, models.ErrNotFound("task")) {
+			return err
+		}
+
+		task.ParentID = ""
+
+		return s.Store.Update(ctx, task)
+	})
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while restoring task",
+			slog.String("error", err.Error()),
+			slog.String("task", id),
+		)
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// PurgeTrash permanently deletes the tasks that have been in the trash for longer than retention
+func (s *Service) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	n, err := s.Store.Purge(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while purging trash", slog.String("error", err.Error()))
+
+		return 0, err
+	}
+
+	if n > 0 {
+		logger.LogAttrs(ctx, slog.LevelInfo, "trash purged", slog.Int64("tasks", n))
+	}
+
+	return n, nil
 }
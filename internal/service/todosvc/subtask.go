@@ -0,0 +1,274 @@
+package todosvc
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SetParent makes a task of the user a subtask of parentID, an empty parentID makes it a top level task.
+// Its own subtasks move along with it, and they all take the list of their new parent.
+func (s *Service) SetParent(ctx context.Context, id, parentID string, userID *uuid.UUID) (*models.Task, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+
+	if parentID != "" {
+		if err := validatePrefixedID(parentID, prefixTask, "parent id"); err != nil {
+			return nil, err
+		}
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.Store.Get(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		changed := *current
+		mt := time.Now().UTC()
+		changed.ParentID = parentID
+		changed.ModifiedAt = &mt
+
+		if parentID != "" {
+			if err := s.takeParentList(ctx, current, &changed, userID); err != nil {
+				return err
+			}
+		}
+
+		task, err = s.save(ctx, current, &changed, userID)
+
+		return err
+	})
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while changing task parent",
+			slog.String("error", err.Error()),
+			slog.String("task", id),
+			slog.String("parent", parentID),
+		)
+
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// takeParentList checks the new parent of changed and keeps changed and its subtasks in the list of
+// that parent, current is the task as stored
+func (s *Service) takeParentList(ctx context.Context, current, changed *models.Task, userID *uuid.UUID) error {
+	levels, err := s.subtaskLevels(ctx, []string{current.ID}, userID)
+	if err != nil {
+		return err
+	}
+
+	parent, err := s.checkParent(ctx, current.ID, changed.ParentID, len(levels), userID)
+	if err != nil {
+		return err
+	}
+
+	if parent.ListID == current.ListID {
+		return nil
+	}
+
+	if parent.ListID != "" {
+		if _, err := s.openList(ctx, parent.ListID, userID); err != nil {
+			return err
+		}
+	}
+
+	changed.ListID = parent.ListID
+
+	return s.moveSubtasks(ctx, changed, userID)
+}
+
+// checkParent returns the task parentID once checked that the task id, with subtasks nested height
+// levels below it, can become its subtask: parentID must not be the task or one of its subtasks and
+// the deepest subtask must stay within MaxDepth
+func (s *Service) checkParent(ctx context.Context, id, parentID string, height int, userID *uuid.UUID,
+) (*models.Task, error) {
+	parent, err := s.Store.Get(ctx, parentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// depth is where the task lands, one below its parent
+	depth := 1
+
+	for ancestor := parent; ; depth++ {
+		if ancestor.ID == id {
+			return nil, models.ErrSubtaskCycle
+		}
+
+		if ancestor.ParentID == "" || depth > s.MaxDepth {
+			break
+		}
+
+		if ancestor, err = s.Store.Get(ctx, ancestor.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if depth+height > s.MaxDepth {
+		return nil, models.ErrSubtaskDepth
+	}
+
+	return parent, nil
+}
+
+// subtaskLevels returns the subtasks below the tasks ids level by level, the direct subtasks first
+func (s *Service) subtaskLevels(ctx context.Context, ids []string, userID *uuid.UUID) ([][]models.Task, error) {
+	levels := make([][]models.Task, 0)
+
+	// a level past MaxDepth can only be there if the data is broken, it is never walked
+	for len(ids) > 0 && len(levels) <= s.MaxDepth {
+		children, err := s.Store.GetChildren(ctx, ids, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(children) == 0 {
+			break
+		}
+
+		levels = append(levels, children)
+
+		ids = make([]string, 0, len(children))
+		for i := range children {
+			ids = append(ids, children[i].ID)
+		}
+	}
+
+	return levels, nil
+}
+
+// attachSubtasks hangs their subtasks below the tasks, each level in the order of q
+func (s *Service) attachSubtasks(ctx context.Context, q *models.TaskQuery, tasks []models.Task, userID *uuid.UUID) error {
+	ids := make([]string, 0, len(tasks))
+	for i := range tasks {
+		ids = append(ids, tasks[i].ID)
+	}
+
+	levels, err := s.subtaskLevels(ctx, ids, userID)
+	if err != nil {
+		return err
+	}
+
+	byParent := make(map[string][]models.Task)
+
+	for _, level := range levels {
+		for i := range level {
+			byParent[level[i].ParentID] = append(byParent[level[i].ParentID], level[i])
+		}
+	}
+
+	for _, children := range byParent {
+		sort.Slice(children, func(i, j int) bool { return q.Less(&children[i], &children[j]) })
+	}
+
+	for i := range tasks {
+		nest(&tasks[i], byParent)
+	}
+
+	return nil
+}
+
+func nest(task *models.Task, byParent map[string][]models.Task) {
+	task.Children = byParent[task.ID]
+
+	for i := range task.Children {
+		nest(&task.Children[i], byParent)
+	}
+}
+
+// completeSubtasks moves every subtask below the task id that can be done to done
+func (s *Service) completeSubtasks(ctx context.Context, id string, at time.Time, force bool, userID *uuid.UUID) error {
+	levels, err := s.subtaskLevels(ctx, []string{id}, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, level := range levels {
+		for i := range level {
+			if canMove(&level[i], models.StatusDone, force) != nil {
+				continue
+			}
+
+			changed := level[i]
+			changed.SetStatus(models.StatusDone, at)
+			changed.ModifiedAt = &at
+
+			if _, err := s.save(ctx, &level[i], &changed, userID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// moveSubtasks keeps every subtask below task in the list of task
+func (s *Service) moveSubtasks(ctx context.Context, task *models.Task, userID *uuid.UUID) error {
+	levels, err := s.subtaskLevels(ctx, []string{task.ID}, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, level := range levels {
+		for i := range level {
+			if level[i].ListID == task.ListID {
+				continue
+			}
+
+			level[i].ListID = task.ListID
+
+			if err := s.Store.Update(ctx, &level[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// trashSubtasks moves every subtask below task to the trash with cascade, otherwise its direct
+// subtasks take its place below its own parent
+func (s *Service) trashSubtasks(ctx context.Context, task *models.Task, cascade bool, userID *uuid.UUID) error {
+	if cascade {
+		levels, err := s.subtaskLevels(ctx, []string{task.ID}, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, level := range levels {
+			for i := range level {
+				if err := s.Store.Delete(ctx, level[i].ID, level[i].Version, userID); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	children, err := s.Store.GetChildren(ctx, []string{task.ID}, userID)
+	if err != nil {
+		return err
+	}
+
+	for i := range children {
+		children[i].ParentID = task.ParentID
+
+		if err := s.Store.Update(ctx, &children[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
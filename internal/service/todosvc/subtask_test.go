@@ -0,0 +1,118 @@
+package todosvc
+
+import (
+	"context"
+	"testing"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	s := New(storeMock, NewMockTransactor(ctrl))
+	userID := uuid.New()
+
+	// task-a > task-b > task-c
+	tasks := map[string]models.Task{
+		"task-a": {ID: "task-a"},
+		"task-b": {ID: "task-b", ParentID: "task-a"},
+		"task-c": {ID: "task-c", ParentID: "task-b"},
+	}
+
+	storeMock.EXPECT().Get(gomock.Any(), gomock.Any(), &userID).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string, _ *uuid.UUID) (*models.Task, error) {
+			task, ok := tasks[id]
+			if !ok {
+				return nil, models.ErrNotFound("task")
+			}
+
+			return &task, nil
+		})
+
+	tests := []struct {
+		name     string
+		id       string
+		parentID string
+		height   int
+		wantErr  error
+	}{
+		{name: "deepest level", id: "task-x", parentID: "task-c"},
+		{name: "subtasks too deep", id: "task-x", parentID: "task-c", height: 1, wantErr: models.ErrSubtaskDepth},
+		{name: "subtasks fit", id: "task-x", parentID: "task-a", height: 2},
+		{name: "below itself", id: "task-b", parentID: "task-b", wantErr: models.ErrSubtaskCycle},
+		{name: "below its subtask", id: "task-a", parentID: "task-c", wantErr: models.ErrSubtaskCycle},
+		{name: "missing parent", id: "task-x", parentID: "task-y", wantErr: models.ErrNotFound("task")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := s.checkParent(context.Background(), tt.id, tt.parentID, tt.height, &userID)
+
+			assert.Equal(t, tt.wantErr, err)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.parentID, parent.ID)
+			}
+		})
+	}
+}
+
+func TestSetParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeMock := NewMockTodoStorer(ctrl)
+	txMock := NewMockTransactor(ctrl)
+	s := New(storeMock, txMock)
+	userID := uuid.New()
+	a, x, y, home := generateID(), generateID(), generateID(), prefixList+uuid.New().String()
+
+	// x > y are in no list, a is in home
+	tasks := map[string]models.Task{
+		a: {ID: a, UserID: userID, Title: "Move", ListID: home},
+		x: {ID: x, UserID: userID, Title: "Pack"},
+		y: {ID: y, UserID: userID, Title: "Boxes", ParentID: x},
+	}
+
+	txMock.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	storeMock.EXPECT().Get(gomock.Any(), gomock.Any(), &userID).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string, _ *uuid.UUID) (*models.Task, error) {
+			task := tasks[id]
+
+			return &task, nil
+		})
+	storeMock.EXPECT().GetList(gomock.Any(), home, &userID).Return(&models.List{ID: home, UserID: userID, Name: "home"}, nil)
+	storeMock.EXPECT().GetChildren(gomock.Any(), gomock.Any(), &userID).AnyTimes().
+		DoAndReturn(func(_ context.Context, ids []string, _ *uuid.UUID) ([]models.Task, error) {
+			var children []models.Task
+
+			for _, task := range tasks {
+				if len(ids) == 1 && task.ParentID == ids[0] {
+					children = append(children, task)
+				}
+			}
+
+			return children, nil
+		})
+	storeMock.EXPECT().Update(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, task *models.Task) error {
+			tasks[task.ID] = *task
+
+			return nil
+		})
+
+	moved, err := s.SetParent(context.Background(), x, a, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, a, moved.ParentID)
+	assert.Equal(t, home, moved.ListID, "a subtask takes the list of its parent")
+	assert.Equal(t, home, tasks[y].ListID)
+}
@@ -0,0 +1,188 @@
+package todosvc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) ListTags(ctx context.Context, userID *uuid.UUID) ([]models.Tag, error) {
+	tags, err := s.Store.ListTags(ctx, userID)
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while listing tags",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (s *Service) CreateTag(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error) {
+	name, err := validateTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag *models.Tag
+
+	err = s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err = s.createTag(ctx, name, userID)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *Service) RenameTag(ctx context.Context, id, name string, userID *uuid.UUID) (*models.Tag, error) {
+	if err := validateTagID(id); err != nil {
+		return nil, err
+	}
+
+	name, err := validateTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag *models.Tag
+
+	err = s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if tag, err = s.Store.GetTag(ctx, id, userID); err != nil {
+			return err
+		}
+
+		if other, err := s.Store.GetTagByName(ctx, name, userID); err == nil && other.ID != id {
+			return models.ErrTagAlreadyExists
+		}
+
+		tag.Name = name
+
+		return s.Store.RenameTag(ctx, tag)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag of the user, the tasks carrying it are kept
+func (s *Service) DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error {
+	if err := validateTagID(id); err != nil {
+		return err
+	}
+
+	return s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.Store.GetTag(ctx, id, userID); err != nil {
+			return err
+		}
+
+		return s.Store.DeleteTag(ctx, id, userID)
+	})
+}
+
+// TagTask attaches the user's tag called name to a task, the tag is created if the user has none by that name
+func (s *Service) TagTask(ctx context.Context, taskID, name string, userID *uuid.UUID) (*models.Task, error) {
+	if err := validateID(taskID); err != nil {
+		return nil, err
+	}
+
+	name, err := validateTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *models.Task
+
+	err = s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.Store.Get(ctx, taskID, userID); err != nil {
+			return err
+		}
+
+		tag, err := s.Store.GetTagByName(ctx, name, userID)
+		if errors.Is(err, models.ErrNotFound("tag")) {
+			tag, err = s.createTag(ctx, name, userID)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.TagTask(ctx, taskID, tag.ID); err != nil {
+			return err
+		}
+
+		task, err = s.Store.Get(ctx, taskID, userID)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (s *Service) UntagTask(ctx context.Context, taskID, tagID string, userID *uuid.UUID) (*models.Task, error) {
+	if err := validateID(taskID); err != nil {
+		return nil, err
+	}
+
+	if err := validateTagID(tagID); err != nil {
+		return nil, err
+	}
+
+	var task *models.Task
+
+	err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.Store.Get(ctx, taskID, userID); err != nil {
+			return err
+		}
+
+		if _, err := s.Store.GetTag(ctx, tagID, userID); err != nil {
+			return err
+		}
+
+		if err := s.Store.UntagTask(ctx, taskID, tagID); err != nil {
+			return err
+		}
+
+		var err error
+
+		task, err = s.Store.Get(ctx, taskID, userID)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// createTag stores a new tag named name unless the user already has one by that name
+func (s *Service) createTag(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error) {
+	if _, err := s.Store.GetTagByName(ctx, name, userID); err == nil {
+		return nil, models.ErrTagAlreadyExists
+	}
+
+	tag := models.Tag{ID: prefixTag + uuid.NewString(), UserID: *userID, Name: name, CreatedAt: time.Now().UTC()}
+
+	if err := s.Store.CreateTag(ctx, &tag); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while creating tag",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return &tag, nil
+}
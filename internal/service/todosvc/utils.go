@@ -1,15 +1,20 @@
 package todosvc
 
 import (
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 	"todoapp/internal/models"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 const (
 	prefixTask = "task-"
+	prefixTag  = "tag-"
+	prefixList = "list-"
 )
 
 func generateID() string {
@@ -23,36 +28,311 @@ func validateTask(id string, task *models.TaskReq) error {
 
 	task.Title = strings.TrimSpace(task.Title)
 	task.Description = strings.TrimSpace(task.Description)
+	task.Priority = strings.TrimSpace(task.Priority)
+	task.ListID = strings.TrimSpace(task.ListID)
+	task.ParentID = strings.TrimSpace(task.ParentID)
+	task.Recurrence = strings.TrimSpace(task.Recurrence)
+	task.DueTime = strings.TrimSpace(task.DueTime)
 
 	if task.Title == "" {
 		return models.ErrRequired("task title")
 	}
 
+	if hasControlChars(task.Title) {
+		return models.ErrInvalid("task title, control characters are not allowed")
+	}
+
 	if len(task.Description) > 1000 {
 		return models.ErrInvalid("task description, size > 1K characters")
 	}
 
+	if hasControlChars(task.Description) {
+		return models.ErrInvalid("task description, control characters are not allowed")
+	}
+
 	if strings.TrimSpace(task.DueDate) == "" {
 		return models.ErrRequired("due date")
 	}
 
-	if _, err := time.Parse(time.DateOnly, task.DueDate); err != nil {
-		return models.ErrInvalid("due date")
+	if _, err := models.ParseDue(task.DueDate, task.DueTime, time.UTC); err != nil {
+		return err
+	}
+
+	if _, err := models.ParsePriority(task.Priority); err != nil {
+		return err
+	}
+
+	if _, err := parseRecurrence(task.Recurrence); err != nil {
+		return err
+	}
+
+	return validateTaskRefs(task)
+}
+
+// hasControlChars reports a C0 control character other than a tab or a line break, the search
+// highlight markers are control characters and must never come from the task text
+func hasControlChars(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool {
+		return r < 0x20 && r != '\t' && r != '\n' && r != '\r'
+	})
+}
+
+// validateTaskRefs checks the ids of the parent and the list a task refers to
+func validateTaskRefs(task *models.TaskReq) error {
+	if task.ParentID != "" {
+		if err := validatePrefixedID(task.ParentID, prefixTask, "parent id"); err != nil {
+			return err
+		}
+	}
+
+	if task.ListID != "" {
+		return validateListID(task.ListID)
 	}
 
 	return nil
 }
 
+// parseRecurrence returns the canonical form of an RRULE, an empty rule is a task that doesn't recur
+func parseRecurrence(rule string) (string, error) {
+	if rule == "" {
+		return "", nil
+	}
+
+	r, err := models.ParseRecurrence(rule)
+	if err != nil {
+		return "", err
+	}
+
+	return r.String(), nil
+}
+
+// newTaskQuery reads a listing request, its dates are days in loc
+func newTaskQuery(req *models.TaskListReq, loc *time.Location) (*models.TaskQuery, error) {
+	if req == nil {
+		req = &models.TaskListReq{}
+	}
+
+	keys, err := models.ParseSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := newTaskFilter(req, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := pageSize(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	q := models.TaskQuery{Filter: *filter, Sort: keys, Limit: limit}
+
+	if req.Tree != "" {
+		if q.Filter.TopLevel, err = strconv.ParseBool(req.Tree); err != nil {
+			return nil, models.ErrInvalid("tree")
+		}
+	}
+
+	if req.Cursor != "" {
+		if q.After, err = models.DecodeCursor(req.Cursor, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	return &q, nil
+}
+
+// pageSize defaults an unset limit and caps it to the largest page
+func pageSize(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, models.ErrInvalid("limit")
+	case limit == 0:
+		return models.DefaultPageSize, nil
+	default:
+		return min(limit, models.MaxPageSize), nil
+	}
+}
+
+// newTaskFilter reads the filters of a listing request, the dates of its bounds are days in the
+// location of now
+func newTaskFilter(req *models.TaskListReq, now time.Time) (*models.TaskFilter, error) {
+	var (
+		f   = models.TaskFilter{TitleContains: strings.TrimSpace(req.Title)}
+		err error
+	)
+
+	if req.Done != "" {
+		done, err := strconv.ParseBool(req.Done)
+		if err != nil {
+			return nil, models.ErrInvalid("done")
+		}
+
+		f.Done = &done
+	}
+
+	bounds := []struct {
+		name  string
+		value string
+		dst   **time.Time
+	}{
+		{"dueAfter", req.DueAfter, &f.DueAfter},
+		{"dueBefore", req.DueBefore, &f.DueBefore},
+		{"addedAfter", req.AddedAfter, &f.AddedAfter},
+		{"addedBefore", req.AddedBefore, &f.AddedBefore},
+	}
+
+	for _, b := range bounds {
+		if *b.dst, err = parseBound(b.name, b.value, now.Location()); err != nil {
+			return nil, err
+		}
+	}
+
+	f.Tags = parseTags(req.Tags)
+
+	if f.AllTags, err = parseTagMatch(req.TagMatch); err != nil {
+		return nil, err
+	}
+
+	if f.ListID, err = parseList(req.List); err != nil {
+		return nil, err
+	}
+
+	if req.Overdue != "" {
+		overdue, err := strconv.ParseBool(req.Overdue)
+		if err != nil {
+			return nil, models.ErrInvalid("overdue")
+		}
+
+		if overdue {
+			return overdueFilter(&f, now)
+		}
+	}
+
+	return &f, nil
+}
+
+// overdueFilter narrows f to the open tasks due before now
+func overdueFilter(f *models.TaskFilter, now time.Time) (*models.TaskFilter, error) {
+	if f.Done != nil && *f.Done {
+		return nil, models.ErrInvalid("overdue, done tasks are never overdue")
+	}
+
+	f.Open = true
+
+	if f.DueBefore == nil || f.DueBefore.After(now) {
+		f.DueBefore = &now
+	}
+
+	return f, nil
+}
+
+// parseBound reads a filter time bound given either as a date, its start in loc, or as an RFC 3339 timestamp
+func parseBound(name, value string, loc *time.Location) (*time.Time, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, models.ErrInvalid(name)
+}
+
 func validateID(id string) error {
-	splits := strings.Split(id, prefixTask)
+	return validatePrefixedID(id, prefixTask, "task id")
+}
+
+func validateTagID(id string) error {
+	return validatePrefixedID(id, prefixTag, "tag id")
+}
+
+func validateListID(id string) error {
+	return validatePrefixedID(id, prefixList, "list id")
+}
+
+func validatePrefixedID(id, prefix, field string) error {
+	splits := strings.Split(id, prefix)
 	if len(splits) != 2 {
-		return models.ErrInvalid("task id")
+		return models.ErrInvalid(field)
 	}
 
 	uid, err := uuid.Parse(splits[1])
 	if err != nil || uid == uuid.Nil {
-		return models.ErrInvalid("task id")
+		return models.ErrInvalid(field)
 	}
 
 	return nil
 }
+
+// validateTagName trims the name, commas are not allowed as they separate the tags of a filter
+func validateTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	switch {
+	case name == "":
+		return "", models.ErrRequired("tag name")
+	case utf8.RuneCountInString(name) > models.MaxTagName || strings.Contains(name, ","):
+		return "", models.ErrInvalid("tag name")
+	default:
+		return name, nil
+	}
+}
+
+// parseTagMatch tells whether a task has to carry all the filter's tags rather than any of them
+func parseTagMatch(tagMatch string) (bool, error) {
+	switch tagMatch {
+	case "", "any":
+		return false, nil
+	case "all":
+		return true, nil
+	default:
+		return false, models.ErrInvalid("tagMatch")
+	}
+}
+
+// parseList reads the list filter, InboxList keeps the tasks in no list
+func parseList(list string) (*string, error) {
+	switch list {
+	case "":
+		return nil, nil
+	case models.InboxList:
+		none := ""
+
+		return &none, nil
+	}
+
+	if err := validateListID(list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// parseTags splits a comma separated list of tag names, dropping blanks and repeats
+func parseTags(tags string) []string {
+	var names []string
+
+	for _, name := range strings.Split(tags, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// sameTime tells whether two optional times are both unset or the same instant
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
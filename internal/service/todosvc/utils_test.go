@@ -4,9 +4,12 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 	"todoapp/internal/models"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateID(t *testing.T) {
@@ -44,6 +47,15 @@ func TestValidateTask(t *testing.T) {
 			wantErr: models.ErrRequired("due date")},
 		{name: "invalid dueDate", task: models.TaskReq{ID: "task-" + uid, Title: "test", DueDate: " 1235 "},
 			wantErr: models.ErrInvalid("due date")},
+		{name: "valid priority", task: models.TaskReq{ID: "task-" + uid, Title: "test", DueDate: date, Priority: " high "}},
+		{name: "invalid priority", task: models.TaskReq{ID: "task-" + uid, Title: "test", DueDate: date, Priority: "asap"},
+			wantErr: models.ErrInvalid("priority")},
+		{name: "multiline description", task: models.TaskReq{ID: "task-" + uid, Title: "test", DueDate: date,
+			Description: "first\r\n\tsecond"}},
+		{name: "control character in title", task: models.TaskReq{ID: "task-" + uid, Title: "te\x02st", DueDate: date},
+			wantErr: models.ErrInvalid("task title, control characters are not allowed")},
+		{name: "control character in description", task: models.TaskReq{ID: "task-" + uid, Title: "test", DueDate: date,
+			Description: "a\x03b"}, wantErr: models.ErrInvalid("task description, control characters are not allowed")},
 	}
 
 	for _, tt := range tests {
@@ -74,3 +86,93 @@ func TestValidateID(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTaskFilter(t *testing.T) {
+	now := time.Date(2025, 6, 16, 12, 0, 0, 0, time.UTC)
+	open, done := false, true
+	later, earlier := now.AddDate(0, 0, 3), time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	list, inbox := prefixList+uuid.NewString(), ""
+
+	tests := []struct {
+		name    string
+		req     models.TaskListReq
+		want    *models.TaskFilter
+		wantErr error
+	}{
+		{name: "empty", req: models.TaskListReq{}, want: &models.TaskFilter{}},
+		{name: "done and title", req: models.TaskListReq{Done: "true", Title: " report "},
+			want: &models.TaskFilter{Done: &done, TitleContains: "report"}},
+		{name: "date and timestamp bounds", req: models.TaskListReq{DueBefore: "2025-06-10", AddedAfter: "2025-06-19T12:00:00Z"},
+			want: &models.TaskFilter{DueBefore: &earlier, AddedAfter: &later}},
+		{name: "overdue", req: models.TaskListReq{Overdue: "true", DueBefore: "2025-06-19T12:00:00Z"},
+			want: &models.TaskFilter{Open: true, DueBefore: &now}},
+		{name: "overdue keeps an earlier bound", req: models.TaskListReq{Overdue: "1", DueBefore: "2025-06-10"},
+			want: &models.TaskFilter{Open: true, DueBefore: &earlier}},
+		{name: "overdue undone tasks", req: models.TaskListReq{Overdue: "true", Done: "false"},
+			want: &models.TaskFilter{Done: &open, Open: true, DueBefore: &now}},
+		{name: "overdue done tasks", req: models.TaskListReq{Overdue: "true", Done: "true"},
+			wantErr: models.ErrInvalid("overdue, done tasks are never overdue")},
+		{name: "invalid done", req: models.TaskListReq{Done: "maybe"}, wantErr: models.ErrInvalid("done")},
+		{name: "invalid bound", req: models.TaskListReq{DueAfter: "16/06/2025"}, wantErr: models.ErrInvalid("dueAfter")},
+		{name: "list", req: models.TaskListReq{List: list}, want: &models.TaskFilter{ListID: &list}},
+		{name: "inbox", req: models.TaskListReq{List: models.InboxList}, want: &models.TaskFilter{ListID: &inbox}},
+		{name: "invalid list", req: models.TaskListReq{List: "work"}, wantErr: models.ErrInvalid("list id")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTaskFilter(&tt.req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("newTaskFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// a cancelled task isn't done but is no longer overdue either, like TaskResp.Overdue tells
+	got, err := newTaskFilter(&models.TaskListReq{Overdue: "true"}, now)
+	require.NoError(t, err)
+
+	task := models.Task{Status: models.StatusTodo, DueDate: &earlier}
+	assert.True(t, got.Match(&task))
+
+	task.SetStatus(models.StatusCancelled, now)
+	assert.False(t, got.Match(&task))
+}
+
+func TestRevert(t *testing.T) {
+	revs := []models.Revision{
+		{Number: 3, Changes: []models.FieldChange{{Field: "status", Old: "in_progress", New: "blocked"}}},
+		{Number: 2, Changes: []models.FieldChange{{Field: "title", Old: "second", New: "third"}}},
+		{Number: 1, Changes: []models.FieldChange{{Field: "title", Old: "first", New: "second"}}},
+	}
+
+	task := models.Task{Title: "third", Status: models.StatusBlocked}
+	assert.NoError(t, revert(&task, revs, 1, time.UTC))
+	assert.Equal(t, models.Task{Title: "second", Status: models.StatusInProgress}, task)
+
+	task = models.Task{Title: "third", Status: models.StatusBlocked}
+	assert.NoError(t, revert(&task, revs, 3, time.UTC))
+	assert.Equal(t, models.Task{Title: "third", Status: models.StatusBlocked}, task)
+
+	assert.Equal(t, models.ErrNotFound("revision"), revert(&task, revs, 4, time.UTC))
+	assert.Equal(t, models.ErrNotFound("revision"), revert(&task, nil, 1, time.UTC))
+}
+
+func TestValidateTagName(t *testing.T) {
+	name, err := validateTagName("  work  ")
+	assert.NoError(t, err)
+	assert.Equal(t, "work", name)
+
+	_, err = validateTagName(" ")
+	assert.Equal(t, models.ErrRequired("tag name"), err)
+
+	_, err = validateTagName("a,b")
+	assert.Equal(t, models.ErrInvalid("tag name"), err)
+
+	_, err = validateTagName(strings.Repeat("é", models.MaxTagName+1))
+	assert.Equal(t, models.ErrInvalid("tag name"), err)
+
+	assert.Equal(t, []string{"work", "home"}, parseTags(" work,, home ,work"))
+}
@@ -2,6 +2,7 @@ package usersvc
 
 import (
 	"context"
+	"time"
 
 	"todoapp/internal/models"
 
@@ -12,6 +13,19 @@ import (
 type UserStorer interface {
 	GetUserByEmail(ctx context.Context, email string) (*models.UserData, error)
 	RegisterUser(ctx context.Context, data *models.UserData) error
+	GetUserByID(ctx context.Context, id *uuid.UUID) (*models.UserData, error)
+	SetTimeZone(ctx context.Context, id *uuid.UUID, timeZone string) error
+	CreateToken(ctx context.Context, t *models.AccessToken) error
+	ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error)
+	GetTokenByHash(ctx context.Context, hash string) (*models.AccessToken, error)
+	DeleteToken(ctx context.Context, id string, userID *uuid.UUID) error
+	TouchToken(ctx context.Context, id string, at time.Time) error
+}
+
+// Transactor runs fn as one unit of work, the store calls made with the ctx given to fn
+// are committed together when fn returns nil and rolled back otherwise
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type SessionStorer interface {
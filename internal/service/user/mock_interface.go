@@ -12,6 +12,7 @@ package usersvc
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	models "todoapp/internal/models"
 
 	uuid "github.com/google/uuid"
@@ -42,6 +43,49 @@ func (m *MockUserStorer) EXPECT() *MockUserStorerMockRecorder {
 	return m.recorder
 }
 
+// CreateToken mocks base method.
+func (m *MockUserStorer) CreateToken(ctx context.Context, t *models.AccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockUserStorerMockRecorder) CreateToken(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockUserStorer)(nil).CreateToken), ctx, t)
+}
+
+// DeleteToken mocks base method.
+func (m *MockUserStorer) DeleteToken(ctx context.Context, id string, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockUserStorerMockRecorder) DeleteToken(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockUserStorer)(nil).DeleteToken), ctx, id, userID)
+}
+
+// GetTokenByHash mocks base method.
+func (m *MockUserStorer) GetTokenByHash(ctx context.Context, hash string) (*models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenByHash", ctx, hash)
+	ret0, _ := ret[0].(*models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenByHash indicates an expected call of GetTokenByHash.
+func (mr *MockUserStorerMockRecorder) GetTokenByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenByHash", reflect.TypeOf((*MockUserStorer)(nil).GetTokenByHash), ctx, hash)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserStorer) GetUserByEmail(ctx context.Context, email string) (*models.UserData, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +101,36 @@ func (mr *MockUserStorerMockRecorder) GetUserByEmail(ctx, email any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserStorer)(nil).GetUserByEmail), ctx, email)
 }
 
+// GetUserByID mocks base method.
+func (m *MockUserStorer) GetUserByID(ctx context.Context, id *uuid.UUID) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserStorerMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserStorer)(nil).GetUserByID), ctx, id)
+}
+
+// ListTokens mocks base method.
+func (m *MockUserStorer) ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTokens", ctx, userID)
+	ret0, _ := ret[0].([]models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTokens indicates an expected call of ListTokens.
+func (mr *MockUserStorerMockRecorder) ListTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTokens", reflect.TypeOf((*MockUserStorer)(nil).ListTokens), ctx, userID)
+}
+
 // RegisterUser mocks base method.
 func (m *MockUserStorer) RegisterUser(ctx context.Context, data *models.UserData) error {
 	m.ctrl.T.Helper()
@@ -71,6 +145,72 @@ func (mr *MockUserStorerMockRecorder) RegisterUser(ctx, data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserStorer)(nil).RegisterUser), ctx, data)
 }
 
+// SetTimeZone mocks base method.
+func (m *MockUserStorer) SetTimeZone(ctx context.Context, id *uuid.UUID, timeZone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimeZone", ctx, id, timeZone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTimeZone indicates an expected call of SetTimeZone.
+func (mr *MockUserStorerMockRecorder) SetTimeZone(ctx, id, timeZone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeZone", reflect.TypeOf((*MockUserStorer)(nil).SetTimeZone), ctx, id, timeZone)
+}
+
+// TouchToken mocks base method.
+func (m *MockUserStorer) TouchToken(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchToken", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchToken indicates an expected call of TouchToken.
+func (mr *MockUserStorerMockRecorder) TouchToken(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchToken", reflect.TypeOf((*MockUserStorer)(nil).TouchToken), ctx, id, at)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTransactorMockRecorder) WithinTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTransactor)(nil).WithinTx), ctx, fn)
+}
+
 // MockSessionStorer is a mock of SessionStorer interface.
 type MockSessionStorer struct {
 	ctrl     *gomock.Controller
@@ -14,10 +14,11 @@ import (
 type Service struct {
 	UserStore    UserStorer
 	SessionStore SessionStorer
+	Tx           Transactor
 }
 
-func New(st UserStorer, ss SessionStorer) *Service {
-	return &Service{UserStore: st, SessionStore: ss}
+func New(st UserStorer, ss SessionStorer, tx Transactor) *Service {
+	return &Service{UserStore: st, SessionStore: ss, Tx: tx}
 }
 
 func (s *Service) Register(ctx context.Context, req *models.RegisterReq) (*models.SessionData, error) {
@@ -51,20 +52,16 @@ func (s *Service) Register(ctx context.Context, req *models.RegisterReq) (*model
 		return nil, err
 	}
 
+	loc, _ := models.LoadTimeZone(req.TimeZone)
+
 	user := models.UserData{
 		ID:       uuid.New(),
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: passwd,
+		TimeZone: loc.String(),
 	}
 
-	if err := s.UserStore.RegisterUser(ctx, &user); err != nil {
-		return nil, err
-	}
-
-	logger.LogAttrs(ctx, slog.LevelInfo, "user created successfully!!",
-		slog.String("email", req.Email), slog.String("userID", user.ID.String()))
-
 	session := models.SessionData{
 		ID:     uuid.New(),
 		UserID: user.ID,
@@ -72,7 +69,18 @@ func (s *Service) Register(ctx context.Context, req *models.RegisterReq) (*model
 		Expiry: time.Now().Add(time.Minute * 15),
 	}
 
-	if err := s.SessionStore.CreateSession(ctx, &session); err != nil {
+	// user and session are written together, so a failed session never leaves a user behind
+	err = s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.UserStore.RegisterUser(ctx, &user); err != nil {
+			return err
+		}
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "user created successfully!!",
+			slog.String("email", req.Email), slog.String("userID", user.ID.String()))
+
+		return s.SessionStore.CreateSession(ctx, &session)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -152,6 +160,36 @@ func (s *Service) handleLoginSession(ctx context.Context, user *models.UserData)
 	return session, nil
 }
 
+// GetUser returns the signed in user
+func (s *Service) GetUser(ctx context.Context, userID *uuid.UUID) (*models.UserData, error) {
+	user, err := s.UserStore.GetUserByID(ctx, userID)
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while fetching user",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SetTimeZone changes the IANA time zone the user's due dates are read and shown in
+func (s *Service) SetTimeZone(ctx context.Context, userID *uuid.UUID, timeZone string) (*time.Location, error) {
+	loc, err := models.LoadTimeZone(timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.UserStore.SetTimeZone(ctx, userID, loc.String()); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while changing the time zone",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return loc, nil
+}
+
 func encryptedPassword(password string) (string, error) {
 	passwd, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -25,7 +25,8 @@ func TestServiceRegister(t *testing.T) {
 
 	userMock := NewMockUserStorer(ctrl)
 	sessionMock := NewMockSessionStorer(ctrl)
-	s := New(userMock, sessionMock)
+	txMock := NewMockTransactor(ctrl)
+	s := New(userMock, sessionMock, txMock)
 	email := "abcd@cdef.com"
 	ctx := context.Background()
 	userData := models.UserData{}
@@ -35,6 +36,9 @@ func TestServiceRegister(t *testing.T) {
 		LoginReq: &models.LoginReq{Email: email, Password: "abcd@abcd"},
 	}
 
+	txMock.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
 	tests := []struct {
 		name     string
 		req      *models.RegisterReq
@@ -0,0 +1,125 @@
+package usersvc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	prefixToken = "tok-"
+	// tokenBytes is how many random bytes a token is made of
+	tokenBytes = 32
+	// touchInterval is how stale the last use of a token may get before it is written again,
+	// so a script making many calls doesn't write on every one
+	touchInterval = time.Minute
+)
+
+// CreateToken creates a named personal access token for the user, the token itself is returned
+// this once and only its hash is kept. A request signed in with a token can't create one allowed
+// more than its own.
+func (s *Service) CreateToken(ctx context.Context, userID *uuid.UUID, req *models.TokenReq) (*models.NewAccessToken, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, models.ErrRequired("token name")
+	}
+
+	if utf8.RuneCountInString(name) > models.MaxTokenName {
+		return nil, models.ErrInvalid("token name, too long")
+	}
+
+	scopes, err := models.ParseScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if held, ok := ctx.Value(models.CtxKeyScopes).([]models.Scope); ok {
+		for _, scope := range scopes {
+			if !slices.Contains(held, scope) {
+				return nil, fmt.Errorf("%w %s", models.ErrScopeMissing, scope)
+			}
+		}
+	}
+
+	secret := make([]byte, tokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	token := models.TokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	created := models.NewAccessToken{
+		AccessToken: models.AccessToken{
+			ID:        prefixToken + uuid.NewString(),
+			UserID:    *userID,
+			Name:      name,
+			Hash:      models.HashToken(token),
+			Scopes:    scopes,
+			CreatedAt: time.Now().UTC(),
+		},
+		Token: token,
+	}
+
+	if err := s.UserStore.CreateToken(ctx, &created.AccessToken); err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while creating access token",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// ListTokens returns the user's access tokens, the oldest first
+func (s *Service) ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error) {
+	return s.UserStore.ListTokens(ctx, userID)
+}
+
+// RevokeToken deletes an access token of the user, it can't be used from then on
+func (s *Service) RevokeToken(ctx context.Context, id string, userID *uuid.UUID) error {
+	if !strings.HasPrefix(id, prefixToken) {
+		return models.ErrInvalid("token id")
+	}
+
+	return s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.UserStore.DeleteToken(ctx, id, userID)
+	})
+}
+
+// AuthenticateToken returns the access token a request is signed in with and records its use
+func (s *Service) AuthenticateToken(ctx context.Context, token string) (*models.AccessToken, error) {
+	if !strings.HasPrefix(token, models.TokenPrefix) {
+		return nil, models.ErrInvalidToken
+	}
+
+	t, err := s.UserStore.GetTokenByHash(ctx, models.HashToken(token))
+	if errors.Is(err, models.ErrNotFound("token")) {
+		return nil, models.ErrInvalidToken
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if now := time.Now().UTC(); t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= touchInterval {
+		// a failed write only loses the last use, the request goes on
+		if err := s.UserStore.TouchToken(ctx, t.ID, now); err != nil {
+			models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error while recording access token use",
+				slog.String("error", err.Error()), slog.String("token", t.ID))
+		} else {
+			t.LastUsedAt = &now
+		}
+	}
+
+	return t, nil
+}
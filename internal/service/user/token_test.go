@@ -0,0 +1,80 @@
+package usersvc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userMock := NewMockUserStorer(ctrl)
+	s := New(userMock, NewMockSessionStorer(ctrl), NewMockTransactor(ctrl))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	var stored models.AccessToken
+
+	userMock.EXPECT().CreateToken(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, t *models.AccessToken) error {
+			stored = *t
+
+			return nil
+		})
+
+	created, err := s.CreateToken(ctx, &userID, &models.TokenReq{Name: " CI ", Scopes: []string{"tasks:read"}})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, models.TokenPrefix))
+	assert.Equal(t, "CI", stored.Name)
+	assert.Equal(t, []models.Scope{models.ScopeTasksRead}, stored.Scopes)
+	// only the hash of the token is kept
+	assert.Equal(t, models.HashToken(created.Token), stored.Hash)
+	assert.NotContains(t, stored.Hash, created.Token)
+
+	_, err = s.CreateToken(ctx, &userID, &models.TokenReq{Name: "CI"})
+	assert.Equal(t, models.ErrRequired("scopes"), err)
+
+	// a token can only create tokens with the scopes it holds itself
+	tokenCtx := context.WithValue(ctx, models.CtxKeyScopes, []models.Scope{models.ScopeTasksRead, models.ScopeAccount})
+
+	_, err = s.CreateToken(tokenCtx, &userID, &models.TokenReq{Name: "CI", Scopes: []string{"tasks:read", "tasks:write"}})
+	assert.ErrorIs(t, err, models.ErrScopeMissing)
+
+	userMock.EXPECT().CreateToken(tokenCtx, gomock.Any()).Return(nil)
+
+	_, err = s.CreateToken(tokenCtx, &userID, &models.TokenReq{Name: "CI", Scopes: []string{"tasks:read"}})
+	require.NoError(t, err)
+
+	userMock.EXPECT().GetTokenByHash(ctx, stored.Hash).Return(&stored, nil)
+	userMock.EXPECT().TouchToken(ctx, stored.ID, gomock.Any()).Return(nil)
+
+	got, err := s.AuthenticateToken(ctx, created.Token)
+	require.NoError(t, err)
+	assert.NotNil(t, got.LastUsedAt)
+
+	// a token used a moment ago is not written again
+	recent := time.Now().UTC()
+	stored.LastUsedAt = &recent
+	userMock.EXPECT().GetTokenByHash(ctx, stored.Hash).Return(&stored, nil)
+
+	_, err = s.AuthenticateToken(ctx, created.Token)
+	require.NoError(t, err)
+
+	userMock.EXPECT().GetTokenByHash(ctx, gomock.Any()).Return(nil, models.ErrNotFound("token"))
+
+	_, err = s.AuthenticateToken(ctx, models.TokenPrefix+"revoked")
+	assert.Equal(t, models.ErrInvalidToken, err)
+
+	_, err = s.AuthenticateToken(ctx, uuid.NewString())
+	assert.Equal(t, models.ErrInvalidToken, err)
+}
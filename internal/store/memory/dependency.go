@@ -0,0 +1,139 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AddDependency makes a task wait for its blocker, adding it twice keeps one
+func (s *TodoStore) AddDependency(ctx context.Context, dep *models.Dependency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bump(ctx, dep.TaskID)
+
+	prev := s.blockers[dep.TaskID]
+	if slices.Contains(prev, dep.BlockerID) {
+		return nil
+	}
+
+	s.blockers[dep.TaskID] = append(slices.Clip(prev), dep.BlockerID)
+	onRollback(ctx, func() { s.setBlockers(dep.TaskID, prev) })
+
+	return nil
+}
+
+func (s *TodoStore) RemoveDependency(ctx context.Context, dep *models.Dependency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bump(ctx, dep.TaskID)
+
+	prev := s.blockers[dep.TaskID]
+
+	i := slices.Index(prev, dep.BlockerID)
+	if i < 0 {
+		return nil
+	}
+
+	s.blockers[dep.TaskID] = slices.Delete(slices.Clone(prev), i, i+1)
+	onRollback(ctx, func() { s.setBlockers(dep.TaskID, prev) })
+
+	return nil
+}
+
+// LockDependencies holds off the other units of work changing dependencies until the one in ctx
+// ends, outside a transaction it does nothing
+func (s *TodoStore) LockDependencies(ctx context.Context, _ *uuid.UUID) error {
+	holdUntilEnd(ctx, &s.depMu)
+
+	return nil
+}
+
+// ListDependencies returns every dependency between the user's tasks, the trashed ones included
+func (s *TodoStore) ListDependencies(_ context.Context, userID *uuid.UUID) ([]models.Dependency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]models.Dependency, 0)
+
+	for taskID, blockerIDs := range s.blockers {
+		if task, ok := s.tasks[taskID]; !ok || task.UserID != *userID {
+			continue
+		}
+
+		for _, blockerID := range blockerIDs {
+			res = append(res, models.Dependency{TaskID: taskID, BlockerID: blockerID})
+		}
+	}
+
+	return res, nil
+}
+
+// withDependencies returns task carrying the tasks it waits for and the tasks waiting for it,
+// trashed tasks are left out, the caller holds the lock
+func (s *TodoStore) withDependencies(task models.Task) models.Task {
+	task.BlockedBy, task.Blocking = make(models.TaskRefs, 0), make(models.TaskRefs, 0)
+
+	for _, id := range s.blockers[task.ID] {
+		if blocker, ok := s.tasks[id]; ok && blocker.DeletedAt == nil {
+			task.BlockedBy = append(task.BlockedBy, models.TaskRef{ID: id, Title: blocker.Title, Status: blocker.Status})
+		}
+	}
+
+	for id, blockerIDs := range s.blockers {
+		if waiting, ok := s.tasks[id]; ok && waiting.DeletedAt == nil && slices.Contains(blockerIDs, task.ID) {
+			task.Blocking = append(task.Blocking, models.TaskRef{ID: id, Title: waiting.Title, Status: waiting.Status})
+		}
+	}
+
+	sortRefs(task.BlockedBy)
+	sortRefs(task.Blocking)
+
+	return task
+}
+
+func sortRefs(refs models.TaskRefs) {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Title != refs[j].Title {
+			return refs[i].Title < refs[j].Title
+		}
+
+		return refs[i].ID < refs[j].ID
+	})
+}
+
+// dropDependencies forgets every dependency of the purged task id, the caller holds the lock
+func (s *TodoStore) dropDependencies(ctx context.Context, id string) {
+	for taskID, blockerIDs := range s.blockers {
+		if taskID != id && !slices.Contains(blockerIDs, id) {
+			continue
+		}
+
+		if taskID == id {
+			delete(s.blockers, taskID)
+		} else {
+			s.blockers[taskID] = slices.DeleteFunc(slices.Clone(blockerIDs), func(b string) bool { return b == id })
+		}
+
+		onRollback(ctx, func() { s.setBlockers(taskID, blockerIDs) })
+	}
+}
+
+func (s *TodoStore) setBlockers(taskID string, blockerIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if blockerIDs == nil {
+		delete(s.blockers, taskID)
+
+		return
+	}
+
+	s.blockers[taskID] = blockerIDs
+}
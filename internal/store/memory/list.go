@@ -0,0 +1,138 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (s *TodoStore) CreateList(ctx context.Context, list *models.List) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lists[list.ID]; ok {
+		return models.ErrListAlreadyExists
+	}
+
+	for _, l := range s.lists {
+		if l.UserID == list.UserID && l.Name == list.Name {
+			return models.ErrListAlreadyExists
+		}
+	}
+
+	s.lists[list.ID] = *list
+
+	id := list.ID
+	onRollback(ctx, func() { s.restoreList(id, nil) })
+
+	return nil
+}
+
+// GetLists returns either the active or the archived lists of the user, ordered by name
+func (s *TodoStore) GetLists(_ context.Context, archived bool, userID *uuid.UUID) ([]models.List, error) {
+	s.mu.RLock()
+
+	res := make([]models.List, 0)
+
+	for _, list := range s.lists {
+		if list.UserID == *userID && list.IsArchived() == archived {
+			res = append(res, list)
+		}
+	}
+
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+
+	return res, nil
+}
+
+func (s *TodoStore) GetList(_ context.Context, id string, userID *uuid.UUID) (*models.List, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list, ok := s.lists[id]
+	if !ok || list.UserID != *userID {
+		return nil, models.ErrNotFound("list")
+	}
+
+	return &list, nil
+}
+
+func (s *TodoStore) GetListByName(_ context.Context, name string, userID *uuid.UUID) (*models.List, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, list := range s.lists {
+		if list.UserID == *userID && list.Name == name {
+			return &list, nil
+		}
+	}
+
+	return nil, models.ErrNotFound("list")
+}
+
+// UpdateList writes the name, sort and archive state of the list
+func (s *TodoStore) UpdateList(ctx context.Context, list *models.List) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.lists[list.ID]
+	if !ok || existing.UserID != list.UserID {
+		return nil
+	}
+
+	prev := existing
+	onRollback(ctx, func() { s.restoreList(prev.ID, &prev) })
+
+	existing.Name = list.Name
+	existing.Sort = list.Sort
+	existing.ArchivedAt = list.ArchivedAt
+	existing.ModifiedAt = list.ModifiedAt
+	s.lists[list.ID] = existing
+
+	return nil
+}
+
+// DeleteList deletes the list, its tasks, trashed ones included, are left in no list
+func (s *TodoStore) DeleteList(ctx context.Context, id string, userID *uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, ok := s.lists[id]
+	if !ok || list.UserID != *userID {
+		return nil
+	}
+
+	delete(s.lists, id)
+	onRollback(ctx, func() { s.restoreList(id, &list) })
+
+	for taskID, task := range s.tasks {
+		if task.ListID == id {
+			prev := task
+			onRollback(ctx, func() { s.restore(taskID, &prev) })
+
+			task.ListID = ""
+			s.tasks[taskID] = task
+		}
+	}
+
+	return nil
+}
+
+// restoreList puts back a list as it was before a rolled back write, nil removes it
+func (s *TodoStore) restoreList(id string, list *models.List) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if list == nil {
+		delete(s.lists, id)
+
+		return
+	}
+
+	s.lists[id] = *list
+}
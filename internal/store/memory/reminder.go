@@ -0,0 +1,249 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (s *TodoStore) AddReminder(ctx context.Context, rem *models.Reminder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.reminders[rem.ID]; ok {
+		return models.NewConstError("reminder already exists")
+	}
+
+	s.reminders[rem.ID] = *rem
+	s.bump(ctx, rem.TaskID)
+
+	id := rem.ID
+	onRollback(ctx, func() { s.restoreReminder(id, nil) })
+
+	return nil
+}
+
+func (s *TodoStore) RemoveReminder(ctx context.Context, rem *models.Reminder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bump(ctx, rem.TaskID)
+
+	prev, ok := s.reminders[rem.ID]
+	if !ok || prev.TaskID != rem.TaskID || prev.UserID != rem.UserID {
+		return nil
+	}
+
+	delete(s.reminders, rem.ID)
+	onRollback(ctx, func() { s.restoreReminder(prev.ID, &prev) })
+
+	return nil
+}
+
+// DueReminders returns at most limit unsent reminders of every user due at now, the oldest first.
+// Reminders of trashed, done or cancelled tasks are left out.
+func (s *TodoStore) DueReminders(_ context.Context, now time.Time, limit int) ([]models.DueReminder, error) {
+	s.mu.RLock()
+
+	res := make([]models.DueReminder, 0)
+
+	for _, rem := range s.reminders {
+		task, ok := s.tasks[rem.TaskID]
+		if !ok || rem.SentAt != nil || task.DeletedAt != nil || !task.Status.IsOpen() {
+			continue
+		}
+
+		if at, ok := rem.FireAt(task.DueDate); ok && !at.After(now) {
+			res = append(res, models.DueReminder{Reminder: rem, Task: task})
+		}
+	}
+
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		a, _ := res[i].FireAt(res[i].Task.DueDate)
+		b, _ := res[j].FireAt(res[j].Task.DueDate)
+
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+
+		return res[i].ID < res[j].ID
+	})
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+// MarkReminderSent records that a reminder fired at sentAt, it is false when it was already sent
+func (s *TodoStore) MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rem, ok := s.reminders[id]
+	if !ok || rem.SentAt != nil {
+		return false, nil
+	}
+
+	prev := rem
+	rem.SentAt = &sentAt
+	s.reminders[id] = rem
+	onRollback(ctx, func() { s.restoreReminder(id, &prev) })
+
+	return true, nil
+}
+
+// ResetReminders lets the relative reminders of a task fire again once its due date moved past them
+func (s *TodoStore) ResetReminders(ctx context.Context, taskID string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return nil
+	}
+
+	for id, rem := range s.reminders {
+		if rem.TaskID != taskID || rem.At != nil || rem.SentAt == nil {
+			continue
+		}
+
+		if at, ok := rem.FireAt(task.DueDate); ok && at.After(now) {
+			prev := rem
+			rem.SentAt = nil
+			s.reminders[id] = rem
+			onRollback(ctx, func() { s.restoreReminder(id, &prev) })
+		}
+	}
+
+	return nil
+}
+
+// withReminders returns task carrying its reminders, the caller holds the lock
+func (s *TodoStore) withReminders(task models.Task) models.Task {
+	task.Reminders = make([]models.Reminder, 0)
+
+	for _, rem := range s.reminders {
+		if rem.TaskID == task.ID {
+			task.Reminders = append(task.Reminders, rem)
+		}
+	}
+
+	sort.Slice(task.Reminders, func(i, j int) bool {
+		a, b := task.Reminders[i], task.Reminders[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+
+		return a.ID < b.ID
+	})
+
+	return task
+}
+
+// dropReminders forgets every reminder of the purged task id, the caller holds the lock
+func (s *TodoStore) dropReminders(ctx context.Context, taskID string) {
+	for id, rem := range s.reminders {
+		if rem.TaskID == taskID {
+			delete(s.reminders, id)
+			onRollback(ctx, func() { s.restoreReminder(id, &rem) })
+		}
+	}
+}
+
+// restoreReminder puts back a reminder as it was before a rolled back write, nil removes it
+func (s *TodoStore) restoreReminder(id string, rem *models.Reminder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rem == nil {
+		delete(s.reminders, id)
+
+		return
+	}
+
+	s.reminders[id] = *rem
+}
+
+func (s *TodoStore) AddNotification(ctx context.Context, n *models.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notifications[n.ID] = *n
+
+	id := n.ID
+	onRollback(ctx, func() { s.restoreNotification(id, nil) })
+
+	return nil
+}
+
+// ListNotifications returns the user's last limit notifications, the newest first
+func (s *TodoStore) ListNotifications(_ context.Context, limit int, userID *uuid.UUID) ([]models.Notification, error) {
+	s.mu.RLock()
+
+	res := make([]models.Notification, 0)
+
+	for _, n := range s.notifications {
+		if n.UserID == *userID {
+			res = append(res, n)
+		}
+	}
+
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.After(res[j].CreatedAt)
+		}
+
+		return res[i].ID > res[j].ID
+	})
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+// ReadNotification marks a notification of the user as read at readAt, reading it twice keeps the first time
+func (s *TodoStore) ReadNotification(ctx context.Context, id string, readAt time.Time, userID *uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.notifications[id]
+	if !ok || n.UserID != *userID {
+		return models.ErrNotFound("notification")
+	}
+
+	if n.ReadAt != nil {
+		return nil
+	}
+
+	prev := n
+	n.ReadAt = &readAt
+	s.notifications[id] = n
+	onRollback(ctx, func() { s.restoreNotification(id, &prev) })
+
+	return nil
+}
+
+func (s *TodoStore) restoreNotification(id string, n *models.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n == nil {
+		delete(s.notifications, id)
+
+		return
+	}
+
+	s.notifications[id] = *n
+}
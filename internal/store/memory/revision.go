@@ -0,0 +1,67 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Get returns a task of the user, trashed tasks are not found
+func (s *TodoStore) Get(_ context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, ok := s.tasks[id]
+	if !ok || task.UserID != *userID || task.DeletedAt != nil {
+		return nil, models.ErrNotFound("task")
+	}
+
+	task = s.withDetails(task)
+
+	return &task, nil
+}
+
+// AddRevision stores rev as the next revision of its task and sets its number
+func (s *TodoStore) AddRevision(ctx context.Context, rev *models.Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.revisions[rev.TaskID]
+	onRollback(ctx, func() { s.setRevisions(rev.TaskID, prev) })
+
+	rev.Number = int64(len(prev)) + 1
+	s.revisions[rev.TaskID] = append(slices.Clip(prev), *rev)
+
+	return nil
+}
+
+// ListRevisions returns the revisions of a task, newest first
+func (s *TodoStore) ListRevisions(_ context.Context, taskID string) ([]models.Revision, error) {
+	s.mu.RLock()
+	res := slices.Clone(s.revisions[taskID])
+	s.mu.RUnlock()
+
+	slices.Reverse(res)
+
+	if res == nil {
+		res = make([]models.Revision, 0)
+	}
+
+	return res, nil
+}
+
+func (s *TodoStore) setRevisions(taskID string, revs []models.Revision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if revs == nil {
+		delete(s.revisions, taskID)
+
+		return
+	}
+
+	s.revisions[taskID] = revs
+}
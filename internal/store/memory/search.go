@@ -0,0 +1,132 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Search returns at most limit tasks of the user whose title or description has a word
+// starting with every term, ranked by the number of matched words with title words
+// weighing ten times more
+func (s *TodoStore) Search(_ context.Context, terms []string, limit int, userID *uuid.UUID) ([]models.SearchResult, error) {
+	type scored struct {
+		models.SearchResult
+		score int
+	}
+
+	res := make([]scored, 0)
+
+	s.mu.RLock()
+
+	for id := range s.tasks {
+		task := s.withDetails(s.tasks[id])
+		if task.UserID != *userID || task.DeletedAt != nil {
+			continue
+		}
+
+		title, titleHits := highlight(task.Title, terms)
+		desc, descHits := highlight(task.Description, terms)
+
+		if !allMatched(terms, titleHits, descHits) {
+			continue
+		}
+
+		res = append(res, scored{
+			SearchResult: models.SearchResult{Task: task, TitleSnippet: title, DescriptionSnippet: desc},
+			score:        10*total(titleHits) + total(descHits),
+		})
+	}
+
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].score != res[j].score {
+			return res[i].score > res[j].score
+		}
+
+		return res[i].Task.AddedAt.Before(res[j].Task.AddedAt)
+	})
+
+	out := make([]models.SearchResult, 0, min(limit, len(res)))
+	for i := 0; i < len(res) && i < limit; i++ {
+		out = append(out, res[i].SearchResult)
+	}
+
+	return out, nil
+}
+
+// highlight marks the words of text starting with one of the terms and counts the hits per term
+func highlight(text string, terms []string) (string, map[string]int) {
+	var (
+		b     strings.Builder
+		hits  = make(map[string]int)
+		runes = []rune(text)
+	)
+
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			b.WriteRune(runes[i])
+			i++
+
+			continue
+		}
+
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+
+		word := string(runes[i:j])
+		if countHits(strings.ToLower(word), terms, hits) {
+			word = models.HighlightStart + word + models.HighlightEnd
+		}
+
+		b.WriteString(word)
+		i = j
+	}
+
+	return b.String(), hits
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// countHits records every term word starts with and reports whether there was one
+func countHits(word string, terms []string, hits map[string]int) bool {
+	matched := false
+
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			hits[term]++
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+func allMatched(terms []string, a, b map[string]int) bool {
+	for _, term := range terms {
+		if a[term]+b[term] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func total(hits map[string]int) int {
+	n := 0
+	for _, h := range hits {
+		n += h
+	}
+
+	return n
+}
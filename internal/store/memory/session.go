@@ -0,0 +1,120 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SessionStore keeps one session per user in process memory, like the sessions table it
+// rejects a second session for the same user or a reused token.
+type SessionStore struct {
+	mu       sync.RWMutex
+	sessions map[uuid.UUID]models.SessionData
+}
+
+func NewSessionStore() *SessionStore {
+	return &SessionStore{sessions: make(map[uuid.UUID]models.SessionData)}
+}
+
+func (s *SessionStore) CreateSession(ctx context.Context, session *models.SessionData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.sessions {
+		if s.sessions[id].UserID == session.UserID || s.sessions[id].Token == session.Token {
+			return models.NewConstError("session already exists")
+		}
+	}
+
+	s.sessions[session.ID] = *session
+
+	onRollback(ctx, func() { s.restore(session.ID, nil) })
+
+	return nil
+}
+
+func (s *SessionStore) GetSessionByID(_ context.Context, userID *uuid.UUID) (*models.SessionData, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for id := range s.sessions {
+		if s.sessions[id].UserID == *userID {
+			session := s.sessions[id]
+
+			return &session, nil
+		}
+	}
+
+	return nil, models.ErrNotFound("user ID")
+}
+
+func (s *SessionStore) RefreshSession(ctx context.Context, newSession *models.SessionData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[newSession.ID]
+	if !ok {
+		return nil
+	}
+
+	prev := session
+	onRollback(ctx, func() { s.restore(prev.ID, &prev) })
+
+	session.Token = newSession.Token
+	session.Expiry = newSession.Expiry
+
+	s.sessions[newSession.ID] = session
+
+	return nil
+}
+
+func (s *SessionStore) Logout(ctx context.Context, token *uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.sessions {
+		if s.sessions[id].Token == token.String() {
+			prev := s.sessions[id]
+
+			delete(s.sessions, id)
+			onRollback(ctx, func() { s.restore(prev.ID, &prev) })
+
+			return nil
+		}
+	}
+
+	return models.ErrNotFound("session with current user")
+}
+
+func (s *SessionStore) GetUserIDByToken(_ context.Context, token *uuid.UUID) (*uuid.UUID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for id := range s.sessions {
+		if s.sessions[id].Token == token.String() {
+			uid := s.sessions[id].UserID
+
+			return &uid, nil
+		}
+	}
+
+	return nil, models.ErrInvalidCookie
+}
+
+// restore puts back a session as it was before a rolled back write, nil removes it
+func (s *SessionStore) restore(id uuid.UUID, session *models.SessionData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session == nil {
+		delete(s.sessions, id)
+
+		return
+	}
+
+	s.sessions[id] = *session
+}
@@ -0,0 +1,133 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoStore(t *testing.T) {
+	ctx := context.Background()
+	st := NewTodoStore()
+	user, other := uuid.New(), uuid.New()
+	dd := time.Now()
+	task := models.Task{ID: "task-" + uuid.NewString(), UserID: user, Title: "hello", DueDate: &dd, AddedAt: time.Now()}
+
+	require.NoError(t, st.Create(ctx, &task))
+	assert.Error(t, st.Create(ctx, &task))
+
+	got, err := st.GetAll(ctx, &models.TaskQuery{Sort: []models.SortKey{{Field: models.SortAddedAt}}, Limit: 10}, &other)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	task.Title = "updated"
+	task.SetStatus(models.StatusDone, time.Now())
+	require.NoError(t, st.Update(ctx, &task))
+
+	done, err := st.Get(ctx, task.ID, &user)
+	require.NoError(t, err)
+	assert.True(t, done.IsDone)
+	assert.NotNil(t, done.CompletedAt)
+	assert.Equal(t, "updated", done.Title)
+
+	_, err = st.Get(ctx, task.ID, &other)
+	assert.True(t, errors.Is(err, models.ErrNotFound("task")))
+
+	require.NoError(t, st.Delete(ctx, task.ID, done.Version, &user))
+
+	got, err = st.GetAll(ctx, &models.TaskQuery{Sort: []models.SortKey{{Field: models.SortAddedAt}}, Limit: 10}, &user)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestUserStore(t *testing.T) {
+	ctx := context.Background()
+	st := NewUserStore()
+	user := models.UserData{ID: uuid.New(), Name: "hello", Email: "abcd@abcd.com", Password: "hash"}
+
+	_, err := st.GetUserByEmail(ctx, user.Email)
+	assert.Equal(t, models.ErrUserNotFound, err)
+
+	require.NoError(t, st.RegisterUser(ctx, &user))
+	assert.Equal(t, models.ErrUserAlreadyExists, st.RegisterUser(ctx, &user))
+
+	got, err := st.GetUserByEmail(ctx, user.Email)
+	require.NoError(t, err)
+	assert.Equal(t, user, *got)
+
+	require.NoError(t, st.SetTimeZone(ctx, &user.ID, "Asia/Kolkata"))
+
+	got, err = st.GetUserByID(ctx, &user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Kolkata", got.TimeZone)
+
+	unknown := uuid.New()
+	assert.Equal(t, models.ErrUserNotFound, st.SetTimeZone(ctx, &unknown, "UTC"))
+}
+
+func TestSessionStore(t *testing.T) {
+	ctx := context.Background()
+	st := NewSessionStore()
+	token := uuid.New()
+	session := models.SessionData{ID: uuid.New(), UserID: uuid.New(), Token: token.String(), Expiry: time.Now()}
+
+	require.NoError(t, st.CreateSession(ctx, &session))
+	assert.Error(t, st.CreateSession(ctx, &session))
+
+	uid, err := st.GetUserIDByToken(ctx, &token)
+	require.NoError(t, err)
+	assert.Equal(t, session.UserID, *uid)
+
+	newToken := uuid.New()
+	session.Token = newToken.String()
+	require.NoError(t, st.RefreshSession(ctx, &session))
+
+	_, err = st.GetUserIDByToken(ctx, &token)
+	assert.Equal(t, models.ErrInvalidCookie, err)
+
+	got, err := st.GetSessionByID(ctx, &session.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, newToken.String(), got.Token)
+
+	require.NoError(t, st.Logout(ctx, &newToken))
+	assert.Error(t, st.Logout(ctx, &newToken))
+
+	_, err = st.GetSessionByID(ctx, &session.UserID)
+	assert.Error(t, err)
+}
+
+func TestTokens(t *testing.T) {
+	ctx := context.Background()
+	st := NewUserStore()
+	user, other := uuid.New(), uuid.New()
+	token := models.AccessToken{ID: "tok-a", UserID: user, Name: "CI", Hash: "hash-a",
+		Scopes: []models.Scope{models.ScopeTasksRead}, CreatedAt: time.Now()}
+
+	require.NoError(t, st.CreateToken(ctx, &token))
+	assert.Error(t, st.CreateToken(ctx, &token))
+
+	got, err := st.GetTokenByHash(ctx, "hash-a")
+	require.NoError(t, err)
+	assert.Equal(t, token, *got)
+
+	at := time.Now()
+	require.NoError(t, st.TouchToken(ctx, token.ID, at))
+
+	tokens, err := st.ListTokens(ctx, &user)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, at, *tokens[0].LastUsedAt)
+
+	assert.Equal(t, models.ErrNotFound("token"), st.DeleteToken(ctx, token.ID, &other))
+	require.NoError(t, st.DeleteToken(ctx, token.ID, &user))
+
+	_, err = st.GetTokenByHash(ctx, "hash-a")
+	assert.Equal(t, models.ErrNotFound("token"), err)
+}
@@ -0,0 +1,46 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// GetChildren returns the direct subtasks of the given tasks of the user, in no particular order
+func (s *TodoStore) GetChildren(_ context.Context, parentIDs []string, userID *uuid.UUID) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]models.Task, 0)
+
+	for _, task := range s.tasks {
+		if task.UserID == *userID && task.DeletedAt == nil && task.ParentID != "" && slices.Contains(parentIDs, task.ParentID) {
+			res = append(res, s.withDetails(task))
+		}
+	}
+
+	return res, nil
+}
+
+// withDetails returns task carrying its tags, subtask progress, dependencies and reminders, the caller holds the lock
+func (s *TodoStore) withDetails(task models.Task) models.Task {
+	task = s.withReminders(s.withDependencies(s.withTags(task)))
+	task.Progress = models.Progress{}
+
+	for _, child := range s.tasks {
+		if child.ParentID != task.ID || child.DeletedAt != nil || child.Status == models.StatusCancelled {
+			continue
+		}
+
+		task.Progress.Total++
+
+		if child.Status == models.StatusDone {
+			task.Progress.Completed++
+		}
+	}
+
+	return task
+}
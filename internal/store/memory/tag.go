@@ -0,0 +1,195 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (s *TodoStore) CreateTag(ctx context.Context, tag *models.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tags[tag.ID]; ok {
+		return models.ErrTagAlreadyExists
+	}
+
+	for _, t := range s.tags {
+		if t.UserID == tag.UserID && t.Name == tag.Name {
+			return models.ErrTagAlreadyExists
+		}
+	}
+
+	s.tags[tag.ID] = *tag
+
+	id := tag.ID
+	onRollback(ctx, func() { s.restoreTag(id, nil) })
+
+	return nil
+}
+
+// ListTags returns the user's tags ordered by name
+func (s *TodoStore) ListTags(_ context.Context, userID *uuid.UUID) ([]models.Tag, error) {
+	s.mu.RLock()
+
+	res := make([]models.Tag, 0)
+
+	for _, tag := range s.tags {
+		if tag.UserID == *userID {
+			res = append(res, tag)
+		}
+	}
+
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+
+	return res, nil
+}
+
+func (s *TodoStore) GetTag(_ context.Context, id string, userID *uuid.UUID) (*models.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tag, ok := s.tags[id]
+	if !ok || tag.UserID != *userID {
+		return nil, models.ErrNotFound("tag")
+	}
+
+	return &tag, nil
+}
+
+func (s *TodoStore) GetTagByName(_ context.Context, name string, userID *uuid.UUID) (*models.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, tag := range s.tags {
+		if tag.UserID == *userID && tag.Name == name {
+			return &tag, nil
+		}
+	}
+
+	return nil, models.ErrNotFound("tag")
+}
+
+func (s *TodoStore) RenameTag(ctx context.Context, tag *models.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.tags[tag.ID]
+	if !ok || existing.UserID != tag.UserID {
+		return nil
+	}
+
+	prev := existing
+	onRollback(ctx, func() { s.restoreTag(prev.ID, &prev) })
+
+	existing.Name = tag.Name
+	s.tags[tag.ID] = existing
+
+	return nil
+}
+
+// DeleteTag deletes the tag and takes it off every task carrying it
+func (s *TodoStore) DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tag, ok := s.tags[id]
+	if !ok || tag.UserID != *userID {
+		return nil
+	}
+
+	delete(s.tags, id)
+	onRollback(ctx, func() { s.restoreTag(id, &tag) })
+
+	for taskID, tagIDs := range s.taskTags {
+		if i := slices.Index(tagIDs, id); i >= 0 {
+			s.taskTags[taskID] = slices.Delete(slices.Clone(tagIDs), i, i+1)
+			onRollback(ctx, func() { s.setTaskTags(taskID, tagIDs) })
+		}
+	}
+
+	return nil
+}
+
+// TagTask attaches the tag to the task, attaching it twice does nothing
+func (s *TodoStore) TagTask(ctx context.Context, taskID, tagID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bump(ctx, taskID)
+
+	prev := s.taskTags[taskID]
+	if slices.Contains(prev, tagID) {
+		return nil
+	}
+
+	s.taskTags[taskID] = append(slices.Clip(prev), tagID)
+	onRollback(ctx, func() { s.setTaskTags(taskID, prev) })
+
+	return nil
+}
+
+func (s *TodoStore) UntagTask(ctx context.Context, taskID, tagID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bump(ctx, taskID)
+
+	prev := s.taskTags[taskID]
+
+	i := slices.Index(prev, tagID)
+	if i < 0 {
+		return nil
+	}
+
+	s.taskTags[taskID] = slices.Delete(slices.Clone(prev), i, i+1)
+	onRollback(ctx, func() { s.setTaskTags(taskID, prev) })
+
+	return nil
+}
+
+// withTags returns task carrying its tags ordered by name, the caller holds the lock
+func (s *TodoStore) withTags(task models.Task) models.Task {
+	task.Tags = make([]models.Tag, 0, len(s.taskTags[task.ID]))
+
+	for _, id := range s.taskTags[task.ID] {
+		task.Tags = append(task.Tags, s.tags[id])
+	}
+
+	sort.Slice(task.Tags, func(i, j int) bool { return task.Tags[i].Name < task.Tags[j].Name })
+
+	return task
+}
+
+// restoreTag puts back a tag as it was before a rolled back write, nil removes it
+func (s *TodoStore) restoreTag(id string, tag *models.Tag) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tag == nil {
+		delete(s.tags, id)
+
+		return
+	}
+
+	s.tags[id] = *tag
+}
+
+func (s *TodoStore) setTaskTags(taskID string, tagIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tagIDs == nil {
+		delete(s.taskTags, taskID)
+
+		return
+	}
+
+	s.taskTags[taskID] = tagIDs
+}
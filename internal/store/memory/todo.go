@@ -0,0 +1,247 @@
+package memstore
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// TodoStore keeps the tasks in process memory, everything is lost when the server stops
+type TodoStore struct {
+	mu        sync.RWMutex
+	tasks     map[string]models.Task
+	revisions map[string][]models.Revision
+	tags      map[string]models.Tag
+	taskTags  map[string][]string
+	lists     map[string]models.List
+	// blockers are the ids of the tasks each task waits for, depMu is held by the unit of work
+	// checking them for a cycle
+	blockers map[string][]string
+	depMu    sync.Mutex
+	// reminders and notifications are keyed by their own id
+	reminders     map[string]models.Reminder
+	notifications map[string]models.Notification
+}
+
+func NewTodoStore() *TodoStore {
+	return &TodoStore{
+		tasks:         make(map[string]models.Task),
+		revisions:     make(map[string][]models.Revision),
+		tags:          make(map[string]models.Tag),
+		taskTags:      make(map[string][]string),
+		lists:         make(map[string]models.List),
+		blockers:      make(map[string][]string),
+		reminders:     make(map[string]models.Reminder),
+		notifications: make(map[string]models.Notification),
+	}
+}
+
+func (s *TodoStore) GetAll(ctx context.Context, q *models.TaskQuery, userID *uuid.UUID) ([]models.Task, error) {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	s.mu.RLock()
+
+	res := make([]models.Task, 0)
+
+	for id := range s.tasks {
+		task := s.withDetails(s.tasks[id])
+
+		if task.UserID == *userID && task.DeletedAt == nil && q.Filter.Match(&task) && q.IsAfter(&task) {
+			res = append(res, task)
+		}
+	}
+
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		return q.Less(&res[i], &res[j])
+	})
+
+	if len(res) > q.Limit {
+		res = res[:q.Limit]
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "get all tasks", slog.String("user", userID.String()))
+
+	return res, nil
+}
+
+func (s *TodoStore) Create(ctx context.Context, task *models.Task) error {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tasks[task.ID]; ok {
+		return models.NewConstError("task already exists")
+	}
+
+	task.Version = 1
+	s.tasks[task.ID] = *task
+
+	id := task.ID
+	onRollback(ctx, func() { s.restore(id, nil) })
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "task added successfully",
+		slog.String("task", task.ID),
+	)
+
+	return nil
+}
+
+// Update writes the task, it is refused with ErrVersionMismatch when the task changed since it was read
+func (s *TodoStore) Update(ctx context.Context, task *models.Task) error {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.versioned(task.ID, task.UserID, task.Version)
+	if err != nil {
+		return err
+	}
+
+	prev := existing
+	onRollback(ctx, func() { s.restore(prev.ID, &prev) })
+
+	existing.Title = task.Title
+	existing.Description = task.Description
+	existing.IsDone = task.IsDone
+	existing.Status = task.Status
+	existing.Priority = task.Priority
+	existing.DueDate = task.DueDate
+	existing.HasDueTime = task.HasDueTime
+	existing.CompletedAt = task.CompletedAt
+	existing.ModifiedAt = task.ModifiedAt
+	existing.ListID = task.ListID
+	existing.ParentID = task.ParentID
+	existing.Recurrence = task.Recurrence
+	existing.Occurrence = task.Occurrence
+	existing.Version++
+
+	s.tasks[task.ID] = existing
+	task.Version = existing.Version
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "task updated successfully",
+		slog.String("task", task.ID))
+
+	return nil
+}
+
+// Patch writes the fields of the task a partial update changed, along with its modification time. Like
+// Update it is refused when the task changed since it was read.
+func (s *TodoStore) Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.versioned(task.ID, task.UserID, task.Version)
+	if err != nil {
+		return err
+	}
+
+	prev := existing
+	onRollback(ctx, func() { s.restore(prev.ID, &prev) })
+
+	existing.ModifiedAt = task.ModifiedAt
+	existing.Version++
+
+	for _, f := range fields {
+		switch f {
+		case models.FieldTitle:
+			existing.Title = task.Title
+		case models.FieldDescription:
+			existing.Description = task.Description
+		case models.FieldDue:
+			existing.DueDate, existing.HasDueTime = task.DueDate, task.HasDueTime
+		case models.FieldPriority:
+			existing.Priority = task.Priority
+		case models.FieldList:
+			existing.ListID = task.ListID
+		case models.FieldParent:
+			existing.ParentID = task.ParentID
+		case models.FieldRecurrence:
+			existing.Recurrence, existing.Occurrence = task.Recurrence, task.Occurrence
+		}
+	}
+
+	s.tasks[task.ID] = existing
+	task.Version = existing.Version
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "task patched successfully",
+		slog.String("task", task.ID))
+
+	return nil
+}
+
+// Delete moves the version of the task read to the trash
+func (s *TodoStore) Delete(ctx context.Context, id string, version int64, userID *uuid.UUID) error {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, err := s.versioned(id, *userID, version)
+	if err != nil {
+		return err
+	}
+
+	prev := task
+	onRollback(ctx, func() { s.restore(id, &prev) })
+
+	dt := time.Now()
+	task.DeletedAt = &dt
+	task.Version++
+	s.tasks[id] = task
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "task moved to trash", slog.String("task", id))
+
+	return nil
+}
+
+// versioned returns the stored task a write of the version read applies to, like the SQL store it
+// refuses a task that changed or left since, the caller holds the lock
+func (s *TodoStore) versioned(id string, userID uuid.UUID, version int64) (models.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok || task.UserID != userID || task.DeletedAt != nil || task.Version != version {
+		return models.Task{}, models.ErrVersionMismatch
+	}
+
+	return task, nil
+}
+
+// bump counts a write to the tags, reminders or blockers of a task as a write of the task, the
+// caller holds the lock
+func (s *TodoStore) bump(ctx context.Context, id string) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return
+	}
+
+	prev := task
+	onRollback(ctx, func() { s.restore(id, &prev) })
+
+	task.Version++
+	s.tasks[id] = task
+}
+
+// restore puts back a task as it was before a rolled back write, nil removes it
+func (s *TodoStore) restore(id string, task *models.Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if task == nil {
+		delete(s.tasks, id)
+
+		return
+	}
+
+	s.tasks[id] = *task
+}
@@ -0,0 +1,103 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (s *UserStore) CreateToken(ctx context.Context, t *models.AccessToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, other := range s.tokens {
+		if other.Hash == t.Hash {
+			return models.NewConstError("token already exists")
+		}
+	}
+
+	token := *t
+	token.Scopes = slices.Clone(t.Scopes)
+	s.tokens[t.ID] = token
+
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		delete(s.tokens, token.ID)
+		s.mu.Unlock()
+	})
+
+	return nil
+}
+
+// ListTokens returns the user's access tokens, the oldest first
+func (s *UserStore) ListTokens(_ context.Context, userID *uuid.UUID) ([]models.AccessToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]models.AccessToken, 0)
+
+	for _, t := range s.tokens {
+		if t.UserID == *userID {
+			res = append(res, t)
+		}
+	}
+
+	slices.SortFunc(res, func(a, b models.AccessToken) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return res, nil
+}
+
+// GetTokenByHash returns the access token whose token hashes to hash
+func (s *UserStore) GetTokenByHash(_ context.Context, hash string) (*models.AccessToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.tokens {
+		if t.Hash == hash {
+			return &t, nil
+		}
+	}
+
+	return nil, models.ErrNotFound("token")
+}
+
+// DeleteToken revokes an access token of the user
+func (s *UserStore) DeleteToken(ctx context.Context, id string, userID *uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tokens[id]
+	if !ok || t.UserID != *userID {
+		return models.ErrNotFound("token")
+	}
+
+	delete(s.tokens, id)
+
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		s.tokens[id] = t
+		s.mu.Unlock()
+	})
+
+	return nil
+}
+
+// TouchToken records that the token was used at at
+func (s *UserStore) TouchToken(_ context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.tokens[id]; ok {
+		t.LastUsedAt = &at
+		s.tokens[id] = t
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package memstore
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ListTrash returns the user's trashed tasks, most recently deleted first
+func (s *TodoStore) ListTrash(_ context.Context, userID *uuid.UUID) ([]models.Task, error) {
+	s.mu.RLock()
+
+	res := make([]models.Task, 0)
+
+	for id := range s.tasks {
+		if s.tasks[id].UserID == *userID && s.tasks[id].DeletedAt != nil {
+			res = append(res, s.withDetails(s.tasks[id]))
+		}
+	}
+
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].DeletedAt.Equal(*res[j].DeletedAt) {
+			return res[i].DeletedAt.After(*res[j].DeletedAt)
+		}
+
+		return res[i].ID < res[j].ID
+	})
+
+	return res, nil
+}
+
+// Restore takes a task out of the trash
+func (s *TodoStore) Restore(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[id]
+	if !ok || task.UserID != *userID || task.DeletedAt == nil {
+		return nil, models.ErrNotFound("task in trash")
+	}
+
+	prev := task
+	onRollback(ctx, func() { s.restore(id, &prev) })
+
+	mt := time.Now()
+	task.DeletedAt, task.ModifiedAt = nil, &mt
+	task.Version++
+	s.tasks[id] = task
+	task = s.withDetails(task)
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelDebug, "task restored from trash", slog.String("task", id))
+
+	return &task, nil
+}
+
+// Purge permanently deletes the tasks of every user trashed before the given time
+func (s *TodoStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+
+	for id, task := range s.tasks {
+		if task.DeletedAt != nil && task.DeletedAt.Before(before) {
+			revs, tagIDs := s.revisions[id], s.taskTags[id]
+			delete(s.tasks, id)
+			delete(s.revisions, id)
+			delete(s.taskTags, id)
+			s.dropDependencies(ctx, id)
+			s.dropReminders(ctx, id)
+			onRollback(ctx, func() {
+				s.restore(id, &task)
+				s.setRevisions(id, revs)
+				s.setTaskTags(id, tagIDs)
+			})
+
+			n++
+		}
+	}
+
+	return n, nil
+}
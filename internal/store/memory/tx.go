@@ -0,0 +1,92 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+)
+
+type txKey struct{}
+
+// undoLog collects the compensating actions of the writes made inside a transaction and the
+// locks held until it ends
+type undoLog struct {
+	mu      sync.Mutex
+	undo    []func()
+	release []func()
+}
+
+// Transactor gives the in-memory stores all-or-nothing units of work: every write made
+// through a ctx handed out by WithinTx is reverted when the unit of work fails.
+// Unlike a database transaction it offers no isolation from concurrent requests.
+type Transactor struct{}
+
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+func (*Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*undoLog); ok {
+		return fn(ctx)
+	}
+
+	log := &undoLog{}
+	defer log.end()
+
+	if err := fn(context.WithValue(ctx, txKey{}, log)); err != nil {
+		log.rollback()
+
+		return err
+	}
+
+	return nil
+}
+
+// onRollback registers fn to be run if the transaction carried by ctx is rolled back,
+// outside a transaction it does nothing
+func onRollback(ctx context.Context, fn func()) {
+	log, ok := ctx.Value(txKey{}).(*undoLog)
+	if !ok {
+		return
+	}
+
+	log.mu.Lock()
+	log.undo = append(log.undo, fn)
+	log.mu.Unlock()
+}
+
+// holdUntilEnd locks mu until the transaction carried by ctx ends, outside a transaction it does nothing
+func holdUntilEnd(ctx context.Context, mu *sync.Mutex) {
+	log, ok := ctx.Value(txKey{}).(*undoLog)
+	if !ok {
+		return
+	}
+
+	mu.Lock()
+
+	log.mu.Lock()
+	log.release = append(log.release, mu.Unlock)
+	log.mu.Unlock()
+}
+
+func (l *undoLog) rollback() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := len(l.undo) - 1; i >= 0; i-- {
+		l.undo[i]()
+	}
+
+	l.undo = nil
+}
+
+// end releases the locks held by the transaction, it runs once the writes are kept or undone
+func (l *undoLog) end() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := len(l.release) - 1; i >= 0; i-- {
+		l.release[i]()
+	}
+
+	l.release = nil
+}
@@ -0,0 +1,82 @@
+package memstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactorRollback(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTransactor()
+	users, sessions := NewUserStore(), NewSessionStore()
+	user := models.UserData{ID: uuid.New(), Name: "hello", Email: "abcd@abcd.com", Password: "hash"}
+	session := models.SessionData{ID: uuid.New(), UserID: uuid.New(), Token: uuid.NewString(), Expiry: time.Now()}
+
+	require.NoError(t, sessions.CreateSession(ctx, &session))
+
+	err := tr.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, users.RegisterUser(ctx, &user))
+
+		// same token as the existing session, so the whole unit of work is undone
+		return sessions.CreateSession(ctx, &models.SessionData{ID: uuid.New(), UserID: user.ID, Token: session.Token})
+	})
+	assert.Error(t, err)
+
+	_, err = users.GetUserByEmail(ctx, user.Email)
+	assert.Equal(t, models.ErrUserNotFound, err)
+
+	err = tr.WithinTx(ctx, func(ctx context.Context) error {
+		return users.RegisterUser(ctx, &user)
+	})
+	require.NoError(t, err)
+
+	_, err = users.GetUserByEmail(ctx, user.Email)
+	assert.NoError(t, err)
+}
+
+func TestLockDependencies(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTransactor()
+	s := NewTodoStore()
+	user := uuid.New()
+	locked, ended := make(chan struct{}), make(chan struct{})
+
+	go func() {
+		_ = tr.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, s.LockDependencies(ctx, &user))
+			close(locked)
+			time.Sleep(50 * time.Millisecond)
+			close(ended)
+
+			return nil
+		})
+	}()
+
+	<-locked
+
+	// the second unit of work only gets the lock once the first one has ended
+	err := tr.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, s.LockDependencies(ctx, &user))
+
+		select {
+		case <-ended:
+		default:
+			t.Error("lock taken while another unit of work holds it")
+		}
+
+		return models.ErrDependencyCycle
+	})
+	assert.Equal(t, models.ErrDependencyCycle, err)
+
+	// a failed unit of work releases the lock as well
+	require.NoError(t, tr.WithinTx(ctx, func(ctx context.Context) error {
+		return s.LockDependencies(ctx, &user)
+	}))
+}
@@ -0,0 +1,98 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// UserStore keeps the registered users in process memory, keyed by email, along with their
+// access tokens keyed by id
+type UserStore struct {
+	mu     sync.RWMutex
+	users  map[string]models.UserData
+	tokens map[string]models.AccessToken
+}
+
+func NewUserStore() *UserStore {
+	return &UserStore{users: make(map[string]models.UserData), tokens: make(map[string]models.AccessToken)}
+}
+
+func (s *UserStore) RegisterUser(ctx context.Context, data *models.UserData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[data.Email]; ok {
+		return models.ErrUserAlreadyExists
+	}
+
+	s.users[data.Email] = *data
+
+	email := data.Email
+	onRollback(ctx, func() {
+		s.mu.Lock()
+		delete(s.users, email)
+		s.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (s *UserStore) GetUserByEmail(_ context.Context, email string) (*models.UserData, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[email]
+	if !ok {
+		return nil, models.ErrUserNotFound
+	}
+
+	return &user, nil
+}
+
+// GetUserByID returns the user with the given id
+func (s *UserStore) GetUserByID(_ context.Context, id *uuid.UUID) (*models.UserData, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if user.ID == *id {
+			return &user, nil
+		}
+	}
+
+	return nil, models.ErrUserNotFound
+}
+
+// SetTimeZone changes the time zone the user's due dates are shown in
+func (s *UserStore) SetTimeZone(ctx context.Context, id *uuid.UUID, timeZone string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for email, user := range s.users {
+		if user.ID != *id {
+			continue
+		}
+
+		prev := user.TimeZone
+		user.TimeZone = timeZone
+		s.users[email] = user
+
+		onRollback(ctx, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			if u, ok := s.users[email]; ok {
+				u.TimeZone = prev
+				s.users[email] = u
+			}
+		})
+
+		return nil
+	}
+
+	return models.ErrUserNotFound
+}
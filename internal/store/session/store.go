@@ -2,44 +2,43 @@ package sessionstore
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
-	"time"
 
+	"todoapp/internal/database"
 	"todoapp/internal/models"
 
 	"github.com/google/uuid"
-	"github.com/sqlitecloud/sqlitecloud-go"
 )
 
 const (
-	createSession      = "INSERT INTO sessions (id, user_id, token, expiry) VALUES ('%v', '%v', '%v','%v');"
-	deleteSessionByID  = "DELETE FROM sessions WHERE id='%v';"
-	getSessionByUserID = "SELECT id, user_id, token, expiry FROM sessions WHERE user_id='%v';"
+	createSession      = "INSERT INTO sessions (id, user_id, token, expiry) VALUES (?, ?, ?, ?);"
+	deleteSessionByID  = "DELETE FROM sessions WHERE id=?;"
+	getSessionByUserID = "SELECT id, user_id, token, expiry FROM sessions WHERE user_id=?;"
 	//nolint:gosec //not any hardcoded credential
-	getSessionByToken = "SELECT id FROM sessions where token='%v';"
-	updateSession     = "UPDATE sessions SET token='%v',  expiry='%v' WHERE id='%v';"
+	getSessionByToken = "SELECT id FROM sessions where token=?;"
+	//nolint:gosec //not any hardcoded credential
+	getUserIDByToken = "SELECT user_id FROM sessions WHERE token=?;"
+	updateSession    = "UPDATE sessions SET token=?, expiry=? WHERE id=?;"
 )
 
 type Store struct {
-	DB *sqlitecloud.SQCloud
+	DB database.DB
 }
 
-func New(db *sqlitecloud.SQCloud) *Store {
+func New(db database.DB) *Store {
 	return &Store{DB: db}
 }
 
 func (s *Store) CreateSession(ctx context.Context, session *models.SessionData) error {
 	logger := models.GetLoggerFromCtx(ctx)
 
-	query := fmt.Sprintf(
-		createSession,
+	err := s.conn(ctx).Execute(createSession,
 		session.ID,
 		session.UserID,
 		session.Token,
-		session.Expiry.UnixMilli(),
+		session.Expiry,
 	)
-	if err := s.DB.Execute(query); err != nil {
+	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while running session create query",
 			slog.String("error", err.Error()),
 		)
@@ -50,13 +49,12 @@ func (s *Store) CreateSession(ctx context.Context, session *models.SessionData)
 	return nil
 }
 
-// nolint:gocognit // cognitive of 11 is okay, didn't want to split this method
 func (s *Store) GetSessionByID(ctx context.Context, userID *uuid.UUID) (*models.SessionData, error) {
 	logger := models.GetLoggerFromCtx(ctx)
 
 	var session models.SessionData
 
-	res, err := s.DB.Select(fmt.Sprintf(getSessionByUserID, *userID))
+	res, err := s.conn(ctx).Select(getSessionByUserID, userID)
 	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while fetching session by userID",
 			slog.String("error", err.Error()),
@@ -74,31 +72,10 @@ func (s *Store) GetSessionByID(ctx context.Context, userID *uuid.UUID) (*models.
 	}
 
 	for r := uint64(0); r < res.GetNumberOfRows(); r++ {
-		c1, err := res.GetStringValue(r, 0)
-		if err != nil {
-			return nil, err
-		}
-
-		c2, err := res.GetStringValue(r, 1)
-		if err != nil {
-			return nil, err
-		}
-
-		c3, err := res.GetStringValue(r, 2)
-		if err != nil {
-			return nil, err
-		}
-
-		c4, err := res.GetInt64Value(r, 3)
+		err := database.ScanRow(res, r, &session.ID, &session.UserID, &session.Token, &session.Expiry)
 		if err != nil {
 			return nil, err
 		}
-
-		session.ID = uuid.MustParse(c1)
-		session.UserID = uuid.MustParse(c2)
-		session.Token = c3
-
-		session.Expiry = time.UnixMilli(c4)
 	}
 
 	return &session, nil
@@ -106,13 +83,8 @@ func (s *Store) GetSessionByID(ctx context.Context, userID *uuid.UUID) (*models.
 
 func (s *Store) RefreshSession(ctx context.Context, newSession *models.SessionData) error {
 	logger := models.GetLoggerFromCtx(ctx)
-	query := fmt.Sprintf(updateSession,
-		newSession.Token,
-		newSession.Expiry.UnixMilli(),
-		newSession.ID,
-	)
 
-	if err := s.DB.Execute(query); err != nil {
+	if err := s.conn(ctx).Execute(updateSession, newSession.Token, newSession.Expiry, newSession.ID); err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error in refreshing session",
 			slog.String("error", err.Error()),
 		)
@@ -128,7 +100,7 @@ func (s *Store) Logout(ctx context.Context, token *uuid.UUID) error {
 
 	var id uuid.UUID
 
-	res, err := s.DB.Select(fmt.Sprintf(getSessionByToken, *token))
+	res, err := s.conn(ctx).Select(getSessionByToken, token)
 	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while logging out user",
 			slog.String("error", err.Error()),
@@ -142,13 +114,45 @@ func (s *Store) Logout(ctx context.Context, token *uuid.UUID) error {
 	}
 
 	for r := uint64(0); r < res.GetNumberOfRows(); r++ {
-		r1, err := res.GetStringValue(r, 0)
-		if err != nil {
+		if err := database.ScanRow(res, r, &id); err != nil {
 			return err
 		}
+	}
+
+	return s.conn(ctx).Execute(deleteSessionByID, id)
+}
+
+func (s *Store) GetUserIDByToken(ctx context.Context, token *uuid.UUID) (*uuid.UUID, error) {
+	var (
+		uid    uuid.UUID
+		logger = models.GetLoggerFromCtx(ctx)
+	)
+
+	row, err := s.conn(ctx).Select(getUserIDByToken, token)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, err.Error())
+
+		return nil, err
+	}
+
+	if row.GetNumberOfRows() == uint64(0) {
+		logger.LogAttrs(ctx, slog.LevelError, "no valid session found, login again")
 
-		id = uuid.MustParse(r1)
+		return nil, models.ErrInvalidCookie
 	}
 
-	return s.DB.Execute(fmt.Sprintf(deleteSessionByID, id))
+	for r := uint64(0); r < row.GetNumberOfRows(); r++ {
+		if err := database.ScanRow(row, r, &uid); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, err.Error())
+
+			return nil, err
+		}
+	}
+
+	return &uid, nil
+}
+
+// conn runs the query inside the caller's transaction when ctx carries one
+func (s *Store) conn(ctx context.Context) database.Querier {
+	return database.Conn(ctx, s.DB)
 }
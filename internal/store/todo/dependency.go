@@ -0,0 +1,120 @@
+package todostore
+
+import (
+	"context"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	addDependency    = "INSERT INTO task_dependencies (task_id, blocker_id) VALUES (?, ?) ON CONFLICT DO NOTHING;"
+	removeDependency = "DELETE FROM task_dependencies WHERE task_id=? AND blocker_id=?;"
+	listDependencies = "SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN tasks t ON t.id=d.task_id WHERE t.user_id=?;"
+	listBlockers     = "SELECT d.task_id, t.id, t.title, t.status FROM task_dependencies d JOIN tasks t ON t.id=d.blocker_id " +
+		"WHERE t.deleted_at IS NULL AND d.task_id IN "
+	listBlocking = "SELECT d.blocker_id, t.id, t.title, t.status FROM task_dependencies d JOIN tasks t ON t.id=d.task_id " +
+		"WHERE t.deleted_at IS NULL AND d.blocker_id IN "
+	lockUser          = "SELECT id FROM users WHERE id=? FOR UPDATE;"
+	orderRefs         = " ORDER BY t.title ASC, t.id ASC;"
+	purgeDependencies = "DELETE FROM task_dependencies WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at<?) " +
+		"OR blocker_id IN (SELECT id FROM tasks WHERE deleted_at<?);"
+)
+
+// AddDependency makes a task wait for its blocker, adding it twice keeps one
+func (s *Store) AddDependency(ctx context.Context, dep *models.Dependency) error {
+	return s.writeDetail(ctx, dep.TaskID, addDependency, dep.TaskID, dep.BlockerID)
+}
+
+func (s *Store) RemoveDependency(ctx context.Context, dep *models.Dependency) error {
+	return s.writeDetail(ctx, dep.TaskID, removeDependency, dep.TaskID, dep.BlockerID)
+}
+
+// ListDependencies returns every dependency between the user's tasks, the trashed ones included
+func (s *Store) ListDependencies(ctx context.Context, userID *uuid.UUID) ([]models.Dependency, error) {
+	rows, err := s.conn(ctx).Select(listDependencies, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.Dependency, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var dep models.Dependency
+
+		if err := database.ScanRow(rows, row, &dep.TaskID, &dep.BlockerID); err != nil {
+			return nil, err
+		}
+
+		res = append(res, dep)
+	}
+
+	return res, nil
+}
+
+// LockDependencies holds off the other transactions changing the dependencies of the user until
+// the one in ctx ends. SQLite runs one transaction at a time, on Postgres the user's row is locked.
+func (s *Store) LockDependencies(ctx context.Context, userID *uuid.UUID) error {
+	if s.DB.Driver() != database.DriverPostgres {
+		return nil
+	}
+
+	_, err := s.conn(ctx).Select(lockUser, userID)
+
+	return err
+}
+
+// loadDependencies sets the tasks every task waits for and the tasks waiting for it, trashed
+// tasks are left out
+func (s *Store) loadDependencies(ctx context.Context, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Task, len(tasks))
+	args := make([]any, 0, len(tasks))
+
+	for i := range tasks {
+		tasks[i].BlockedBy, tasks[i].Blocking = make(models.TaskRefs, 0), make(models.TaskRefs, 0)
+		byID[tasks[i].ID] = &tasks[i]
+		args = append(args, tasks[i].ID)
+	}
+
+	in := placeholders(len(tasks))
+
+	err := s.loadRefs(ctx, listBlockers+in+orderRefs, args, func(id string, ref models.TaskRef) {
+		byID[id].BlockedBy = append(byID[id].BlockedBy, ref)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.loadRefs(ctx, listBlocking+in+orderRefs, args, func(id string, ref models.TaskRef) {
+		byID[id].Blocking = append(byID[id].Blocking, ref)
+	})
+}
+
+// loadRefs hands every row of query to add, the rows are a task id and the task it refers to
+func (s *Store) loadRefs(ctx context.Context, query string, args []any, add func(id string, ref models.TaskRef)) error {
+	rows, err := s.conn(ctx).Select(query, args...)
+	if err != nil {
+		return err
+	}
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var (
+			id  string
+			ref models.TaskRef
+		)
+
+		if err := database.ScanRow(rows, row, &id, &ref.ID, &ref.Title, &ref.Status); err != nil {
+			return err
+		}
+
+		add(id, ref)
+	}
+
+	return nil
+}
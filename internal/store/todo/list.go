@@ -0,0 +1,107 @@
+package todostore
+
+import (
+	"context"
+	"log/slog"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	listColumns   = "id, user_id, name, sort, archived_at, created_at, modified_at"
+	insertList    = "INSERT INTO lists (id, user_id, name, sort, created_at) VALUES (?, ?, ?, ?, ?);"
+	activeLists   = "SELECT " + listColumns + " FROM lists WHERE user_id=? AND archived_at IS NULL ORDER BY name ASC;"
+	archivedLists = "SELECT " + listColumns + " FROM lists WHERE user_id=? AND archived_at IS NOT NULL ORDER BY name ASC;"
+	getListByID   = "SELECT " + listColumns + " FROM lists WHERE id=? AND user_id=?;"
+	getListByName = "SELECT " + listColumns + " FROM lists WHERE name=? AND user_id=?;"
+	updateList    = "UPDATE lists SET name=?, sort=?, archived_at=?, modified_at=? WHERE id=? AND user_id=?;"
+	unlistTasks   = "UPDATE tasks SET list_id=NULL WHERE list_id=? AND user_id=?;"
+	deleteList    = "DELETE FROM lists WHERE id=? AND user_id=?;"
+)
+
+func (s *Store) CreateList(ctx context.Context, list *models.List) error {
+	if err := s.conn(ctx).Execute(insertList, list.ID, list.UserID, list.Name, list.Sort, list.CreatedAt); err != nil {
+		return err
+	}
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelDebug, "list created", slog.String("list", list.ID))
+
+	return nil
+}
+
+// GetLists returns either the active or the archived lists of the user, ordered by name
+func (s *Store) GetLists(ctx context.Context, archived bool, userID *uuid.UUID) ([]models.List, error) {
+	query := activeLists
+	if archived {
+		query = archivedLists
+	}
+
+	rows, err := s.conn(ctx).Select(query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return populateLists(rows)
+}
+
+func (s *Store) GetList(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error) {
+	return s.getList(ctx, getListByID, id, userID)
+}
+
+func (s *Store) GetListByName(ctx context.Context, name string, userID *uuid.UUID) (*models.List, error) {
+	return s.getList(ctx, getListByName, name, userID)
+}
+
+// UpdateList writes the name, sort and archive state of the list
+func (s *Store) UpdateList(ctx context.Context, list *models.List) error {
+	return s.conn(ctx).Execute(updateList, list.Name, list.Sort, list.ArchivedAt, list.ModifiedAt, list.ID, list.UserID)
+}
+
+// DeleteList deletes the list, its tasks, trashed ones included, are left in no list
+func (s *Store) DeleteList(ctx context.Context, id string, userID *uuid.UUID) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.conn(ctx).Execute(unlistTasks, id, userID); err != nil {
+			return err
+		}
+
+		return s.conn(ctx).Execute(deleteList, id, userID)
+	})
+}
+
+func (s *Store) getList(ctx context.Context, query string, args ...any) (*models.List, error) {
+	rows, err := s.conn(ctx).Select(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := populateLists(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lists) == 0 {
+		return nil, models.ErrNotFound("list")
+	}
+
+	return &lists[0], nil
+}
+
+func populateLists(rows database.Result) ([]models.List, error) {
+	lists := make([]models.List, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var l models.List
+
+		err := database.ScanRow(rows, row, &l.ID, &l.UserID, &l.Name, &l.Sort, &l.ArchivedAt, &l.CreatedAt, &l.ModifiedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		lists = append(lists, l)
+	}
+
+	return lists, nil
+}
@@ -0,0 +1,68 @@
+package todostore
+
+import (
+	"context"
+	"time"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	notificationColumns = "id, user_id, task_id, title, body, created_at, read_at"
+	addNotification     = "INSERT INTO notifications (" + notificationColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?);"
+	listNotifications   = "SELECT " + notificationColumns + " FROM notifications WHERE user_id=? " +
+		"ORDER BY created_at DESC, id DESC LIMIT ?;"
+	countNotification = "SELECT COUNT(*) FROM notifications WHERE id=? AND user_id=?;"
+	readNotification  = "UPDATE notifications SET read_at=? WHERE id=? AND user_id=? AND read_at IS NULL;"
+)
+
+func (s *Store) AddNotification(ctx context.Context, n *models.Notification) error {
+	return s.conn(ctx).Execute(addNotification, n.ID, n.UserID, nullString(n.TaskID), n.Title, n.Body, n.CreatedAt, n.ReadAt)
+}
+
+// ListNotifications returns the user's last limit notifications, the newest first
+func (s *Store) ListNotifications(ctx context.Context, limit int, userID *uuid.UUID) ([]models.Notification, error) {
+	rows, err := s.conn(ctx).Select(listNotifications, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.Notification, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var n models.Notification
+
+		err := database.ScanRow(rows, row, &n.ID, &n.UserID, &n.TaskID, &n.Title, &n.Body, &n.CreatedAt, &n.ReadAt)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+// ReadNotification marks a notification of the user as read at readAt, reading it twice keeps the first time
+func (s *Store) ReadNotification(ctx context.Context, id string, readAt time.Time, userID *uuid.UUID) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rows, err := s.conn(ctx).Select(countNotification, id, userID)
+		if err != nil {
+			return err
+		}
+
+		n, err := rows.GetInt64Value(0, 0)
+		if err != nil {
+			return err
+		}
+
+		if n == 0 {
+			return models.ErrNotFound("notification")
+		}
+
+		return s.conn(ctx).Execute(readNotification, readAt, id, userID)
+	})
+}
@@ -0,0 +1,185 @@
+package todostore
+
+import (
+	"strings"
+	"time"
+
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const listTasks = "SELECT " + taskColumns + " FROM tasks WHERE user_id=? AND deleted_at IS NULL"
+
+// nolint:gochecknoglobals // replacer is immutable and safe to share
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// sortColumn is the expression the tasks are ordered by for field, it must order the rows
+// exactly like models.SortValue does, a missing due date sorts last
+func sortColumn(field models.SortField) string {
+	switch field {
+	case models.SortDueDate:
+		return "COALESCE(due_date, 9223372036854775807)"
+	case models.SortTitle:
+		return "title"
+	case models.SortDone:
+		return "done_status"
+	case models.SortPriority:
+		return "priority"
+	default:
+		return "added_at"
+	}
+}
+
+// listQuery builds the keyset query of one page, the task ID always closes the order so
+// the pages never overlap or skip a row
+func listQuery(q *models.TaskQuery, userID *uuid.UUID) (string, []any) {
+	var (
+		b     strings.Builder
+		args  = []any{userID}
+		order = make([]string, 0, len(q.Sort)+1)
+	)
+
+	b.WriteString(listTasks)
+
+	conds, condArgs := filterConds(&q.Filter)
+	for _, cond := range conds {
+		b.WriteString(" AND " + cond)
+	}
+
+	args = append(args, condArgs...)
+
+	if q.After != nil {
+		cond, condArgs := afterCursor(q)
+
+		b.WriteString(" AND (" + cond + ")")
+
+		args = append(args, condArgs...)
+	}
+
+	for _, key := range q.Sort {
+		if key.Desc {
+			order = append(order, sortColumn(key.Field)+" DESC")
+			continue
+		}
+
+		order = append(order, sortColumn(key.Field)+" ASC")
+	}
+
+	order = append(order, "id ASC")
+
+	b.WriteString(" ORDER BY " + strings.Join(order, ", ") + " LIMIT ?;")
+
+	return b.String(), append(args, q.Limit)
+}
+
+// filterConds returns the conditions of the filter with their bound values, they must match
+// the same tasks as models.TaskFilter.Match
+func filterConds(f *models.TaskFilter) ([]string, []any) {
+	var (
+		conds = make([]string, 0)
+		args  = make([]any, 0)
+	)
+
+	add := func(cond string, arg any) {
+		conds = append(conds, cond)
+		args = append(args, arg)
+	}
+
+	if f.Done != nil {
+		add("done_status=?", *f.Done)
+	}
+
+	if f.Open {
+		open := models.OpenStatuses()
+		conds = append(conds, "status IN "+placeholders(len(open)))
+
+		for _, s := range open {
+			args = append(args, s.String())
+		}
+	}
+
+	if f.ListID != nil {
+		add("COALESCE(list_id, '')=?", *f.ListID)
+	}
+
+	if f.TopLevel {
+		conds = append(conds, "parent_id IS NULL")
+	}
+
+	bounds := []struct {
+		cond string
+		t    *time.Time
+	}{
+		{"due_date>?", f.DueAfter}, {"due_date<?", f.DueBefore}, {"added_at>?", f.AddedAfter}, {"added_at<?", f.AddedBefore},
+	}
+
+	for _, bound := range bounds {
+		if bound.t != nil {
+			add(bound.cond, *bound.t)
+		}
+	}
+
+	if f.TitleContains != "" {
+		add(`LOWER(title) LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(strings.ToLower(f.TitleContains))+"%")
+	}
+
+	if len(f.Tags) > 0 {
+		cond, tagArgs := tagCond(f)
+		conds = append(conds, cond)
+		args = append(args, tagArgs...)
+	}
+
+	return conds, args
+}
+
+// tagCond keeps the tasks carrying any of the filter's tags, or all of them
+func tagCond(f *models.TaskFilter) (string, []any) {
+	args := make([]any, 0, len(f.Tags)+1)
+	for _, name := range f.Tags {
+		args = append(args, name)
+	}
+
+	cond := "id IN (SELECT tt.task_id FROM task_tags tt JOIN tags tg ON tg.id=tt.tag_id " +
+		"WHERE tg.user_id=tasks.user_id AND tg.name IN (?" + strings.Repeat(", ?", len(f.Tags)-1) + ")"
+
+	if f.AllTags {
+		cond += " GROUP BY tt.task_id HAVING COUNT(*)=?"
+		args = append(args, len(f.Tags))
+	}
+
+	return cond + ")", args
+}
+
+// afterCursor matches the rows after the cursor: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func afterCursor(q *models.TaskQuery) (string, []any) {
+	var (
+		ors  = make([]string, 0, len(q.Sort)+1)
+		args = make([]any, 0)
+	)
+
+	for i := 0; i <= len(q.Sort); i++ {
+		ands := make([]string, 0, i+1)
+
+		for j := 0; j < i; j++ {
+			ands = append(ands, sortColumn(q.Sort[j].Field)+"=?")
+			args = append(args, q.After.Values[j])
+		}
+
+		switch {
+		case i == len(q.Sort):
+			ands = append(ands, "id>?")
+			args = append(args, q.After.ID)
+		case q.Sort[i].Desc:
+			ands = append(ands, sortColumn(q.Sort[i].Field)+"<?")
+			args = append(args, q.After.Values[i])
+		default:
+			ands = append(ands, sortColumn(q.Sort[i].Field)+">?")
+			args = append(args, q.After.Values[i])
+		}
+
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+
+	return strings.Join(ors, " OR "), args
+}
@@ -0,0 +1,135 @@
+package todostore
+
+import (
+	"context"
+	"time"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+)
+
+const (
+	reminderColumns = "r.id, r.task_id, r.user_id, r.before_minutes, r.remind_at, r.sent_at, r.created_at"
+	addReminder     = "INSERT INTO reminders (id, task_id, user_id, before_minutes, remind_at, created_at) VALUES (?, ?, ?, ?, ?, ?);"
+	removeReminder  = "DELETE FROM reminders WHERE id=? AND task_id=? AND user_id=?;"
+	listReminders   = "SELECT " + reminderColumns + " FROM reminders r WHERE r.task_id IN "
+	orderReminders  = " ORDER BY r.created_at ASC, r.id ASC;"
+	// a relative reminder fires before_minutes ahead of the due date of its task
+	fireAt       = "COALESCE(r.remind_at, t.due_date - r.before_minutes * 60000)"
+	dueReminders = "SELECT " + reminderColumns + ", " + searchColumns + " FROM reminders r JOIN tasks t ON t.id=r.task_id " +
+		"WHERE r.sent_at IS NULL AND t.deleted_at IS NULL AND t.status NOT IN ('done', 'cancelled') " +
+		"AND (r.remind_at IS NOT NULL OR t.due_date IS NOT NULL) AND " + fireAt + "<=? " +
+		"ORDER BY " + fireAt + " ASC, r.id ASC LIMIT ?;"
+	countUnsent      = "SELECT COUNT(*) FROM reminders WHERE id=? AND sent_at IS NULL;"
+	markReminderSent = "UPDATE reminders SET sent_at=? WHERE id=? AND sent_at IS NULL;"
+	resetReminders   = "UPDATE reminders SET sent_at=NULL WHERE task_id=? AND remind_at IS NULL AND sent_at IS NOT NULL " +
+		"AND (SELECT due_date FROM tasks WHERE id=?) - before_minutes * 60000 > ?;"
+	purgeReminders = "DELETE FROM reminders WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at<?);"
+)
+
+func (s *Store) AddReminder(ctx context.Context, rem *models.Reminder) error {
+	return s.writeDetail(ctx, rem.TaskID, addReminder,
+		rem.ID, rem.TaskID, rem.UserID, int(rem.Before/time.Minute), rem.At, rem.CreatedAt)
+}
+
+func (s *Store) RemoveReminder(ctx context.Context, rem *models.Reminder) error {
+	return s.writeDetail(ctx, rem.TaskID, removeReminder, rem.ID, rem.TaskID, rem.UserID)
+}
+
+// DueReminders returns at most limit unsent reminders of every user due at now, the oldest first.
+// Reminders of trashed, done or cancelled tasks are left out.
+func (s *Store) DueReminders(ctx context.Context, now time.Time, limit int) ([]models.DueReminder, error) {
+	rows, err := s.conn(ctx).Select(dueReminders, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.DueReminder, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var due models.DueReminder
+
+		if err := scanReminder(rows, row, &due.Reminder, taskFields(&due.Task)...); err != nil {
+			return nil, err
+		}
+
+		res = append(res, due)
+	}
+
+	return res, nil
+}
+
+// MarkReminderSent records that a reminder fired at sentAt, it is false when it was already sent
+func (s *Store) MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	var marked bool
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rows, err := s.conn(ctx).Select(countUnsent, id)
+		if err != nil {
+			return err
+		}
+
+		n, err := rows.GetInt64Value(0, 0)
+		if err != nil || n == 0 {
+			return err
+		}
+
+		marked = true
+
+		return s.conn(ctx).Execute(markReminderSent, sentAt, id)
+	})
+
+	return marked, err
+}
+
+// ResetReminders lets the relative reminders of a task fire again once its due date moved past them
+func (s *Store) ResetReminders(ctx context.Context, taskID string, now time.Time) error {
+	return s.conn(ctx).Execute(resetReminders, taskID, taskID, now)
+}
+
+// loadReminders sets the reminders of every task with a single query
+func (s *Store) loadReminders(ctx context.Context, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Task, len(tasks))
+	args := make([]any, 0, len(tasks))
+
+	for i := range tasks {
+		tasks[i].Reminders = make([]models.Reminder, 0)
+		byID[tasks[i].ID] = &tasks[i]
+		args = append(args, tasks[i].ID)
+	}
+
+	rows, err := s.conn(ctx).Select(listReminders+placeholders(len(tasks))+orderReminders, args...)
+	if err != nil {
+		return err
+	}
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var rem models.Reminder
+
+		if err := scanReminder(rows, row, &rem); err != nil {
+			return err
+		}
+
+		byID[rem.TaskID].Reminders = append(byID[rem.TaskID].Reminders, rem)
+	}
+
+	return nil
+}
+
+// scanReminder reads the reminderColumns of a row into rem, the columns after them into rest
+func scanReminder(rows database.Result, row uint64, rem *models.Reminder, rest ...any) error {
+	var before int
+
+	dest := append([]any{&rem.ID, &rem.TaskID, &rem.UserID, &before, &rem.At, &rem.SentAt, &rem.CreatedAt}, rest...)
+	if err := database.ScanRow(rows, row, dest...); err != nil {
+		return err
+	}
+
+	rem.Before = time.Duration(before) * time.Minute
+
+	return nil
+}
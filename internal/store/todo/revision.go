@@ -0,0 +1,69 @@
+package todostore
+
+import (
+	"context"
+	"encoding/json"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+)
+
+const (
+	lastRevision   = "SELECT COALESCE(MAX(number), 0) FROM task_revisions WHERE task_id=?;"
+	insertRevision = "INSERT INTO task_revisions(task_id, number, user_id, changed_at, changes) VALUES (?, ?, ?, ?, ?);"
+	listRevisions  = "SELECT task_id, number, user_id, changed_at, changes FROM task_revisions " +
+		"WHERE task_id=? ORDER BY number DESC;"
+)
+
+// AddRevision stores rev as the next revision of its task and sets its number
+func (s *Store) AddRevision(ctx context.Context, rev *models.Revision) error {
+	changes, err := json.Marshal(rev.Changes)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rows, err := s.conn(ctx).Select(lastRevision, rev.TaskID)
+		if err != nil {
+			return err
+		}
+
+		last, err := rows.GetInt64Value(0, 0)
+		if err != nil {
+			return err
+		}
+
+		rev.Number = last + 1
+
+		return s.conn(ctx).Execute(insertRevision, rev.TaskID, rev.Number, rev.UserID, rev.ChangedAt, string(changes))
+	})
+}
+
+// ListRevisions returns the revisions of a task, newest first
+func (s *Store) ListRevisions(ctx context.Context, taskID string) ([]models.Revision, error) {
+	rows, err := s.conn(ctx).Select(listRevisions, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.Revision, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var (
+			rev     models.Revision
+			changes string
+		)
+
+		if err := database.ScanRow(rows, row, &rev.TaskID, &rev.Number, &rev.UserID, &rev.ChangedAt, &changes); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(changes), &rev.Changes); err != nil {
+			return nil, err
+		}
+
+		res = append(res, rev)
+	}
+
+	return res, nil
+}
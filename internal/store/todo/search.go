@@ -0,0 +1,105 @@
+package todostore
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	searchColumns = "t.id, t.user_id, t.title, t.description, t.done_status, t.status, t.priority, t.due_date, t.due_time, " +
+		"t.added_at, t.modified_at, t.completed_at, t.deleted_at, t.list_id, t.parent_id, t.recurrence, t.occurrence, " +
+		"t.version"
+
+	// title weighs ten times the description in the ranking, bm25 is lower for better matches
+	searchQuery = "SELECT " + searchColumns + ", highlight(tasks_fts, 2, ?, ?), snippet(tasks_fts, 3, ?, ?, '…', 16) " +
+		"FROM tasks_fts JOIN tasks t ON t.id=tasks_fts.task_id " +
+		"WHERE tasks_fts MATCH ? AND tasks_fts.user_id=? AND t.deleted_at IS NULL ORDER BY bm25(tasks_fts, 0.0, 0.0, 10.0, 1.0) LIMIT ?;"
+	searchQueryPostgres = "SELECT " + searchColumns + ", ts_headline('simple', t.title, q, ?), " +
+		"ts_headline('simple', COALESCE(t.description, ''), q, ?) " +
+		"FROM tasks t, to_tsquery('simple', ?) q " +
+		"WHERE t.user_id=? AND t.deleted_at IS NULL AND to_tsvector('simple', t.title || ' ' || COALESCE(t.description, '')) @@ q " +
+		"ORDER BY ts_rank(setweight(to_tsvector('simple', t.title), 'A') || " +
+		"setweight(to_tsvector('simple', COALESCE(t.description, '')), 'B'), q) DESC, t.added_at LIMIT ?;"
+
+	insertSearch = "INSERT INTO tasks_fts(task_id, user_id, title, description) VALUES (?, ?, ?, ?);"
+	updateSearch = "UPDATE tasks_fts SET title=?, description=? WHERE task_id=? AND user_id=?;"
+)
+
+// Search returns at most limit tasks of the user matching every term, best match first
+func (s *Store) Search(ctx context.Context, terms []string, limit int, userID *uuid.UUID) ([]models.SearchResult, error) {
+	var (
+		res    = make([]models.SearchResult, 0)
+		logger = models.GetLoggerFromCtx(ctx)
+		rows   database.Result
+		err    error
+	)
+
+	// the terms are plain words, each one is matched as a prefix
+	match := make([]string, 0, len(terms))
+
+	if s.DB.Driver() == database.DriverPostgres {
+		for _, term := range terms {
+			match = append(match, term+":*")
+		}
+
+		rows, err = s.conn(ctx).Select(searchQueryPostgres,
+			"StartSel="+models.HighlightStart+", StopSel="+models.HighlightEnd+", HighlightAll=true",
+			"StartSel="+models.HighlightStart+", StopSel="+models.HighlightEnd+", MaxWords=16, MinWords=8",
+			strings.Join(match, " & "), userID, limit)
+	} else {
+		for _, term := range terms {
+			match = append(match, `"`+term+`"*`)
+		}
+
+		rows, err = s.conn(ctx).Select(searchQuery,
+			models.HighlightStart, models.HighlightEnd, models.HighlightStart, models.HighlightEnd,
+			strings.Join(match, " "), userID, limit)
+	}
+
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error while searching tasks",
+			slog.String("error", err.Error()), slog.String("user", userID.String()))
+
+		return nil, err
+	}
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var r models.SearchResult
+
+		if err := database.ScanRow(rows, row, append(taskFields(&r.Task), &r.TitleSnippet, &r.DescriptionSnippet)...); err != nil {
+			return nil, err
+		}
+
+		res = append(res, r)
+	}
+
+	tasks := make([]models.Task, 0, len(res))
+	for i := range res {
+		tasks = append(tasks, res[i].Task)
+	}
+
+	if err := s.loadDetails(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	for i := range res {
+		res[i].Task.Tags = tasks[i].Tags
+	}
+
+	return res, nil
+}
+
+// index keeps the FTS5 table in step with a task write, postgres searches the tasks table itself
+func (s *Store) index(ctx context.Context, query string, args ...any) error {
+	if s.DB.Driver() == database.DriverPostgres {
+		return nil
+	}
+
+	return s.conn(ctx).Execute(query, args...)
+}
@@ -2,41 +2,51 @@ package todostore
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
+
+	"todoapp/internal/database"
 	"todoapp/internal/models"
 
 	"github.com/google/uuid"
-	"github.com/sqlitecloud/sqlitecloud-go"
 )
 
+// trashed tasks are only reachable through the trash queries, every other query skips them. The writes of a
+// task read before only apply to the version read, they return the new one.
 const (
-	deleteTask     = "DELETE FROM tasks WHERE id='%v' AND user_id='%v';"
-	getAllByUserID = "SELECT id, user_id, title, description, done_status, due_date, added_at, modified_at FROM tasks WHERE user_id='%v';"
-	getTaskByID    = "SELECT id, user_id, title, description, done_status, due_date, added_at, modified_at FROM " +
-		"tasks WHERE id='%v' AND user_id='%v';"
-	insertQuery = "INSERT INTO tasks (id, user_id, title, description, done_status, due_date, added_at) VALUES " +
-		"('%v', '%v', '%v', '%v', %v, '%v', '%v');"
-	setDone     = "UPDATE tasks SET done_status=%v, modified_at='%v' WHERE id='%v' AND user_id='%v';"
-	updateQuery = "UPDATE tasks SET title='%v', description='%v', done_status=%v, modified_at='%v' WHERE id='%v' AND user_id='%v';"
+	taskColumns = "id, user_id, title, description, done_status, status, priority, due_date, due_time, added_at, " +
+		"modified_at, completed_at, deleted_at, list_id, parent_id, recurrence, occurrence, version"
+	sameVersion = " WHERE id=? AND user_id=? AND deleted_at IS NULL AND version=? RETURNING version;"
+	trashTask   = "UPDATE tasks SET deleted_at=?, version=version+1" + sameVersion
+	bumpVersion = "UPDATE tasks SET version=version+1 WHERE id=?;"
+	getTaskByID = "SELECT " + taskColumns + " FROM tasks WHERE id=? AND user_id=? AND deleted_at IS NULL;"
+	insertQuery = "INSERT INTO tasks (id, user_id, title, description, done_status, status, priority, due_date, due_time, " +
+		"added_at, list_id, parent_id, recurrence, occurrence, version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1);"
+	updateQuery = "UPDATE tasks SET title=?, description=?, done_status=?, status=?, priority=?, due_date=?, due_time=?, " +
+		"completed_at=?, modified_at=?, list_id=?, parent_id=?, recurrence=?, occurrence=?, version=version+1" + sameVersion
 )
 
 type Store struct {
-	DB *sqlitecloud.SQCloud
+	DB database.DB
+	tx *database.Transactor
 }
 
-func New(db *sqlitecloud.SQCloud) *Store {
-	return &Store{DB: db}
+func New(db database.DB) *Store {
+	return &Store{DB: db, tx: database.NewTransactor(db)}
 }
 
-func (s *Store) GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+// GetAll returns at most q.Limit tasks of the user following q.After in the q.Sort order
+func (s *Store) GetAll(ctx context.Context, q *models.TaskQuery, userID *uuid.UUID) ([]models.Task, error) {
 	var (
 		res    = make([]models.Task, 0)
 		logger = models.GetLoggerFromCtx(ctx)
 	)
 
-	rows, err := s.DB.Select(fmt.Sprintf(getAllByUserID, *userID))
+	query, args := listQuery(q, userID)
+
+	rows, err := s.conn(ctx).Select(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +66,10 @@ func (s *Store) GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, e
 		res = append(res, *task)
 	}
 
+	if err := s.loadDetails(ctx, res); err != nil {
+		return nil, err
+	}
+
 	logger.LogAttrs(ctx, slog.LevelDebug, "get all tasks", slog.String("user", userID.String()))
 
 	return res, nil
@@ -63,20 +77,36 @@ func (s *Store) GetAll(ctx context.Context, userID *uuid.UUID) ([]models.Task, e
 
 func (s *Store) Create(ctx context.Context, task *models.Task) error {
 	logger := models.GetLoggerFromCtx(ctx)
-	query := fmt.Sprintf(insertQuery,
-		task.ID,
-		task.UserID,
-		task.Title,
-		task.Description,
-		task.IsDone,
-		task.DueDate.UnixMilli(),
-		task.AddedAt.UnixMilli(),
-	)
 
-	if err := s.DB.Execute(query); err != nil {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.conn(ctx).Execute(insertQuery,
+			task.ID,
+			task.UserID,
+			task.Title,
+			task.Description,
+			task.IsDone,
+			task.Status,
+			int(task.Priority),
+			task.DueDate,
+			task.HasDueTime,
+			task.AddedAt,
+			nullString(task.ListID),
+			nullString(task.ParentID),
+			nullString(task.Recurrence),
+			task.Occurrence,
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.index(ctx, insertSearch, task.ID, task.UserID, task.Title, task.Description)
+	})
+	if err != nil {
 		return err
 	}
 
+	task.Version = 1
+
 	logger.LogAttrs(ctx, slog.LevelDebug, "task added successfully",
 		slog.String("task", task.ID),
 	)
@@ -84,131 +114,204 @@ func (s *Store) Create(ctx context.Context, task *models.Task) error {
 	return nil
 }
 
+// Update writes the task, it is refused with ErrVersionMismatch when the task changed since it was read
 func (s *Store) Update(ctx context.Context, task *models.Task) error {
 	logger := models.GetLoggerFromCtx(ctx)
-	query := fmt.Sprintf(updateQuery,
-		task.Title,
-		task.Description,
-		task.IsDone,
-		task.ModifiedAt.UnixMilli(),
-		task.ID,
-		task.UserID,
-	)
 
-	if err := s.DB.Execute(query); err != nil {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.writeVersion(ctx, updateQuery,
+			task.Title,
+			task.Description,
+			task.IsDone,
+			task.Status,
+			int(task.Priority),
+			task.DueDate,
+			task.HasDueTime,
+			task.CompletedAt,
+			task.ModifiedAt,
+			nullString(task.ListID),
+			nullString(task.ParentID),
+			nullString(task.Recurrence),
+			task.Occurrence,
+			task.ID,
+			task.UserID,
+			task.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.index(ctx, updateSearch, task.Title, task.Description, task.ID, task.UserID)
+	})
+	if err != nil {
 		return err
 	}
 
+	task.Version++
+
 	logger.LogAttrs(ctx, slog.LevelDebug, "task updated successfully",
 		slog.String("task", task.ID))
 
 	return nil
 }
 
-func (s *Store) Delete(ctx context.Context, id string, userID *uuid.UUID) error {
+// Patch writes the fields of the task a partial update changed, along with its modification time. Like
+// Update it is refused when the task changed since it was read.
+func (s *Store) Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error {
 	logger := models.GetLoggerFromCtx(ctx)
 
-	if err := s.DB.Execute(fmt.Sprintf(deleteTask, id, *userID)); err != nil {
-		return err
+	sets, args := []string{"modified_at=?", "version=version+1"}, []any{task.ModifiedAt}
+
+	for _, f := range fields {
+		switch f {
+		case models.FieldTitle:
+			sets, args = append(sets, "title=?"), append(args, task.Title)
+		case models.FieldDescription:
+			sets, args = append(sets, "description=?"), append(args, task.Description)
+		case models.FieldDue:
+			sets, args = append(sets, "due_date=?", "due_time=?"), append(args, task.DueDate, task.HasDueTime)
+		case models.FieldPriority:
+			sets, args = append(sets, "priority=?"), append(args, int(task.Priority))
+		case models.FieldList:
+			sets, args = append(sets, "list_id=?"), append(args, nullString(task.ListID))
+		case models.FieldParent:
+			sets, args = append(sets, "parent_id=?"), append(args, nullString(task.ParentID))
+		case models.FieldRecurrence:
+			sets, args = append(sets, "recurrence=?", "occurrence=?"), append(args, nullString(task.Recurrence), task.Occurrence)
+		}
 	}
 
-	logger.LogAttrs(ctx, slog.LevelDebug, "task deleted successfully", slog.String("task", id))
+	query := "UPDATE tasks SET " + strings.Join(sets, ", ") + sameVersion
 
-	return nil
-}
-
-func (s *Store) MarkDone(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
-	var (
-		task   = &models.Task{ID: id}
-		logger = models.GetLoggerFromCtx(ctx)
-		err    error
-	)
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.writeVersion(ctx, query, append(args, task.ID, task.UserID, task.Version)...); err != nil {
+			return err
+		}
 
-	if err := s.DB.Execute(fmt.Sprintf(setDone, true, time.Now().UnixMilli(), id, *userID)); err != nil {
-		return nil, err
-	}
+		if !slices.Contains(fields, models.FieldTitle) && !slices.Contains(fields, models.FieldDescription) {
+			return nil
+		}
 
-	row, err := s.DB.Select(fmt.Sprintf(getTaskByID, id, *userID))
+		return s.index(ctx, updateSearch, task.Title, task.Description, task.ID, task.UserID)
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if row.GetNumberOfRows() == 0 {
-		return nil, models.ErrNotFound("task")
-	}
+	task.Version++
 
-	for i := uint64(0); i < row.GetNumberOfRows(); i++ {
-		task, err = populateTaskFields(row, i)
-		if err != nil {
-			return nil, err
-		}
+	logger.LogAttrs(ctx, slog.LevelDebug, "task patched successfully",
+		slog.String("task", task.ID))
+
+	return nil
+}
+
+// Delete moves the version of the task read to the trash, it stays indexed for search so a restore finds it again
+func (s *Store) Delete(ctx context.Context, id string, version int64, userID *uuid.UUID) error {
+	logger := models.GetLoggerFromCtx(ctx)
+
+	if err := s.writeVersion(ctx, trashTask, time.Now(), id, userID, version); err != nil {
+		return err
 	}
 
-	logger.LogAttrs(ctx, slog.LevelDebug, "task marked done",
-		slog.String("task", id), slog.String("user", userID.String()))
+	logger.LogAttrs(ctx, slog.LevelDebug, "task moved to trash", slog.String("task", id))
 
-	return task, nil
+	return nil
 }
 
-func populateTaskFields(rows *sqlitecloud.Result, r uint64) (*models.Task, error) {
-	var (
-		task models.Task
-		err  error
-	)
-
-	task.ID, err = rows.GetStringValue(r, 0) // taskID
+// Get returns a task of the user, trashed tasks are not found
+func (s *Store) Get(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	rows, err := s.conn(ctx).Select(getTaskByID, id, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	v2, err := rows.GetStringValue(r, 1) // userID
+	tasks, err := populateTasks(rows)
 	if err != nil {
 		return nil, err
 	}
 
-	task.Title, err = rows.GetStringValue(r, 2) // title
-	if err != nil {
-		return nil, err
+	if len(tasks) == 0 {
+		return nil, models.ErrNotFound("task")
 	}
 
-	task.Description, err = rows.GetStringValue(r, 3) // description
-	if err != nil {
+	if err := s.loadDetails(ctx, tasks); err != nil {
 		return nil, err
 	}
 
-	v4, err := rows.GetInt64Value(r, 4) // done status
-	if err != nil {
+	return &tasks[0], nil
+}
+
+func populateTaskFields(rows database.Result, r uint64) (*models.Task, error) {
+	var task models.Task
+
+	if err := database.ScanRow(rows, r, taskFields(&task)...); err != nil {
 		return nil, err
 	}
 
-	dd, err := rows.GetInt64Value(r, 5) // due date
-	if err != nil {
-		return nil, err
+	return &task, nil
+}
+
+// taskFields are the scan destinations of taskColumns
+func taskFields(task *models.Task) []any {
+	return []any{
+		&task.ID,
+		&task.UserID,
+		&task.Title,
+		&task.Description,
+		&task.IsDone,
+		&task.Status,
+		(*int)(&task.Priority),
+		&task.DueDate,
+		&task.HasDueTime,
+		&task.AddedAt,
+		&task.ModifiedAt,
+		&task.CompletedAt,
+		&task.DeletedAt,
+		&task.ListID,
+		&task.ParentID,
+		&task.Recurrence,
+		&task.Occurrence,
+		&task.Version,
 	}
+}
 
-	v5, err := rows.GetInt64Value(r, 6) // added time
-	if err != nil {
-		return nil, err
+// nullString stores an empty value as NULL
+func nullString(v string) any {
+	if v == "" {
+		return nil
 	}
 
-	v6 := rows.GetInt64Value_(r, 7) // modified time
+	return v
+}
 
-	task.UserID = uuid.MustParse(v2)
+// writeVersion runs a write guarded by the version of the task, no row returned means the task
+// changed or left since it was read
+func (s *Store) writeVersion(ctx context.Context, query string, args ...any) error {
+	rows, err := s.conn(ctx).Select(query, args...)
+	if err != nil {
+		return err
+	}
 
-	task.IsDone = (v4 == 1)
-	task.DueDate = dateRef(dd)
-	task.AddedAt = *dateRef(v5)
-	task.ModifiedAt = dateRef(v6)
+	if rows.GetNumberOfRows() == 0 {
+		return models.ErrVersionMismatch
+	}
 
-	return &task, nil
+	return nil
 }
 
-func dateRef(data int64) *time.Time {
-	if data == 0 {
-		return nil
-	}
+// writeDetail runs a write to the tags, reminders or blockers of a task, it counts as a write of the task
+func (s *Store) writeDetail(ctx context.Context, taskID, query string, args ...any) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.conn(ctx).Execute(query, args...); err != nil {
+			return err
+		}
 
-	t := time.UnixMilli(data)
+		return s.conn(ctx).Execute(bumpVersion, taskID)
+	})
+}
 
-	return &t
+// conn runs the query inside the caller's transaction when ctx carries one
+func (s *Store) conn(ctx context.Context) database.Querier {
+	return database.Conn(ctx, s.DB)
 }
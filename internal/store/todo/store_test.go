@@ -0,0 +1,672 @@
+package todostore_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"todoapp/internal/database"
+	"todoapp/internal/migrations"
+	"todoapp/internal/models"
+	"todoapp/internal/server"
+	memstore "todoapp/internal/store/memory"
+	todostore "todoapp/internal/store/todo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lister interface {
+	Create(ctx context.Context, task *models.Task) error
+	Update(ctx context.Context, task *models.Task) error
+	Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error
+	Delete(ctx context.Context, id string, version int64, userID *uuid.UUID) error
+	GetAll(ctx context.Context, q *models.TaskQuery, userID *uuid.UUID) ([]models.Task, error)
+	Search(ctx context.Context, terms []string, limit int, userID *uuid.UUID) ([]models.SearchResult, error)
+	ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error)
+	Restore(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	Get(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error)
+	AddRevision(ctx context.Context, rev *models.Revision) error
+	ListRevisions(ctx context.Context, taskID string) ([]models.Revision, error)
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	ListTags(ctx context.Context, userID *uuid.UUID) ([]models.Tag, error)
+	GetTagByName(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error
+	TagTask(ctx context.Context, taskID, tagID string) error
+	UntagTask(ctx context.Context, taskID, tagID string) error
+	CreateList(ctx context.Context, list *models.List) error
+	GetLists(ctx context.Context, archived bool, userID *uuid.UUID) ([]models.List, error)
+	GetList(ctx context.Context, id string, userID *uuid.UUID) (*models.List, error)
+	UpdateList(ctx context.Context, list *models.List) error
+	DeleteList(ctx context.Context, id string, userID *uuid.UUID) error
+	GetChildren(ctx context.Context, parentIDs []string, userID *uuid.UUID) ([]models.Task, error)
+	AddDependency(ctx context.Context, dep *models.Dependency) error
+	RemoveDependency(ctx context.Context, dep *models.Dependency) error
+	ListDependencies(ctx context.Context, userID *uuid.UUID) ([]models.Dependency, error)
+	AddReminder(ctx context.Context, rem *models.Reminder) error
+	RemoveReminder(ctx context.Context, rem *models.Reminder) error
+	DueReminders(ctx context.Context, now time.Time, limit int) ([]models.DueReminder, error)
+	MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error)
+	ResetReminders(ctx context.Context, taskID string, now time.Time) error
+	AddNotification(ctx context.Context, n *models.Notification) error
+	ListNotifications(ctx context.Context, limit int, userID *uuid.UUID) ([]models.Notification, error)
+	ReadNotification(ctx context.Context, id string, readAt time.Time, userID *uuid.UUID) error
+}
+
+// seed creates the same tasks in the SQL and the in-memory store
+func seed(t *testing.T) (map[string]lister, uuid.UUID, time.Time) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	db, err := database.Open(&database.Config{Driver: database.DriverSQLite, Path: filepath.Join(t.TempDir(), "todo.db")})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	require.NoError(t, migrations.RunMigrations(ctx, &server.Server{DB: db, Logger: slog.Default()}, "UP"))
+
+	stores := map[string]lister{"sqlite": todostore.New(db), "memory": memstore.NewTodoStore()}
+	user := uuid.New()
+	added := time.Now().Truncate(time.Millisecond)
+
+	for i := range 23 {
+		dd := added.AddDate(0, 0, i%4)
+		task := models.Task{
+			ID:       fmt.Sprintf("task-%02d", i),
+			UserID:   user,
+			Title:    fmt.Sprintf("Title_%d%%", i%5),
+			DueDate:  &dd,
+			AddedAt:  added.Add(time.Duration(i%7) * time.Second),
+			Status:   models.StatusTodo,
+			Priority: models.Priority(i % 5),
+		}
+
+		if i%3 == 0 {
+			task.SetStatus(models.StatusDone, added)
+		}
+
+		for _, st := range stores {
+			require.NoError(t, st.Create(ctx, &task))
+		}
+	}
+
+	return stores, user, added
+}
+
+// trash moves a task to the trash at its current version
+func trash(t *testing.T, st lister, id string, user uuid.UUID) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	task, err := st.Get(ctx, id, &user)
+	require.NoError(t, err)
+	require.NoError(t, st.Delete(ctx, id, task.Version, &user))
+}
+
+// TestGetAllPages walks every sort order page by page on the SQL and the in-memory store,
+// both must return each task exactly once and in the same order
+func TestGetAllPages(t *testing.T) {
+	ctx := context.Background()
+	stores, user, _ := seed(t)
+
+	for _, sort := range []string{"added", "-added", "due,title", "done,-due", "-title,-done", "-priority,due"} {
+		keys, err := models.ParseSort(sort)
+		require.NoError(t, err)
+
+		got := make(map[string][]string)
+
+		for name, st := range stores {
+			q := models.TaskQuery{Sort: keys, Limit: 5}
+
+			for {
+				tasks, err := st.GetAll(ctx, &q, &user)
+				require.NoError(t, err)
+
+				for i := range tasks {
+					got[name] = append(got[name], tasks[i].ID)
+				}
+
+				if len(tasks) < q.Limit {
+					break
+				}
+
+				q.After = models.NewCursor(keys, &tasks[len(tasks)-1])
+			}
+		}
+
+		assert.Len(t, got["sqlite"], 23, sort)
+		assert.Equal(t, got["memory"], got["sqlite"], sort)
+	}
+}
+
+func TestGetAllFilter(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+	done, dueAfter, addedBefore := true, added.AddDate(0, 0, 1), added.Add(3*time.Second)
+
+	// a cancelled task is neither done nor open
+	for _, st := range stores {
+		task, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err)
+
+		task.SetStatus(models.StatusCancelled, added)
+		require.NoError(t, st.Update(ctx, task))
+	}
+
+	tests := []struct {
+		name   string
+		filter models.TaskFilter
+		want   int
+	}{
+		{name: "no filter", want: 23},
+		{name: "done", filter: models.TaskFilter{Done: &done}, want: 8},
+		{name: "open leaves out cancelled tasks", filter: models.TaskFilter{Open: true}, want: 14},
+		{name: "due after", filter: models.TaskFilter{DueAfter: &dueAfter}, want: 11},
+		{name: "added before", filter: models.TaskFilter{AddedBefore: &addedBefore}, want: 11},
+		{name: "title contains is case insensitive", filter: models.TaskFilter{TitleContains: "title_3"}, want: 4},
+		{name: "like wildcards are literal", filter: models.TaskFilter{TitleContains: "_%"}, want: 0},
+		{name: "combined", filter: models.TaskFilter{Done: &done, DueAfter: &dueAfter, TitleContains: "%"}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := models.TaskQuery{Filter: tt.filter, Sort: []models.SortKey{{Field: models.SortAddedAt}}, Limit: 100}
+
+			for name, st := range stores {
+				tasks, err := st.GetAll(ctx, &q, &user)
+				require.NoError(t, err)
+				assert.Len(t, tasks, tt.want, name)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+	other := uuid.New()
+
+	fixtures := []models.Task{
+		{ID: "task-a", UserID: user, Title: "Quarterly report", Description: "numbers for Bob", DueDate: &added, AddedAt: added},
+		{ID: "task-b", UserID: user, Title: "Call Bob", Description: "about the quarterly <report>", DueDate: &added, AddedAt: added},
+		{ID: "task-c", UserID: other, Title: "Quarterly report", DueDate: &added, AddedAt: added},
+	}
+
+	for name, st := range stores {
+		tasks := slices.Clone(fixtures)
+
+		for i := range tasks {
+			tasks[i].Status = models.StatusTodo
+			require.NoError(t, st.Create(ctx, &tasks[i]), name)
+		}
+
+		res, err := st.Search(ctx, models.SearchTerms("REPO quart"), 10, &user)
+		require.NoError(t, err, name)
+		require.Len(t, res, 2, name)
+
+		assert.Equal(t, "task-a", res[0].Task.ID, "title matches rank first on %s", name)
+		assert.Equal(t, "<mark>Quarterly</mark> <mark>report</mark>", string(res[0].ToTaskResp(time.UTC).TitleHighlight), name)
+		assert.Contains(t, string(res[1].ToTaskResp(time.UTC).DescriptionHighlight), "&lt;<mark>report</mark>&gt;", name)
+
+		tasks[0].Title = "Yearly summary"
+		require.NoError(t, st.Update(ctx, &tasks[0]), name)
+		trash(t, st, "task-b", user)
+
+		res, err = st.Search(ctx, []string{"report"}, 10, &user)
+		require.NoError(t, err, name)
+		assert.Empty(t, res, name)
+
+		res, err = st.Search(ctx, []string{"yearly"}, 10, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, res, 1, name)
+	}
+}
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	stores, user, _ := seed(t)
+	all := models.TaskQuery{Sort: []models.SortKey{{Field: models.SortAddedAt}}, Limit: 100}
+
+	for name, st := range stores {
+		trash(t, st, "task-01", user)
+		trash(t, st, "task-02", user)
+
+		tasks, err := st.GetAll(ctx, &all, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, tasks, 21, name)
+
+		res, err := st.Search(ctx, models.SearchTerms("title_1"), 10, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, res, 4, "task-01 is trashed on %s", name)
+
+		trash, err := st.ListTrash(ctx, &user)
+		require.NoError(t, err, name)
+		require.Len(t, trash, 2, name)
+		assert.NotNil(t, trash[0].DeletedAt, name)
+
+		restored, err := st.Restore(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		assert.Nil(t, restored.DeletedAt, name)
+
+		_, err = st.Restore(ctx, "task-01", &user)
+		assert.Equal(t, models.ErrNotFound("task in trash"), err, name)
+
+		n, err := st.Purge(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err, name)
+		assert.Equal(t, int64(1), n, name)
+
+		_, err = st.Restore(ctx, "task-02", &user)
+		assert.Equal(t, models.ErrNotFound("task in trash"), err, "task-02 is purged on %s", name)
+
+		tasks, err = st.GetAll(ctx, &all, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, tasks, 22, name)
+	}
+}
+
+// TestPatch writes only the fields a partial update names, the rest of the task passed is ignored
+func TestPatch(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+	dd := added.AddDate(0, 1, 0)
+
+	for name, st := range stores {
+		task, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, int64(1), task.Version, name)
+
+		mt := added.Add(time.Minute)
+		task.Title, task.Priority, task.DueDate, task.HasDueTime, task.ModifiedAt = "Pay rent", models.PriorityUrgent, &dd, true, &mt
+		require.NoError(t, st.Patch(ctx, task, []models.TaskField{models.FieldDue}), name)
+
+		got, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, "Title_1%", got.Title, name)
+		assert.Equal(t, models.PriorityLow, got.Priority, name)
+		assert.True(t, dd.Equal(*got.DueDate), name)
+		assert.True(t, got.HasDueTime, name)
+		assert.True(t, mt.Equal(*got.ModifiedAt), name)
+		assert.Equal(t, int64(2), got.Version, "every write raises the version on %s", name)
+
+		require.NoError(t, st.Patch(ctx, task, []models.TaskField{models.FieldTitle}), name)
+
+		found, err := st.Search(ctx, []string{"rent"}, 10, &user)
+		require.NoError(t, err, name)
+		require.Len(t, found, 1, name)
+		assert.Equal(t, "task-01", found[0].Task.ID, name)
+	}
+}
+
+// TestVersion refuses a write made with a version another writer already moved past
+func TestVersion(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+
+	for name, st := range stores {
+		tag := models.Tag{ID: "tag-work", UserID: user, Name: "work", CreatedAt: added}
+		require.NoError(t, st.CreateTag(ctx, &tag), name)
+
+		mine, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+
+		theirs, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+
+		theirs.Title = "theirs"
+		require.NoError(t, st.Update(ctx, theirs), name)
+
+		mine.Title = "mine"
+		assert.Equal(t, models.ErrVersionMismatch, st.Update(ctx, mine), name)
+		assert.Equal(t, models.ErrVersionMismatch, st.Patch(ctx, mine, []models.TaskField{models.FieldTitle}), name)
+		assert.Equal(t, models.ErrVersionMismatch, st.Delete(ctx, "task-01", mine.Version, &user), name)
+
+		require.NoError(t, st.TagTask(ctx, "task-01", tag.ID), name)
+
+		got, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, "theirs", got.Title, name)
+		assert.Equal(t, theirs.Version+1, got.Version, "tagging raises the version on %s", name)
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	ctx := context.Background()
+	stores, user, _ := seed(t)
+	changedAt := time.Now().Truncate(time.Millisecond)
+
+	for name, st := range stores {
+		revs, err := st.ListRevisions(ctx, "task-01")
+		require.NoError(t, err, name)
+		assert.Empty(t, revs, name)
+
+		for _, title := range []string{"first", "second"} {
+			rev := models.Revision{TaskID: "task-01", UserID: user, ChangedAt: changedAt,
+				Changes: []models.FieldChange{{Field: "title", Old: "Title_1%", New: title}}}
+
+			require.NoError(t, st.AddRevision(ctx, &rev), name)
+		}
+
+		revs, err = st.ListRevisions(ctx, "task-01")
+		require.NoError(t, err, name)
+		require.Len(t, revs, 2, name)
+		assert.Equal(t, int64(2), revs[0].Number, name)
+		assert.Equal(t, "second", revs[0].Changes[0].New, name)
+		assert.True(t, changedAt.Equal(revs[1].ChangedAt), name)
+
+		_, err = st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+
+		trash(t, st, "task-01", user)
+
+		_, err = st.Get(ctx, "task-01", &user)
+		assert.Equal(t, models.ErrNotFound("task"), err, name)
+
+		_, err = st.Purge(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err, name)
+
+		revs, err = st.ListRevisions(ctx, "task-01")
+		require.NoError(t, err, name)
+		assert.Empty(t, revs, "revisions are purged with the task on %s", name)
+	}
+}
+
+// TestTags filters by tags on the SQL and the in-memory store: task-00 to task-04 are "work",
+// the even ones of them are also "home"
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+
+	ids := func(tasks []models.Task) []string {
+		res := make([]string, 0, len(tasks))
+		for i := range tasks {
+			res = append(res, tasks[i].ID)
+		}
+
+		return res
+	}
+
+	for name, st := range stores {
+		work := models.Tag{ID: "tag-work", UserID: user, Name: "work", CreatedAt: added}
+		home := models.Tag{ID: "tag-home", UserID: user, Name: "home", CreatedAt: added}
+		require.NoError(t, st.CreateTag(ctx, &work), name)
+		require.NoError(t, st.CreateTag(ctx, &home), name)
+
+		for i := range 5 {
+			require.NoError(t, st.TagTask(ctx, fmt.Sprintf("task-%02d", i), work.ID), name)
+			require.NoError(t, st.TagTask(ctx, fmt.Sprintf("task-%02d", i), work.ID), "tagging twice on %s", name)
+
+			if i%2 == 0 {
+				require.NoError(t, st.TagTask(ctx, fmt.Sprintf("task-%02d", i), home.ID), name)
+			}
+		}
+
+		q := models.TaskQuery{Sort: []models.SortKey{{Field: models.SortTitle}}, Limit: 100,
+			Filter: models.TaskFilter{Tags: []string{"home", "work"}}}
+
+		tasks, err := st.GetAll(ctx, &q, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, tasks, 5, name)
+
+		q.Filter.AllTags = true
+		tasks, err = st.GetAll(ctx, &q, &user)
+		require.NoError(t, err, name)
+		assert.ElementsMatch(t, []string{"task-00", "task-02", "task-04"}, ids(tasks), name)
+		assert.Equal(t, []string{"home", "work"}, models.TagNames(tasks[0].Tags), name)
+
+		require.NoError(t, st.UntagTask(ctx, "task-00", home.ID), name)
+		require.NoError(t, st.DeleteTag(ctx, work.ID, &user), name)
+
+		q.Filter = models.TaskFilter{Tags: []string{"home", "work"}}
+		tasks, err = st.GetAll(ctx, &q, &user)
+		require.NoError(t, err, name)
+		assert.ElementsMatch(t, []string{"task-02", "task-04"}, ids(tasks), name)
+
+		tags, err := st.ListTags(ctx, &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, []string{"home"}, models.TagNames(tags), name)
+
+		_, err = st.GetTagByName(ctx, "work", &user)
+		assert.Equal(t, models.ErrNotFound("tag"), err, name)
+	}
+}
+
+func TestLists(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+
+	for name, st := range stores {
+		work := models.List{ID: "list-work", UserID: user, Name: "work", Sort: "-priority,due", CreatedAt: added}
+		home := models.List{ID: "list-home", UserID: user, Name: "home", CreatedAt: added}
+		require.NoError(t, st.CreateList(ctx, &work), name)
+		require.NoError(t, st.CreateList(ctx, &home), name)
+
+		for i := range 4 {
+			task, err := st.Get(ctx, fmt.Sprintf("task-%02d", i), &user)
+			require.NoError(t, err, name)
+
+			task.ListID = work.ID
+			require.NoError(t, st.Update(ctx, task), name)
+		}
+
+		q := models.TaskQuery{Sort: []models.SortKey{{Field: models.SortTitle}}, Limit: 100,
+			Filter: models.TaskFilter{ListID: &work.ID}}
+
+		tasks, err := st.GetAll(ctx, &q, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, tasks, 4, name)
+		assert.Equal(t, work.ID, tasks[0].ListID, name)
+
+		inbox := ""
+		q.Filter.ListID = &inbox
+		tasks, err = st.GetAll(ctx, &q, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, tasks, 19, name)
+
+		archived := added.Add(time.Minute)
+		home.ArchivedAt = &archived
+		require.NoError(t, st.UpdateList(ctx, &home), name)
+
+		lists, err := st.GetLists(ctx, false, &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, []models.List{work}, lists, name)
+
+		lists, err = st.GetLists(ctx, true, &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, []models.List{home}, lists, name)
+
+		require.NoError(t, st.DeleteList(ctx, work.ID, &user), name)
+
+		_, err = st.GetList(ctx, work.ID, &user)
+		assert.Equal(t, models.ErrNotFound("list"), err, name)
+
+		tasks, err = st.GetAll(ctx, &q, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, tasks, 23, name)
+	}
+}
+
+func TestSubtasks(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+
+	for name, st := range stores {
+		// task-00 and task-03 are done
+		for i := range 4 {
+			task, err := st.Get(ctx, fmt.Sprintf("task-%02d", i), &user)
+			require.NoError(t, err, name)
+
+			task.ParentID = "task-05"
+			require.NoError(t, st.Update(ctx, task), name)
+		}
+
+		parent, err := st.Get(ctx, "task-05", &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, models.Progress{Completed: 2, Total: 4}, parent.Progress, name)
+
+		cancelled, err := st.Get(ctx, "task-02", &user)
+		require.NoError(t, err, name)
+
+		cancelled.SetStatus(models.StatusCancelled, added)
+		require.NoError(t, st.Update(ctx, cancelled), name)
+		trash(t, st, "task-01", user)
+
+		parent, err = st.Get(ctx, "task-05", &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, models.Progress{Completed: 2, Total: 2}, parent.Progress, name)
+
+		children, err := st.GetChildren(ctx, []string{"task-05", "task-06"}, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, children, 3, name)
+
+		q := models.TaskQuery{Sort: []models.SortKey{{Field: models.SortTitle}}, Limit: 100,
+			Filter: models.TaskFilter{TopLevel: true}}
+
+		tasks, err := st.GetAll(ctx, &q, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, tasks, 19, name)
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := context.Background()
+	stores, user, _ := seed(t)
+
+	for name, st := range stores {
+		// task-01 waits for task-00, done, and task-02, open
+		for _, blocker := range []string{"task-00", "task-02", "task-02"} {
+			require.NoError(t, st.AddDependency(ctx, &models.Dependency{TaskID: "task-01", BlockerID: blocker}), name)
+		}
+
+		deps, err := st.ListDependencies(ctx, &user)
+		require.NoError(t, err, name)
+		assert.Len(t, deps, 2, name)
+
+		task, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		assert.Len(t, task.BlockedBy, 2, name)
+		assert.Equal(t, models.TaskRefs{{ID: "task-02", Title: "Title_2%", Status: models.StatusTodo}}, task.BlockedBy.Open(), name)
+
+		trash(t, st, "task-02", user)
+
+		blocker, err := st.Get(ctx, "task-00", &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, models.TaskRefs{{ID: "task-01", Title: "Title_1%", Status: models.StatusTodo}}, blocker.Blocking, name)
+
+		task, err = st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		assert.Empty(t, task.BlockedBy.Open(), name)
+
+		require.NoError(t, st.RemoveDependency(ctx, &models.Dependency{TaskID: "task-01", BlockerID: "task-00"}), name)
+
+		deps, err = st.ListDependencies(ctx, &user)
+		require.NoError(t, err, name)
+		assert.Equal(t, []models.Dependency{{TaskID: "task-01", BlockerID: "task-02"}}, deps, name)
+	}
+}
+
+func TestReminders(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+	at := added.Add(time.Hour)
+
+	reminders := []models.Reminder{
+		// task-01 is due a day after added, task-00 is done
+		{ID: "rem-a", TaskID: "task-01", UserID: user, Before: 48 * time.Hour, CreatedAt: added},
+		{ID: "rem-b", TaskID: "task-02", UserID: user, At: &at, CreatedAt: added},
+		{ID: "rem-c", TaskID: "task-00", UserID: user, Before: time.Hour, CreatedAt: added},
+	}
+
+	ids := func(due []models.DueReminder) []string {
+		res := make([]string, 0, len(due))
+		for _, d := range due {
+			res = append(res, d.ID)
+		}
+
+		return res
+	}
+
+	for name, st := range stores {
+		for _, rem := range reminders {
+			require.NoError(t, st.AddReminder(ctx, &rem), name)
+		}
+
+		due, err := st.DueReminders(ctx, added, 10)
+		require.NoError(t, err, name)
+		assert.Equal(t, []string{"rem-a"}, ids(due), name)
+		assert.Equal(t, "Title_1%", due[0].Task.Title, name)
+
+		due, err = st.DueReminders(ctx, added.Add(2*time.Hour), 10)
+		require.NoError(t, err, name)
+		assert.Equal(t, []string{"rem-a", "rem-b"}, ids(due), name)
+
+		marked, err := st.MarkReminderSent(ctx, "rem-a", added)
+		require.NoError(t, err, name)
+		assert.True(t, marked, name)
+
+		marked, err = st.MarkReminderSent(ctx, "rem-a", added)
+		require.NoError(t, err, name)
+		assert.False(t, marked, name)
+
+		due, err = st.DueReminders(ctx, added.Add(2*time.Hour), 10)
+		require.NoError(t, err, name)
+		assert.Equal(t, []string{"rem-b"}, ids(due), name)
+
+		// moving the due date ten days out lets the sent reminder go off again
+		task, err := st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		require.Len(t, task.Reminders, 1, name)
+		assert.NotNil(t, task.Reminders[0].SentAt, name)
+		assert.Equal(t, 48*time.Hour, task.Reminders[0].Before, name)
+
+		dd := added.AddDate(0, 0, 10)
+		task.DueDate = &dd
+		require.NoError(t, st.Update(ctx, task), name)
+		require.NoError(t, st.ResetReminders(ctx, "task-01", added), name)
+
+		task, err = st.Get(ctx, "task-01", &user)
+		require.NoError(t, err, name)
+		assert.Nil(t, task.Reminders[0].SentAt, name)
+
+		require.NoError(t, st.RemoveReminder(ctx, &reminders[1]), name)
+
+		due, err = st.DueReminders(ctx, added.Add(2*time.Hour), 10)
+		require.NoError(t, err, name)
+		assert.Empty(t, due, name)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	ctx := context.Background()
+	stores, user, added := seed(t)
+	other := uuid.New()
+
+	for name, st := range stores {
+		for i := range 3 {
+			n := models.Notification{ID: fmt.Sprintf("ntf-%d", i), UserID: user, TaskID: "task-01", Title: "Reminder",
+				Body: "due", CreatedAt: added.Add(time.Duration(i) * time.Minute)}
+			require.NoError(t, st.AddNotification(ctx, &n), name)
+		}
+
+		list, err := st.ListNotifications(ctx, 2, &user)
+		require.NoError(t, err, name)
+		require.Len(t, list, 2, name)
+		assert.Equal(t, "ntf-2", list[0].ID, name)
+		assert.Nil(t, list[0].ReadAt, name)
+
+		require.NoError(t, st.ReadNotification(ctx, "ntf-2", added, &user), name)
+		assert.Equal(t, models.ErrNotFound("notification"), st.ReadNotification(ctx, "ntf-1", added, &other), name)
+
+		list, err = st.ListNotifications(ctx, 2, &user)
+		require.NoError(t, err, name)
+		assert.NotNil(t, list[0].ReadAt, name)
+		assert.Nil(t, list[1].ReadAt, name)
+	}
+}
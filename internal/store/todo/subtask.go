@@ -0,0 +1,98 @@
+package todostore
+
+import (
+	"context"
+	"strings"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	listChildren  = "SELECT " + taskColumns + " FROM tasks WHERE user_id=? AND deleted_at IS NULL AND parent_id IN "
+	countChildren = "SELECT parent_id, COUNT(*), SUM(CASE WHEN status='done' THEN 1 ELSE 0 END) FROM tasks " +
+		"WHERE deleted_at IS NULL AND status<>'cancelled' AND parent_id IN "
+)
+
+// GetChildren returns the direct subtasks of the given tasks of the user, in no particular order
+func (s *Store) GetChildren(ctx context.Context, parentIDs []string, userID *uuid.UUID) ([]models.Task, error) {
+	if len(parentIDs) == 0 {
+		return make([]models.Task, 0), nil
+	}
+
+	args := []any{userID}
+	for _, id := range parentIDs {
+		args = append(args, id)
+	}
+
+	rows, err := s.conn(ctx).Select(listChildren+placeholders(len(parentIDs))+";", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := populateTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, s.loadDetails(ctx, tasks)
+}
+
+// loadDetails sets what is kept outside the tasks table on every task: its tags, subtask progress,
+// dependencies and reminders
+func (s *Store) loadDetails(ctx context.Context, tasks []models.Task) error {
+	loads := []func(context.Context, []models.Task) error{s.loadTags, s.loadProgress, s.loadDependencies, s.loadReminders}
+
+	for _, load := range loads {
+		if err := load(ctx, tasks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadProgress counts the subtasks of every task with a single query
+func (s *Store) loadProgress(ctx context.Context, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Task, len(tasks))
+	args := make([]any, 0, len(tasks))
+
+	for i := range tasks {
+		tasks[i].Progress = models.Progress{}
+		byID[tasks[i].ID] = &tasks[i]
+		args = append(args, tasks[i].ID)
+	}
+
+	rows, err := s.conn(ctx).Select(countChildren+placeholders(len(tasks))+" GROUP BY parent_id;", args...)
+	if err != nil {
+		return err
+	}
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var (
+			parentID string
+			p        models.Progress
+		)
+
+		if err := database.ScanRow(rows, row, &parentID, &p.Total, &p.Completed); err != nil {
+			return err
+		}
+
+		if task, ok := byID[parentID]; ok {
+			task.Progress = p
+		}
+	}
+
+	return nil
+}
+
+// placeholders is the "(?, ?, ...)" list of n bound values
+func placeholders(n int) string {
+	return "(?" + strings.Repeat(", ?", n-1) + ")"
+}
@@ -0,0 +1,153 @@
+package todostore
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	tagColumns    = "id, user_id, name, created_at"
+	insertTag     = "INSERT INTO tags (id, user_id, name, created_at) VALUES (?, ?, ?, ?);"
+	listTags      = "SELECT " + tagColumns + " FROM tags WHERE user_id=? ORDER BY name ASC;"
+	getTagByID    = "SELECT " + tagColumns + " FROM tags WHERE id=? AND user_id=?;"
+	getTagByName  = "SELECT " + tagColumns + " FROM tags WHERE name=? AND user_id=?;"
+	renameTag     = "UPDATE tags SET name=? WHERE id=? AND user_id=?;"
+	untagAll      = "DELETE FROM task_tags WHERE tag_id IN (SELECT id FROM tags WHERE id=? AND user_id=?);"
+	deleteTag     = "DELETE FROM tags WHERE id=? AND user_id=?;"
+	tagTask       = "INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING;"
+	untagTask     = "DELETE FROM task_tags WHERE task_id=? AND tag_id=?;"
+	listTaskTags  = "SELECT tt.task_id, t.id, t.user_id, t.name, t.created_at FROM task_tags tt JOIN tags t ON t.id=tt.tag_id "
+	orderTaskTags = " ORDER BY t.name ASC;"
+)
+
+func (s *Store) CreateTag(ctx context.Context, tag *models.Tag) error {
+	if err := s.conn(ctx).Execute(insertTag, tag.ID, tag.UserID, tag.Name, tag.CreatedAt); err != nil {
+		return err
+	}
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelDebug, "tag created", slog.String("tag", tag.ID))
+
+	return nil
+}
+
+// ListTags returns the user's tags ordered by name
+func (s *Store) ListTags(ctx context.Context, userID *uuid.UUID) ([]models.Tag, error) {
+	rows, err := s.conn(ctx).Select(listTags, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return populateTags(rows)
+}
+
+func (s *Store) GetTag(ctx context.Context, id string, userID *uuid.UUID) (*models.Tag, error) {
+	return s.getTag(ctx, getTagByID, id, userID)
+}
+
+func (s *Store) GetTagByName(ctx context.Context, name string, userID *uuid.UUID) (*models.Tag, error) {
+	return s.getTag(ctx, getTagByName, name, userID)
+}
+
+func (s *Store) RenameTag(ctx context.Context, tag *models.Tag) error {
+	return s.conn(ctx).Execute(renameTag, tag.Name, tag.ID, tag.UserID)
+}
+
+// DeleteTag deletes the tag and takes it off every task carrying it
+func (s *Store) DeleteTag(ctx context.Context, id string, userID *uuid.UUID) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.conn(ctx).Execute(untagAll, id, userID); err != nil {
+			return err
+		}
+
+		return s.conn(ctx).Execute(deleteTag, id, userID)
+	})
+}
+
+// TagTask attaches the tag to the task, attaching it twice does nothing
+func (s *Store) TagTask(ctx context.Context, taskID, tagID string) error {
+	return s.writeDetail(ctx, taskID, tagTask, taskID, tagID)
+}
+
+func (s *Store) UntagTask(ctx context.Context, taskID, tagID string) error {
+	return s.writeDetail(ctx, taskID, untagTask, taskID, tagID)
+}
+
+func (s *Store) getTag(ctx context.Context, query string, args ...any) (*models.Tag, error) {
+	rows, err := s.conn(ctx).Select(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := populateTags(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, models.ErrNotFound("tag")
+	}
+
+	return &tags[0], nil
+}
+
+// loadTags sets the tags of every task with a single query
+func (s *Store) loadTags(ctx context.Context, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Task, len(tasks))
+	args := make([]any, 0, len(tasks))
+
+	for i := range tasks {
+		tasks[i].Tags = make([]models.Tag, 0)
+		byID[tasks[i].ID] = &tasks[i]
+		args = append(args, tasks[i].ID)
+	}
+
+	query := listTaskTags + "WHERE tt.task_id IN (?" + strings.Repeat(", ?", len(tasks)-1) + ")" + orderTaskTags
+
+	rows, err := s.conn(ctx).Select(query, args...)
+	if err != nil {
+		return err
+	}
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var (
+			taskID string
+			tag    models.Tag
+		)
+
+		if err := database.ScanRow(rows, row, &taskID, &tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt); err != nil {
+			return err
+		}
+
+		if task, ok := byID[taskID]; ok {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	return nil
+}
+
+func populateTags(rows database.Result) ([]models.Tag, error) {
+	res := make([]models.Tag, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var tag models.Tag
+
+		if err := database.ScanRow(rows, row, &tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, tag)
+	}
+
+	return res, nil
+}
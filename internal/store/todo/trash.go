@@ -0,0 +1,130 @@
+package todostore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	listTrash = "SELECT " + taskColumns + " FROM tasks WHERE user_id=? AND deleted_at IS NOT NULL " +
+		"ORDER BY deleted_at DESC, id ASC;"
+	getTrashedTask = "SELECT " + taskColumns + " FROM tasks WHERE id=? AND user_id=? AND deleted_at IS NOT NULL;"
+	restoreTask    = "UPDATE tasks SET deleted_at=NULL, modified_at=?, version=version+1 WHERE id=? AND user_id=?;"
+	countPurgeable = "SELECT COUNT(*) FROM tasks WHERE deleted_at<?;"
+	purgeSearch    = "DELETE FROM tasks_fts WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at<?);"
+	purgeRevisions = "DELETE FROM task_revisions WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at<?);"
+	purgeTaskTags  = "DELETE FROM task_tags WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at<?);"
+	purgeTasks     = "DELETE FROM tasks WHERE deleted_at<?;"
+)
+
+// ListTrash returns the user's trashed tasks, most recently deleted first
+func (s *Store) ListTrash(ctx context.Context, userID *uuid.UUID) ([]models.Task, error) {
+	rows, err := s.conn(ctx).Select(listTrash, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := populateTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, s.loadDetails(ctx, tasks)
+}
+
+// Restore takes a task out of the trash
+func (s *Store) Restore(ctx context.Context, id string, userID *uuid.UUID) (*models.Task, error) {
+	var task *models.Task
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rows, err := s.conn(ctx).Select(getTrashedTask, id, userID)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := populateTasks(rows)
+		if err != nil {
+			return err
+		}
+
+		if len(tasks) == 0 {
+			return models.ErrNotFound("task in trash")
+		}
+
+		if err := s.loadDetails(ctx, tasks); err != nil {
+			return err
+		}
+
+		task = &tasks[0]
+		mt := time.Now()
+		task.DeletedAt, task.ModifiedAt = nil, &mt
+		task.Version++
+
+		return s.conn(ctx).Execute(restoreTask, mt, id, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelDebug, "task restored from trash", slog.String("task", id))
+
+	return task, nil
+}
+
+// Purge permanently deletes the tasks of every user trashed before the given time
+func (s *Store) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rows, err := s.conn(ctx).Select(countPurgeable, before)
+		if err != nil {
+			return err
+		}
+
+		if n, err = rows.GetInt64Value(0, 0); err != nil || n == 0 {
+			return err
+		}
+
+		if err := s.index(ctx, purgeSearch, before); err != nil {
+			return err
+		}
+
+		for _, query := range []string{purgeRevisions, purgeTaskTags, purgeReminders} {
+			if err := s.conn(ctx).Execute(query, before); err != nil {
+				return err
+			}
+		}
+
+		if err := s.conn(ctx).Execute(purgeDependencies, before, before); err != nil {
+			return err
+		}
+
+		return s.conn(ctx).Execute(purgeTasks, before)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func populateTasks(rows database.Result) ([]models.Task, error) {
+	res := make([]models.Task, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		task, err := populateTaskFields(rows, row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *task)
+	}
+
+	return res, nil
+}
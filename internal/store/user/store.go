@@ -2,24 +2,27 @@ package userstore
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
+
+	"todoapp/internal/database"
 	"todoapp/internal/models"
 
 	"github.com/google/uuid"
-	"github.com/sqlitecloud/sqlitecloud-go"
 )
 
 const (
-	getUser       = "SELECT id, name, email, password FROM users WHERE email='%s';"
-	registerQuery = "INSERT INTO users(id, name, email, password) VALUES ('%v','%v','%v','%v');"
+	userColumns   = "id, name, email, password, time_zone"
+	getUser       = "SELECT " + userColumns + " FROM users WHERE email=?;"
+	getUserByID   = "SELECT " + userColumns + " FROM users WHERE id=?;"
+	registerQuery = "INSERT INTO users(id, name, email, password, time_zone) VALUES (?, ?, ?, ?, ?);"
+	setTimeZone   = "UPDATE users SET time_zone=? WHERE id=?;"
 )
 
 type Store struct {
-	DB *sqlitecloud.SQCloud
+	DB database.DB
 }
 
-func New(db *sqlitecloud.SQCloud) *Store {
+func New(db database.DB) *Store {
 	return &Store{
 		DB: db,
 	}
@@ -28,8 +31,7 @@ func New(db *sqlitecloud.SQCloud) *Store {
 func (s *Store) RegisterUser(ctx context.Context, data *models.UserData) error {
 	logger := models.GetLoggerFromCtx(ctx)
 
-	query := fmt.Sprintf(registerQuery, data.ID, data.Name, data.Email, data.Password)
-	if err := s.DB.Execute(query); err != nil {
+	if err := s.conn(ctx).Execute(registerQuery, data.ID, data.Name, data.Email, data.Password, data.TimeZone); err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error while running Register query",
 			slog.String("error", err.Error()),
 		)
@@ -43,7 +45,7 @@ func (s *Store) RegisterUser(ctx context.Context, data *models.UserData) error {
 func (s *Store) GetUserByEmail(ctx context.Context, email string) (*models.UserData, error) {
 	logger := models.GetLoggerFromCtx(ctx)
 
-	res, err := s.DB.Select(fmt.Sprintf(getUser, email))
+	res, err := s.conn(ctx).Select(getUser, email)
 	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "error in fetching user by email",
 			slog.String("error", err.Error()),
@@ -55,7 +57,33 @@ func (s *Store) GetUserByEmail(ctx context.Context, email string) (*models.UserD
 	return populateUserFields(res)
 }
 
-func populateUserFields(res *sqlitecloud.Result) (*models.UserData, error) {
+// GetUserByID returns the user with the given id
+func (s *Store) GetUserByID(ctx context.Context, id *uuid.UUID) (*models.UserData, error) {
+	res, err := s.conn(ctx).Select(getUserByID, id)
+	if err != nil {
+		models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelError, "error in fetching user by id",
+			slog.String("error", err.Error()),
+		)
+
+		return nil, err
+	}
+
+	return populateUserFields(res)
+}
+
+// SetTimeZone changes the time zone the user's due dates are shown in
+func (s *Store) SetTimeZone(ctx context.Context, id *uuid.UUID, timeZone string) error {
+	if err := s.conn(ctx).Execute(setTimeZone, timeZone, id); err != nil {
+		return err
+	}
+
+	models.GetLoggerFromCtx(ctx).LogAttrs(ctx, slog.LevelDebug, "user time zone changed",
+		slog.String("user", id.String()), slog.String("timeZone", timeZone))
+
+	return nil
+}
+
+func populateUserFields(res database.Result) (*models.UserData, error) {
 	var user models.UserData
 
 	if res.GetNumberOfRows() == 0 {
@@ -63,31 +91,15 @@ func populateUserFields(res *sqlitecloud.Result) (*models.UserData, error) {
 	}
 
 	for r := uint64(0); r < res.GetNumberOfRows(); r++ {
-		c1, err := res.GetStringValue(r, 0)
-		if err != nil {
-			return nil, err
-		}
-
-		c2, err := res.GetStringValue(r, 1)
-		if err != nil {
+		if err := database.ScanRow(res, r, &user.ID, &user.Name, &user.Email, &user.Password, &user.TimeZone); err != nil {
 			return nil, err
 		}
-
-		c3, err := res.GetStringValue(r, 2)
-		if err != nil {
-			return nil, err
-		}
-
-		c4, err := res.GetStringValue(r, 3)
-		if err != nil {
-			return nil, err
-		}
-
-		user.ID = uuid.MustParse(c1)
-		user.Name = c2
-		user.Email = c3
-		user.Password = c4
 	}
 
 	return &user, nil
 }
+
+// conn runs the query inside the caller's transaction when ctx carries one
+func (s *Store) conn(ctx context.Context) database.Querier {
+	return database.Conn(ctx, s.DB)
+}
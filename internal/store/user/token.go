@@ -0,0 +1,100 @@
+package userstore
+
+import (
+	"context"
+	"time"
+
+	"todoapp/internal/database"
+	"todoapp/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	tokenColumns     = "id, user_id, name, token_hash, scopes, created_at, last_used_at"
+	createToken      = "INSERT INTO access_tokens (" + tokenColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?);"
+	listTokens       = "SELECT " + tokenColumns + " FROM access_tokens WHERE user_id=? ORDER BY created_at, id;"
+	getTokenByHash   = "SELECT " + tokenColumns + " FROM access_tokens WHERE token_hash=?;"
+	countUserToken   = "SELECT COUNT(*) FROM access_tokens WHERE id=? AND user_id=?;"
+	deleteToken      = "DELETE FROM access_tokens WHERE id=? AND user_id=?;"
+	touchTokenAccess = "UPDATE access_tokens SET last_used_at=? WHERE id=?;"
+)
+
+func (s *Store) CreateToken(ctx context.Context, t *models.AccessToken) error {
+	return s.conn(ctx).Execute(createToken, t.ID, t.UserID, t.Name, t.Hash, models.FormatScopes(t.Scopes), t.CreatedAt, t.LastUsedAt)
+}
+
+// ListTokens returns the user's access tokens, the oldest first
+func (s *Store) ListTokens(ctx context.Context, userID *uuid.UUID) ([]models.AccessToken, error) {
+	rows, err := s.conn(ctx).Select(listTokens, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTokens(rows)
+}
+
+// GetTokenByHash returns the access token whose token hashes to hash
+func (s *Store) GetTokenByHash(ctx context.Context, hash string) (*models.AccessToken, error) {
+	rows, err := s.conn(ctx).Select(getTokenByHash, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := scanTokens(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, models.ErrNotFound("token")
+	}
+
+	return &tokens[0], nil
+}
+
+// DeleteToken revokes an access token of the user, run it in a transaction so the token can't
+// change between the check and the delete
+func (s *Store) DeleteToken(ctx context.Context, id string, userID *uuid.UUID) error {
+	rows, err := s.conn(ctx).Select(countUserToken, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := rows.GetInt64Value(0, 0)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return models.ErrNotFound("token")
+	}
+
+	return s.conn(ctx).Execute(deleteToken, id, userID)
+}
+
+// TouchToken records that the token was used at at
+func (s *Store) TouchToken(ctx context.Context, id string, at time.Time) error {
+	return s.conn(ctx).Execute(touchTokenAccess, at, id)
+}
+
+func scanTokens(rows database.Result) ([]models.AccessToken, error) {
+	res := make([]models.AccessToken, 0, rows.GetNumberOfRows())
+
+	for row := uint64(0); row < rows.GetNumberOfRows(); row++ {
+		var (
+			t      models.AccessToken
+			scopes string
+		)
+
+		err := database.ScanRow(rows, row, &t.ID, &t.UserID, &t.Name, &t.Hash, &scopes, &t.CreatedAt, &t.LastUsedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Scopes = models.SplitScopes(scopes)
+		res = append(res, t)
+	}
+
+	return res, nil
+}
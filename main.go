@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	// the users' time zones are looked up even where the image has no zoneinfo
+	_ "time/tzdata"
 
 	"todoapp/cmd"
 )